@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"iter"
 	"net/url"
 
 	"github.com/cloudfoundry/go-cfclient/v3/internal/path"
@@ -108,6 +109,16 @@ func (c *AppClient) GetEnvironmentVariables(ctx context.Context, guid string) (m
 	return appEnv.Var, nil
 }
 
+// Iter returns an iterator over all apps the user has access to, fetching each page only as needed
+func (c *AppClient) Iter(ctx context.Context, opts *AppListOptions) iter.Seq2[*resource.App, error] {
+	if opts == nil {
+		opts = NewAppListOptions()
+	}
+	return AutoPageIter[*AppListOptions, *resource.App](ctx, opts, func(opts *AppListOptions) ([]*resource.App, *Pager, error) {
+		return c.List(ctx, opts)
+	})
+}
+
 // List pages all the apps the user has access to
 func (c *AppClient) List(ctx context.Context, opts *AppListOptions) ([]*resource.App, *Pager, error) {
 	if opts == nil {
@@ -129,7 +140,7 @@ func (c *AppClient) ListAll(ctx context.Context, opts *AppListOptions) ([]*resou
 	if opts == nil {
 		opts = NewAppListOptions()
 	}
-	return AutoPage[*AppListOptions, *resource.App](opts, func(opts *AppListOptions) ([]*resource.App, *Pager, error) {
+	return autoPage[*AppListOptions, *resource.App](ctx, c.client, opts, func(ctx context.Context, opts *AppListOptions) ([]*resource.App, *Pager, error) {
 		return c.List(ctx, opts)
 	})
 }
@@ -156,21 +167,9 @@ func (c *AppClient) ListIncludeSpacesAll(ctx context.Context, opts *AppListOptio
 		opts = NewAppListOptions()
 	}
 
-	var all []*resource.App
-	var allSpaces []*resource.Space
-	for {
-		page, spaces, pager, err := c.ListIncludeSpaces(ctx, opts)
-		if err != nil {
-			return nil, nil, err
-		}
-		all = append(all, page...)
-		allSpaces = append(allSpaces, spaces...)
-		if !pager.HasNextPage() {
-			break
-		}
-		pager.NextPage(opts)
-	}
-	return all, allSpaces, nil
+	return autoPageInclude(ctx, c.client, opts, func(ctx context.Context, opts *AppListOptions) ([]*resource.App, []*resource.Space, *Pager, error) {
+		return c.ListIncludeSpaces(ctx, opts)
+	})
 }
 
 // ListIncludeSpacesAndOrganizations page all apps the user has access to and include the associated spaces and organizations
@@ -195,23 +194,9 @@ func (c *AppClient) ListIncludeSpacesAndOrganizationsAll(ctx context.Context, op
 		opts = NewAppListOptions()
 	}
 
-	var all []*resource.App
-	var allSpaces []*resource.Space
-	var allOrgs []*resource.Organization
-	for {
-		page, spaces, orgs, pager, err := c.ListIncludeSpacesAndOrganizations(ctx, opts)
-		if err != nil {
-			return nil, nil, nil, err
-		}
-		all = append(all, page...)
-		allSpaces = append(allSpaces, spaces...)
-		allOrgs = append(allOrgs, orgs...)
-		if !pager.HasNextPage() {
-			break
-		}
-		pager.NextPage(opts)
-	}
-	return all, allSpaces, allOrgs, nil
+	return autoPageInclude2(ctx, c.client, opts, func(ctx context.Context, opts *AppListOptions) ([]*resource.App, []*resource.Space, []*resource.Organization, *Pager, error) {
+		return c.ListIncludeSpacesAndOrganizations(ctx, opts)
+	})
 }
 
 // Permissions gets the current user’s permissions for the given app.
@@ -2,9 +2,11 @@ package client
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"testing"
 
+	"github.com/cloudfoundry/go-cfclient/v3/config"
 	"github.com/cloudfoundry/go-cfclient/v3/resource"
 	"github.com/stretchr/testify/require"
 
@@ -183,6 +185,25 @@ func TestApps(t *testing.T) {
 				return c.Applications.ListAll(context.Background(), nil)
 			},
 		},
+		{
+			Description: "Iterate all apps",
+			Route: testutil.MockRoute{
+				Method:   "GET",
+				Endpoint: "/v3/apps",
+				Output:   g.Paged([]string{app1, app2}, []string{app3, app4}),
+				Status:   http.StatusOK},
+			Expected: g.Array(app1, app2, app3, app4),
+			Action: func(c *Client, t *testing.T) (any, error) {
+				var apps []*resource.App
+				for app, err := range c.Applications.Iter(context.Background(), nil) {
+					if err != nil {
+						return nil, err
+					}
+					apps = append(apps, app)
+				}
+				return apps, nil
+			},
+		},
 		{
 			Description: "List all apps include spaces",
 			Route: testutil.MockRoute{
@@ -359,3 +380,73 @@ func TestApps(t *testing.T) {
 	}
 	ExecuteTests(tests, t)
 }
+
+func TestAppListAllConcurrent(t *testing.T) {
+	g := testutil.NewObjectJSONGenerator()
+	app1 := g.Application().JSON
+	app2 := g.Application().JSON
+	app3 := g.Application().JSON
+
+	serverURL := testutil.Setup(testutil.MockRoute{
+		Method:   "GET",
+		Endpoint: "/v3/apps",
+		Output:   g.Paged([]string{app1, app2}, []string{app3}),
+		Status:   http.StatusOK,
+	}, t)
+	defer testutil.Teardown()
+
+	cfg, err := config.New(serverURL, config.Token("", "fake-refresh-token"), config.ListAllConcurrency(4))
+	require.NoError(t, err)
+	c, err := New(cfg)
+	require.NoError(t, err)
+
+	apps, err := c.Applications.ListAll(context.Background(), nil)
+	require.NoError(t, err)
+	actual, err := json.Marshal(apps)
+	require.NoError(t, err)
+	require.JSONEq(t, g.Array(app1, app2, app3), string(actual))
+}
+
+func TestAppListIncludeSpacesAndOrganizationsAllConcurrent(t *testing.T) {
+	g := testutil.NewObjectJSONGenerator()
+	app1 := g.Application().JSON
+	app2 := g.Application().JSON
+	app3 := g.Application().JSON
+	space1 := g.Space().JSON
+	space2 := g.Space().JSON
+	org := g.Organization().JSON
+
+	serverURL := testutil.Setup(testutil.MockRoute{
+		Method:   "GET",
+		Endpoint: "/v3/apps",
+		Output: g.PagedWithInclude(
+			testutil.PagedResult{
+				Resources:     []string{app1, app2},
+				Spaces:        []string{space1},
+				Organizations: []string{org},
+			},
+			testutil.PagedResult{
+				Resources: []string{app3},
+				Spaces:    []string{space2},
+			}),
+		Status: http.StatusOK,
+	}, t)
+	defer testutil.Teardown()
+
+	cfg, err := config.New(serverURL, config.Token("", "fake-refresh-token"), config.ListAllConcurrency(4))
+	require.NoError(t, err)
+	c, err := New(cfg)
+	require.NoError(t, err)
+
+	apps, spaces, orgs, err := c.Applications.ListIncludeSpacesAndOrganizationsAll(context.Background(), nil)
+	require.NoError(t, err)
+	actual, err := json.Marshal(apps)
+	require.NoError(t, err)
+	require.JSONEq(t, g.Array(app1, app2, app3), string(actual))
+	actual, err = json.Marshal(spaces)
+	require.NoError(t, err)
+	require.JSONEq(t, g.Array(space1, space2), string(actual))
+	actual, err = json.Marshal(orgs)
+	require.NoError(t, err)
+	require.JSONEq(t, g.Array(org), string(actual))
+}
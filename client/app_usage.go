@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"iter"
 	"net/url"
 
 	"github.com/cloudfoundry/go-cfclient/v3/internal/path"
@@ -39,6 +40,16 @@ func (c *AppUsageClient) Get(ctx context.Context, guid string) (*resource.AppUsa
 	return &a, nil
 }
 
+// Iter returns an iterator over all app usage events, fetching each page only as needed
+func (c *AppUsageClient) Iter(ctx context.Context, opts *AppUsageListOptions) iter.Seq2[*resource.AppUsage, error] {
+	if opts == nil {
+		opts = NewAppUsageOptions()
+	}
+	return AutoPageIter[*AppUsageListOptions, *resource.AppUsage](ctx, opts, func(opts *AppUsageListOptions) ([]*resource.AppUsage, *Pager, error) {
+		return c.List(ctx, opts)
+	})
+}
+
 // List pages all app usage events
 func (c *AppUsageClient) List(ctx context.Context, opts *AppUsageListOptions) ([]*resource.AppUsage, *Pager, error) {
 	if opts == nil {
@@ -58,7 +69,7 @@ func (c *AppUsageClient) ListAll(ctx context.Context, opts *AppUsageListOptions)
 	if opts == nil {
 		opts = NewAppUsageOptions()
 	}
-	return AutoPage[*AppUsageListOptions, *resource.AppUsage](opts, func(opts *AppUsageListOptions) ([]*resource.AppUsage, *Pager, error) {
+	return autoPage[*AppUsageListOptions, *resource.AppUsage](ctx, c.client, opts, func(ctx context.Context, opts *AppUsageListOptions) ([]*resource.AppUsage, *Pager, error) {
 		return c.List(ctx, opts)
 	})
 }
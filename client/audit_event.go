@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"iter"
 	"net/url"
 
 	"github.com/cloudfoundry/go-cfclient/v3/internal/path"
@@ -48,6 +49,16 @@ func (c *AuditEventClient) Get(ctx context.Context, guid string) (*resource.Audi
 	return &a, nil
 }
 
+// Iter returns an iterator over all audit events the user has access to, fetching each page only as needed
+func (c *AuditEventClient) Iter(ctx context.Context, opts *AuditEventListOptions) iter.Seq2[*resource.AuditEvent, error] {
+	if opts == nil {
+		opts = NewAuditEventListOptions()
+	}
+	return AutoPageIter[*AuditEventListOptions, *resource.AuditEvent](ctx, opts, func(opts *AuditEventListOptions) ([]*resource.AuditEvent, *Pager, error) {
+		return c.List(ctx, opts)
+	})
+}
+
 // List pages all audit events the user has access to
 func (c *AuditEventClient) List(ctx context.Context, opts *AuditEventListOptions) ([]*resource.AuditEvent, *Pager, error) {
 	if opts == nil {
@@ -67,20 +78,9 @@ func (c *AuditEventClient) ListAll(ctx context.Context, opts *AuditEventListOpti
 	if opts == nil {
 		opts = NewAuditEventListOptions()
 	}
-
-	var all []*resource.AuditEvent
-	for {
-		page, pager, err := c.List(ctx, opts)
-		if err != nil {
-			return nil, err
-		}
-		all = append(all, page...)
-		if !pager.HasNextPage() {
-			break
-		}
-		pager.NextPage(opts)
-	}
-	return all, nil
+	return autoPage[*AuditEventListOptions, *resource.AuditEvent](ctx, c.client, opts, func(ctx context.Context, opts *AuditEventListOptions) ([]*resource.AuditEvent, *Pager, error) {
+		return c.List(ctx, opts)
+	})
 }
 
 // Single returns a single audit event matching the options or an error if not exactly 1 match
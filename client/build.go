@@ -2,7 +2,12 @@ package client
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"iter"
 	"net/url"
+	"strings"
+	"time"
 
 	"github.com/cloudfoundry/go-cfclient/v3/internal/path"
 	"github.com/cloudfoundry/go-cfclient/v3/resource"
@@ -88,6 +93,16 @@ func (c *BuildClient) Get(ctx context.Context, guid string) (*resource.Build, er
 	return &build, nil
 }
 
+// Iter returns an iterator over all builds the user has access to, fetching each page only as needed
+func (c *BuildClient) Iter(ctx context.Context, opts *BuildListOptions) iter.Seq2[*resource.Build, error] {
+	if opts == nil {
+		opts = NewBuildListOptions()
+	}
+	return AutoPageIter[*BuildListOptions, *resource.Build](ctx, opts, func(opts *BuildListOptions) ([]*resource.Build, *Pager, error) {
+		return c.List(ctx, opts)
+	})
+}
+
 // List pages all builds the user has access to
 func (c *BuildClient) List(ctx context.Context, opts *BuildListOptions) ([]*resource.Build, *Pager, error) {
 	if opts == nil {
@@ -107,7 +122,7 @@ func (c *BuildClient) ListAll(ctx context.Context, opts *BuildListOptions) ([]*r
 	if opts == nil {
 		opts = NewBuildListOptions()
 	}
-	return AutoPage[*BuildListOptions, *resource.Build](opts, func(opts *BuildListOptions) ([]*resource.Build, *Pager, error) {
+	return autoPage[*BuildListOptions, *resource.Build](ctx, c.client, opts, func(ctx context.Context, opts *BuildListOptions) ([]*resource.Build, *Pager, error) {
 		return c.List(ctx, opts)
 	})
 }
@@ -131,23 +146,32 @@ func (c *BuildClient) ListForAppAll(ctx context.Context, appGUID string, opts *B
 	if opts == nil {
 		opts = NewBuildAppListOptions()
 	}
-	return AutoPage[*BuildAppListOptions, *resource.Build](opts, func(opts *BuildAppListOptions) ([]*resource.Build, *Pager, error) {
+	return autoPage[*BuildAppListOptions, *resource.Build](ctx, c.client, opts, func(ctx context.Context, opts *BuildAppListOptions) ([]*resource.Build, *Pager, error) {
 		return c.ListForApp(ctx, appGUID, opts)
 	})
 }
 
-// PollStaged waits until the build is staged, fails, or times out
+// PollStaged waits until the build is staged, fails, times out, or the context is done
 func (c *BuildClient) PollStaged(ctx context.Context, guid string, opts *PollingOptions) error {
-	return PollForStateOrTimeout(func() (string, string, error) {
+	_, err := c.pollStaged(ctx, guid, opts)
+	return err
+}
+
+// pollStaged waits until the build is staged like PollStaged, returning the last state of the build
+func (c *BuildClient) pollStaged(ctx context.Context, guid string, opts *PollingOptions) (resource.BuildState, error) {
+	var state resource.BuildState
+	err := c.client.pollForState(ctx, func(ctx context.Context) (string, string, error) {
 		build, err := c.Get(ctx, guid)
 		if build != nil {
+			state = build.State
 			if build.Error != nil {
 				return string(build.State), *build.Error, err
 			}
 			return string(build.State), "", err
 		}
 		return "", "", err
-	}, string(resource.BuildStateStaged), opts)
+	}, string(resource.BuildStateStaged), opts.withFailedStates(string(resource.BuildStateFailed)))
+	return state, err
 }
 
 // Single returns a single build matching the options or an error if not exactly 1 match
@@ -173,3 +197,145 @@ func (c *BuildClient) Update(ctx context.Context, guid string, r *resource.Build
 	}
 	return &build, nil
 }
+
+// StagingLogFunc receives each line of a build's staging log as it's read from log-cache
+type StagingLogFunc func(log *resource.Envelope)
+
+// StagingLogWriter returns a StagingLogFunc that writes each staging log line to w
+func StagingLogWriter(w io.Writer) StagingLogFunc {
+	return func(log *resource.Envelope) {
+		_, _ = fmt.Fprintln(w, stagingLogLine(log))
+	}
+}
+
+// stagingLogTailLines is the number of staging log lines kept for a StagingFailedError
+const stagingLogTailLines = 20
+
+// StagingFailedError is returned by PollStagedWithLogs when the build doesn't stage. It includes the last lines of
+// the staging log, which usually explain why.
+type StagingFailedError struct {
+	BuildGUID string
+
+	// LogTail is the last lines of the staging log, oldest first
+	LogTail []string
+
+	Err error
+}
+
+func (e *StagingFailedError) Error() string {
+	if len(e.LogTail) == 0 {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%s\nlast %d lines of the staging log:\n%s", e.Err, len(e.LogTail), strings.Join(e.LogTail, "\n"))
+}
+
+func (e *StagingFailedError) Unwrap() error {
+	return e.Err
+}
+
+// PollStagedWithLogs waits until the build is staged like PollStaged, meanwhile streaming the staging logs of the
+// build's app from log-cache to logFn. If the build fails, a *StagingFailedError with the tail of the staging log
+// is returned. Other errors, i.e. a timeout or a failed request, are returned as is.
+//
+// The logs are best effort, failing to read them from log-cache doesn't fail the build.
+func (c *BuildClient) PollStagedWithLogs(ctx context.Context, guid string, opts *PollingOptions, logFn StagingLogFunc) error {
+	build, err := c.Get(ctx, guid)
+	if err != nil {
+		return err
+	}
+	if opts == nil {
+		opts = NewPollingOptions()
+	}
+	logs := &stagingLogStream{
+		logCache: c.client.LogCache,
+		appGUID:  build.Relationships.App.Data.GUID,
+		cursor:   newEnvelopeCursor(build.CreatedAt),
+		logFn:    logFn,
+	}
+
+	streamCtx, stopStream := context.WithCancel(ctx)
+	streamed := make(chan struct{})
+	go func() {
+		defer close(streamed)
+		logs.follow(streamCtx, opts.CheckInterval)
+	}()
+	state, err := c.pollStaged(ctx, guid, opts)
+	stopStream()
+	<-streamed
+
+	// the last lines are often emitted just before the build finishes
+	logs.read(ctx)
+	if err != nil && state == resource.BuildStateFailed {
+		return &StagingFailedError{BuildGUID: guid, LogTail: logs.tail, Err: err}
+	}
+	return err
+}
+
+// stagingLogStream reads the staging logs of an app from log-cache, passing each new line to logFn
+type stagingLogStream struct {
+	logCache *LogCacheClient
+	appGUID  string
+	cursor   *envelopeCursor
+	logFn    StagingLogFunc
+	tail     []string
+}
+
+// follow reads new staging logs every interval until the context is done
+func (s *stagingLogStream) follow(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		s.read(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// read reads the staging logs written since the last read
+func (s *stagingLogStream) read(ctx context.Context) {
+	for ctx.Err() == nil {
+		opts := NewLogCacheReadOptions()
+		opts.StartTime = s.cursor.next
+		opts.EnvelopeTypes = []resource.EnvelopeType{resource.EnvelopeTypeLog}
+		opts.Limit = logCacheReadLimit
+		envelopes, err := s.logCache.Read(ctx, s.appGUID, opts)
+		if err != nil {
+			return
+		}
+		added := false
+		for _, envelope := range envelopes {
+			// skip lines already passed to logFn
+			if !s.cursor.add(envelope) {
+				continue
+			}
+			added = true
+			if envelope.Log == nil || envelope.SourceType() != resource.LogSourceTypeStaging {
+				continue
+			}
+			if s.logFn != nil {
+				s.logFn(envelope)
+			}
+			s.tail = append(s.tail, stagingLogLine(envelope))
+			if len(s.tail) > stagingLogTailLines {
+				s.tail = s.tail[1:]
+			}
+		}
+		// a full page of lines that were all read before has nothing new after it
+		if len(envelopes) < logCacheReadLimit || !added {
+			return
+		}
+	}
+}
+
+func stagingLogLine(log *resource.Envelope) string {
+	if log.Log == nil {
+		return ""
+	}
+	return strings.TrimRight(string(log.Log.Payload), "\r\n")
+}
@@ -2,9 +2,19 @@ package client
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudfoundry/go-cfclient/v3/config"
 	"github.com/cloudfoundry/go-cfclient/v3/resource"
 	"github.com/cloudfoundry/go-cfclient/v3/testutil"
 )
@@ -139,3 +149,102 @@ func TestBuilds(t *testing.T) {
 	}
 	ExecuteTests(tests, t)
 }
+
+func TestBuildPollStagedWithLogs(t *testing.T) {
+	uaaURL := testutil.SetupFakeUAAServer(300)
+	defer testutil.Teardown()
+
+	// serves a build that finishes on the third check, adding a staging log line on every check
+	serve := func(t *testing.T, finalState string) (*Client, *[]string) {
+		var mu sync.Mutex
+		var checks int
+		var envelopes []string
+		var startTimes []string
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Path {
+			case "/":
+				_, _ = fmt.Fprintf(w, `{"links":{"log_cache":{"href":"http://%s"}}}`, r.Host)
+			case "/v3/builds/build-guid":
+				checks++
+				stagingLog := func(line string) string {
+					return fmt.Sprintf(`{"timestamp":"%d","tags":{"source_type":"STG"},"log":{"payload":"%s"}}`,
+						1700000000000000000+checks, base64.StdEncoding.EncodeToString([]byte(line+"\n")))
+				}
+				envelopes = append(envelopes, stagingLog(fmt.Sprintf("line %d", checks)),
+					fmt.Sprintf(`{"timestamp":"%d","tags":{"source_type":"APP/PROC/WEB"},"log":{"payload":"YXBw"}}`,
+						1700000000000000000+checks))
+				if checks == 2 {
+					// a line with the same timestamp as the lines that may already have been read
+					envelopes = append(envelopes, stagingLog("line 2 continued"))
+				}
+				state, buildErr := "STAGING", "null"
+				if checks >= 3 {
+					state = finalState
+					if state == "FAILED" {
+						buildErr = `"StagingError - Staging error: staging failed"`
+					}
+				}
+				_, _ = fmt.Fprintf(w, `{"guid":"build-guid","state":"%s","error":%s,"created_at":"2023-11-14T22:13:20Z","relationships":{"app":{"data":{"guid":"app-guid"}}}}`,
+					state, buildErr)
+			case "/api/v1/read/app-guid":
+				startTimes = append(startTimes, r.URL.Query().Get("start_time"))
+				require.Equal(t, "LOG", r.URL.Query().Get("envelope_types"))
+				var batch []string
+				for _, envelope := range envelopes {
+					var ts int64
+					_, _ = fmt.Sscanf(envelope, `{"timestamp":"%d"`, &ts)
+					if start := r.URL.Query().Get("start_time"); fmt.Sprint(ts) >= start {
+						batch = append(batch, envelope)
+					}
+				}
+				_, _ = fmt.Fprintf(w, `{"envelopes":{"batch":[%s]}}`, strings.Join(batch, ","))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		t.Cleanup(api.Close)
+		cfg, err := config.New(api.URL, config.ClientCredentials("cf", "secret"), config.AuthTokenURL(uaaURL, uaaURL))
+		require.NoError(t, err)
+		cf, err := New(cfg)
+		require.NoError(t, err)
+		return cf, &startTimes
+	}
+	opts := &PollingOptions{FailedState: "FAILED", Timeout: 5 * time.Second, CheckInterval: time.Millisecond}
+
+	t.Run("staged", func(t *testing.T) {
+		cf, startTimes := serve(t, "STAGED")
+		var lines []string
+		err := cf.Builds.PollStagedWithLogs(context.Background(), "build-guid", opts, func(log *resource.Envelope) {
+			lines = append(lines, string(log.Log.Payload))
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"line 1\n", "line 2\n", "line 2 continued\n", "line 3\n"}, lines)
+		require.Equal(t, "1700000000000000000", (*startTimes)[0])
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		cf, _ := serve(t, "FAILED")
+		var out strings.Builder
+		err := cf.Builds.PollStagedWithLogs(context.Background(), "build-guid", opts, StagingLogWriter(&out))
+		var stagingErr *StagingFailedError
+		require.ErrorAs(t, err, &stagingErr)
+		require.Equal(t, "build-guid", stagingErr.BuildGUID)
+		require.Equal(t, []string{"line 1", "line 2", "line 2 continued", "line 3"}, stagingErr.LogTail)
+		require.ErrorContains(t, err, "staging failed")
+		require.ErrorContains(t, err, "last 4 lines of the staging log:\nline 1\nline 2\nline 2 continued\nline 3")
+		require.Equal(t, "line 1\nline 2\nline 2 continued\nline 3\n", out.String())
+	})
+
+	t.Run("timed out", func(t *testing.T) {
+		cf, _ := serve(t, "STAGING")
+		err := cf.Builds.PollStagedWithLogs(context.Background(), "build-guid",
+			&PollingOptions{Timeout: 50 * time.Millisecond, CheckInterval: time.Millisecond}, nil)
+		var timeoutErr *AsyncProcessTimeoutError
+		require.ErrorAs(t, err, &timeoutErr)
+		var stagingErr *StagingFailedError
+		require.False(t, errors.As(err, &stagingErr))
+	})
+}
@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"io"
+	"iter"
 	"net/url"
 
 	"github.com/cloudfoundry/go-cfclient/v3/internal/path"
@@ -62,6 +63,16 @@ func (c *BuildpackClient) Get(ctx context.Context, guid string) (*resource.Build
 	return &bp, nil
 }
 
+// Iter returns an iterator over all buildpacks the user has access to, fetching each page only as needed
+func (c *BuildpackClient) Iter(ctx context.Context, opts *BuildpackListOptions) iter.Seq2[*resource.Buildpack, error] {
+	if opts == nil {
+		opts = NewBuildpackListOptions()
+	}
+	return AutoPageIter[*BuildpackListOptions, *resource.Buildpack](ctx, opts, func(opts *BuildpackListOptions) ([]*resource.Buildpack, *Pager, error) {
+		return c.List(ctx, opts)
+	})
+}
+
 // List pages all buildpacks the user has access to
 func (c *BuildpackClient) List(ctx context.Context, opts *BuildpackListOptions) ([]*resource.Buildpack, *Pager, error) {
 	if opts == nil {
@@ -81,20 +92,9 @@ func (c *BuildpackClient) ListAll(ctx context.Context, opts *BuildpackListOption
 	if opts == nil {
 		opts = NewBuildpackListOptions()
 	}
-
-	var all []*resource.Buildpack
-	for {
-		page, pager, err := c.List(ctx, opts)
-		if err != nil {
-			return nil, err
-		}
-		all = append(all, page...)
-		if !pager.HasNextPage() {
-			break
-		}
-		pager.NextPage(opts)
-	}
-	return all, nil
+	return autoPage[*BuildpackListOptions, *resource.Buildpack](ctx, c.client, opts, func(ctx context.Context, opts *BuildpackListOptions) ([]*resource.Buildpack, *Pager, error) {
+		return c.List(ctx, opts)
+	})
 }
 
 // Single returns a single buildpack matching the options or an error if not exactly 1 match
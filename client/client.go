@@ -6,17 +6,21 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"mime/multipart"
 	"net/http"
 	"net/url"
 	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/cloudfoundry/go-cfclient/v3/config"
 	"github.com/cloudfoundry/go-cfclient/v3/internal/check"
 	internal "github.com/cloudfoundry/go-cfclient/v3/internal/http"
 	"github.com/cloudfoundry/go-cfclient/v3/internal/ios"
 	"github.com/cloudfoundry/go-cfclient/v3/internal/path"
+	"github.com/cloudfoundry/go-cfclient/v3/internal/telemetry"
 )
 
 // Client used to communicate with Cloud Foundry
@@ -36,6 +40,7 @@ type Client struct {
 	Info                      *InfoClient
 	IsolationSegments         *IsolationSegmentClient
 	Jobs                      *JobClient
+	LogCache                  *LogCacheClient
 	Manifests                 *ManifestClient
 	Organizations             *OrganizationClient
 	OrganizationQuotas        *OrganizationQuotaClient
@@ -63,7 +68,10 @@ type Client struct {
 	Tasks                     *TaskClient
 	Users                     *UserClient
 
-	common commonClient // Reuse a single struct instead of allocating one for each commonClient on the heap.
+	common    commonClient // Reuse a single struct instead of allocating one for each commonClient on the heap.
+	limiter   *requestLimiter
+	telemetry *clientTelemetry
+	logCache  logCacheEndpoint
 	*config.Config
 }
 
@@ -79,8 +87,15 @@ func New(config *config.Config) (*Client, error) {
 	if err := config.Validate(); err != nil {
 		return nil, err
 	}
+	telemetry, err := newClientTelemetry(config)
+	if err != nil {
+		return nil, fmt.Errorf("error creating the client telemetry instruments: %w", err)
+	}
+	requestsPerSecond, burst := config.RateLimit()
 	client := &Client{
-		Config: config,
+		Config:    config,
+		limiter:   newRequestLimiter(requestsPerSecond, burst, config.MaxConcurrentRequests()),
+		telemetry: telemetry,
 	}
 
 	// populate sub-clients
@@ -100,6 +115,7 @@ func New(config *config.Config) (*Client, error) {
 	client.Info = (*InfoClient)(&client.common)
 	client.IsolationSegments = (*IsolationSegmentClient)(&client.common)
 	client.Jobs = (*JobClient)(&client.common)
+	client.LogCache = (*LogCacheClient)(&client.common)
 	client.Manifests = (*ManifestClient)(&client.common)
 	client.Organizations = (*OrganizationClient)(&client.common)
 	client.OrganizationQuotas = (*OrganizationQuotaClient)(&client.common)
@@ -139,8 +155,18 @@ func (c *Client) ExecuteRequest(req *http.Request) (*http.Response, error) {
 	return c.executeHTTPRequest(req, false)
 }
 
+// RateLimitStats returns a snapshot of how long requests have waited on the client-side rate limiter and
+// concurrency cap. All values are zero if neither is configured.
+func (c *Client) RateLimitStats() RateLimitStats {
+	return c.limiter.stats()
+}
+
 // SSHCode generates an SSH code that can be used by generic SSH clients to SSH into app instances
 func (c *Client) SSHCode(ctx context.Context) (string, error) {
+	// the UAA endpoint and SSH client are discovered lazily with config.LazyInit
+	if err := c.Initialize(ctx); err != nil {
+		return "", err
+	}
 	values := url.Values{}
 	values.Set("response_type", "code")
 	values.Set("client_id", c.SSHOAuthClientID())
@@ -178,7 +204,10 @@ func (c *Client) SSHCode(ctx context.Context) (string, error) {
 //
 // This function takes the relative API resource path. If the resource returns an async job ID
 // then the function returns the job GUID which the caller can reference via the job endpoint.
-func (c *Client) delete(ctx context.Context, resourcePath string) (string, error) {
+func (c *Client) delete(ctx context.Context, resourcePath string) (_ string, err error) {
+	ctx, span := c.startOperation(ctx)
+	defer func() { telemetry.End(span, err) }()
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.ApiURL(resourcePath), nil)
 	if err != nil {
 		return "", fmt.Errorf("creating DELETE request for %s failed: %w", resourcePath, err)
@@ -193,7 +222,10 @@ func (c *Client) delete(ctx context.Context, resourcePath string) (string, error
 
 // get does an HTTP GET to the specified endpoint and automatically handles unmarshalling
 // the result JSON body
-func (c *Client) get(ctx context.Context, resourcePath string, result any) error {
+func (c *Client) get(ctx context.Context, resourcePath string, result any) (err error) {
+	ctx, span := c.startOperation(ctx)
+	defer func() { telemetry.End(span, err) }()
+
 	if !check.IsNil(result) && !check.IsPointer(result) {
 		return errors.New("expected result to be nil or a pointer type")
 	}
@@ -248,7 +280,10 @@ func (c *Client) post(ctx context.Context, resourcePath string, params, result a
 
 // Download the bits of an existing package or droplet
 // It is the caller's responsibility to close the io.ReadCloser
-func (c *Client) download(ctx context.Context, resourcePath string) (io.ReadCloser, error) {
+func (c *Client) download(ctx context.Context, resourcePath string) (_ io.ReadCloser, err error) {
+	ctx, span := c.startOperation(ctx)
+	defer func() { telemetry.End(span, err) }()
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.ApiURL(resourcePath), nil)
 	if err != nil {
 		return nil, fmt.Errorf("creating download request for %s failed: %w", resourcePath, err)
@@ -284,11 +319,25 @@ func (c *Client) download(ctx context.Context, resourcePath string) (io.ReadClos
 // struct to unmarshall the result body. If the resource returns an async job ID in the Location
 // header then the job GUID is returned which the caller can reference via the job endpoint.
 func (c *Client) postFileUpload(ctx context.Context, path, fieldName, fileName string, fileContent io.Reader, result any) (string, error) {
+	if fileContent == nil {
+		return "", fmt.Errorf("no content was provided for the %s file", fileName)
+	}
+	return c.postMultipartUpload(ctx, path, nil, fieldName, fileName, fileContent, result)
+}
+
+// postMultipartUpload does an HTTP POST of a multipart form to the specified endpoint with the form fields and
+// an optional file and handles the result whether that's a JSON body or job ID.
+//
+// The file is skipped if fileContent is nil, in which case at least one form field must be specified.
+func (c *Client) postMultipartUpload(ctx context.Context, path string, formFields map[string]string, fieldName, fileName string, fileContent io.Reader, result any) (_ string, err error) {
+	ctx, span := c.startOperation(ctx)
+	defer func() { telemetry.End(span, err) }()
+
 	// Validate input parameters
 	if path == "" || fieldName == "" || fileName == "" {
 		return "", errors.New("path, fieldName, and fileName are required")
 	}
-	if fileContent == nil {
+	if fileContent == nil && len(formFields) == 0 {
 		return "", fmt.Errorf("no content was provided for the %s file", fileName)
 	}
 
@@ -299,14 +348,21 @@ func (c *Client) postFileUpload(ctx context.Context, path, fieldName, fileName s
 	// Prepare multipart form data
 	body := &bytes.Buffer{}
 	formWriter := multipart.NewWriter(body)
-	part, err := formWriter.CreateFormFile(fieldName, filepath.Base(fileName))
-	if err != nil {
-		return "", fmt.Errorf("error uploading file to %s: %w", path, err)
-	}
-	if _, err = io.Copy(part, fileContent); err != nil {
-		return "", fmt.Errorf("error uploading file to %s, failed on copy: %w", path, err)
-	}
-	if err = formWriter.Close(); err != nil {
+	for _, name := range slices.Sorted(maps.Keys(formFields)) {
+		if err := formWriter.WriteField(name, formFields[name]); err != nil {
+			return "", fmt.Errorf("error uploading file to %s, failed to write the %s field: %w", path, name, err)
+		}
+	}
+	if fileContent != nil {
+		part, err := formWriter.CreateFormFile(fieldName, filepath.Base(fileName))
+		if err != nil {
+			return "", fmt.Errorf("error uploading file to %s: %w", path, err)
+		}
+		if _, err = io.Copy(part, fileContent); err != nil {
+			return "", fmt.Errorf("error uploading file to %s, failed on copy: %w", path, err)
+		}
+	}
+	if err := formWriter.Close(); err != nil {
 		return "", fmt.Errorf("error uploading file to %s, failed to close multipart form writer: %w", path, err)
 	}
 
@@ -333,7 +389,10 @@ func (c *Client) postFileUpload(ctx context.Context, path, fieldName, fileName s
 // This function takes the relative API resource path, any parameters to POST/PATCH and an optional
 // struct to unmarshall the result body. If the resource returns an async job ID in the Location
 // header then the job GUID is returned which the caller can reference via the job endpoint.
-func (c *Client) createOrUpdate(ctx context.Context, method, resourcePath string, params, result any) (string, error) {
+func (c *Client) createOrUpdate(ctx context.Context, method, resourcePath string, params, result any) (_ string, err error) {
+	ctx, span := c.startOperation(ctx)
+	defer func() { telemetry.End(span, err) }()
+
 	if !check.IsNil(result) && !check.IsPointer(result) {
 		return "", errors.New("expected result to be a pointer type, or nil")
 	}
@@ -357,21 +416,95 @@ func (c *Client) createOrUpdate(ctx context.Context, method, resourcePath string
 }
 
 // executeHTTPRequest is the low level client function that handles executing the request against the
-// correct http.Client.
-func (c *Client) executeHTTPRequest(req *http.Request, includeAuthHeader bool) (resp *http.Response, err error) {
+// correct http.Client, retrying the request if a retry policy is configured.
+func (c *Client) executeHTTPRequest(req *http.Request, includeAuthHeader bool) (*http.Response, error) {
+	if c.telemetry == nil {
+		return c.retryHTTPRequest(req, includeAuthHeader)
+	}
+	return c.telemetry.instrument(req, func(req *http.Request) (*http.Response, error) {
+		return c.retryHTTPRequest(req, includeAuthHeader)
+	})
+}
+
+// retryHTTPRequest sends the request, retrying it while the retry policy allows
+func (c *Client) retryHTTPRequest(req *http.Request, includeAuthHeader bool) (*http.Response, error) {
 	req.Header.Set("User-Agent", c.UserAgent())
+	httpClient := c.HTTPClient()
 	if includeAuthHeader {
-		resp, err = c.HTTPAuthClient().Do(req)
-	} else {
-		resp, err = c.HTTPClient().Do(req)
+		httpClient = c.HTTPAuthClient()
+	}
+
+	policy := c.RetryPolicy()
+	if policy == nil || !policy.ShouldRetryMethod(req.Method) || !internal.CanRewindBody(req) {
+		return checkHTTPResponse(c.sendHTTPRequest(httpClient, req))
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.sendHTTPRequest(httpClient, req)
+		if attempt >= policy.MaxAttempts || !shouldRetryHTTPRequest(req, policy, resp, err) {
+			return checkHTTPResponse(resp, err)
+		}
+
+		// We're going to retry, consume any response to reuse the connection
+		retryAfter := internal.RetryAfter(resp, time.Now())
+		internal.DrainBody(resp)
+
+		if err = sleepWithContext(req.Context(), policy.Backoff(attempt, retryAfter)); err != nil {
+			return nil, fmt.Errorf("error executing request, cancelled while waiting to retry: %w", err)
+		}
+		if err = internal.RewindBody(req); err != nil {
+			return nil, fmt.Errorf("error executing request, failed to reset the request body for retry: %w", err)
+		}
+	}
+}
+
+// sendHTTPRequest sends a single request attempt once the rate limiter and concurrency cap allow it. The
+// in-flight slot is released once the response headers arrive, so a caller streaming a large body, i.e. a
+// droplet download, doesn't block other requests.
+func (c *Client) sendHTTPRequest(httpClient *http.Client, req *http.Request) (*http.Response, error) {
+	if c.limiter == nil {
+		return httpClient.Do(req)
 	}
+	release, err := c.limiter.acquire(req.Context())
+	if err != nil {
+		return nil, fmt.Errorf("cancelled while waiting on the client rate limiter: %w", err)
+	}
+	defer release()
+	return httpClient.Do(req)
+}
 
+// checkHTTPResponse converts a send error or unsuccessful response into an error
+func checkHTTPResponse(resp *http.Response, err error) (*http.Response, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error executing request, failed during HTTP request send: %w", err)
 	}
 	if !internal.IsStatusSuccess(resp.StatusCode) {
 		return nil, internal.DecodeError(resp)
 	}
+	return resp, nil
+}
+
+// shouldRetryHTTPRequest returns true if the request failed with a transient network error or a response
+// status code that the retry policy considers retryable
+func shouldRetryHTTPRequest(req *http.Request, policy *config.RetryPolicy, resp *http.Response, err error) bool {
+	if err != nil {
+		// never retry if the caller gave up, or if the error isn't going to go away
+		return req.Context().Err() == nil && internal.IsTransientError(err)
+	}
+	return policy.ShouldRetryStatus(resp.StatusCode)
+}
 
-	return resp, err
+// sleepWithContext waits for the specified duration or until the context is done
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
 }
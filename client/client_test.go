@@ -1,10 +1,15 @@
 package client_test
 
 import (
+	"context"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/cloudfoundry/go-cfclient/v3/client"
 	"github.com/cloudfoundry/go-cfclient/v3/config"
+	"github.com/cloudfoundry/go-cfclient/v3/resource"
+	"github.com/cloudfoundry/go-cfclient/v3/testutil"
 
 	"github.com/stretchr/testify/require"
 )
@@ -19,3 +24,95 @@ func TestClientWithInvalidConfig(t *testing.T) {
 	require.Error(t, err)
 	require.Equal(t, config.ErrConfigInvalid, err)
 }
+
+func TestClientRetry(t *testing.T) {
+	g := testutil.NewObjectJSONGenerator()
+	app := g.Application()
+
+	policy := config.DefaultRetryPolicy()
+	policy.InitialBackoff = time.Millisecond
+	policy.MaxBackoff = 5 * time.Millisecond
+
+	newClient := func(t *testing.T, serverURL string, options ...config.Option) *client.Client {
+		options = append(options, config.Token("", "fake-refresh-token"))
+		cfg, err := config.New(serverURL, options...)
+		require.NoError(t, err)
+		cf, err := client.New(cfg)
+		require.NoError(t, err)
+		return cf
+	}
+
+	t.Run("retries transient failures", func(t *testing.T) {
+		serverURL := testutil.Setup(testutil.MockRoute{
+			Method:   "GET",
+			Endpoint: "/v3/apps/" + app.GUID,
+			Output:   []string{"bad gateway", "unavailable", app.JSON},
+			Statuses: []int{502, 503, 200},
+		}, t)
+		defer testutil.Teardown()
+
+		cf := newClient(t, serverURL, config.Retry(policy))
+		a, err := cf.Applications.Get(context.Background(), app.GUID)
+		require.NoError(t, err)
+		require.Equal(t, app.GUID, a.GUID)
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		serverURL := testutil.Setup(testutil.MockRoute{
+			Method:   "GET",
+			Endpoint: "/v3/apps/" + app.GUID,
+			Output:   []string{"unavailable", "unavailable", "unavailable", app.JSON},
+			Statuses: []int{503, 503, 503, 200},
+		}, t)
+		defer testutil.Teardown()
+
+		cf := newClient(t, serverURL, config.Retry(policy))
+		_, err := cf.Applications.Get(context.Background(), app.GUID)
+		require.ErrorContains(t, err, "503")
+	})
+
+	t.Run("does not retry without a policy", func(t *testing.T) {
+		serverURL := testutil.Setup(testutil.MockRoute{
+			Method:   "GET",
+			Endpoint: "/v3/apps/" + app.GUID,
+			Output:   []string{"unavailable", app.JSON},
+			Statuses: []int{503, 200},
+		}, t)
+		defer testutil.Teardown()
+
+		cf := newClient(t, serverURL)
+		_, err := cf.Applications.Get(context.Background(), app.GUID)
+		require.ErrorContains(t, err, "503")
+	})
+
+	t.Run("does not retry non-idempotent requests", func(t *testing.T) {
+		serverURL := testutil.Setup(testutil.MockRoute{
+			Method:   "POST",
+			Endpoint: "/v3/apps",
+			Output:   []string{"unavailable", app.JSON},
+			Statuses: []int{503, 201},
+		}, t)
+		defer testutil.Teardown()
+
+		cf := newClient(t, serverURL, config.Retry(policy))
+		_, err := cf.Applications.Create(context.Background(), &resource.AppCreate{Name: "my-app"})
+		require.ErrorContains(t, err, "503")
+	})
+
+	t.Run("retries non-idempotent requests when opted in", func(t *testing.T) {
+		serverURL := testutil.Setup(testutil.MockRoute{
+			Method:   "POST",
+			Endpoint: "/v3/apps",
+			Output:   []string{"unavailable", app.JSON},
+			Statuses: []int{503, 201},
+			PostForm: `{"name":"my-app","relationships":{"space":{"data":null}}}`,
+		}, t)
+		defer testutil.Teardown()
+
+		p := policy
+		p.RetryableMethods = append(p.RetryableMethods, http.MethodPost)
+		cf := newClient(t, serverURL, config.Retry(p))
+		_, err := cf.Applications.Create(context.Background(), &resource.AppCreate{Name: "my-app"})
+		require.NoError(t, err)
+	})
+}
@@ -3,7 +3,11 @@ package client
 import (
 	"context"
 	"errors"
+	"fmt"
+	"iter"
 	"net/url"
+	"slices"
+	"time"
 
 	"github.com/cloudfoundry/go-cfclient/v3/internal/path"
 	"github.com/cloudfoundry/go-cfclient/v3/resource"
@@ -21,6 +25,59 @@ type DeploymentListOptions struct {
 	StatusValues  Filter `qs:"status_values"`
 }
 
+// DeploymentEventType is the kind of change a DeploymentEvent reports
+type DeploymentEventType string
+
+const (
+	// DeploymentEventActive is sent when an active deployment's reason changes, i.e. to DEPLOYING or CANCELING
+	DeploymentEventActive DeploymentEventType = "ACTIVE"
+
+	// DeploymentEventPaused is sent when a canary deployment pauses at a step, waiting to be continued
+	DeploymentEventPaused DeploymentEventType = "PAUSED"
+
+	// DeploymentEventNewProcesses is sent when the deployment creates new processes
+	DeploymentEventNewProcesses DeploymentEventType = "NEW_PROCESSES"
+
+	// DeploymentEventDeployed is sent when the deployment finishes successfully
+	DeploymentEventDeployed DeploymentEventType = "DEPLOYED"
+
+	// DeploymentEventCanceled is sent when the deployment finishes because it was cancelled
+	DeploymentEventCanceled DeploymentEventType = "CANCELED"
+
+	// DeploymentEventSuperseded is sent when the deployment finishes because a newer deployment replaced it
+	DeploymentEventSuperseded DeploymentEventType = "SUPERSEDED"
+
+	// DeploymentEventFinalized is sent when the deployment finishes for any other reason
+	DeploymentEventFinalized DeploymentEventType = "FINALIZED"
+)
+
+// DeploymentEvent is a change to a deployment observed by DeploymentClient.Watch
+type DeploymentEvent struct {
+	Type       DeploymentEventType
+	Deployment *resource.Deployment
+
+	// CanaryStep is the current step of a canary deployment, or 0 if the CC doesn't report steps
+	CanaryStep int
+
+	// NewProcesses are the processes that appeared since the previous event, for DeploymentEventNewProcesses
+	NewProcesses []resource.ProcessReference
+}
+
+// DeploymentFailedError is returned by DeploymentClient.Watch when a deployment finishes without being deployed
+type DeploymentFailedError struct {
+	Deployment *resource.Deployment
+	Reason     string
+	Detail     string
+}
+
+func (e *DeploymentFailedError) Error() string {
+	msg := fmt.Sprintf("deployment %s finished with reason %s", e.Deployment.GUID, e.Reason)
+	if e.Detail != "" {
+		msg += ": " + e.Detail
+	}
+	return msg
+}
+
 // NewDeploymentListOptions creates new options to pass to list
 func NewDeploymentListOptions() *DeploymentListOptions {
 	return &DeploymentListOptions{
@@ -77,6 +134,16 @@ func (c *DeploymentClient) Get(ctx context.Context, guid string) (*resource.Depl
 	return &d, nil
 }
 
+// Iter returns an iterator over all deployments the user has access to, fetching each page only as needed
+func (c *DeploymentClient) Iter(ctx context.Context, opts *DeploymentListOptions) iter.Seq2[*resource.Deployment, error] {
+	if opts == nil {
+		opts = NewDeploymentListOptions()
+	}
+	return AutoPageIter[*DeploymentListOptions, *resource.Deployment](ctx, opts, func(opts *DeploymentListOptions) ([]*resource.Deployment, *Pager, error) {
+		return c.List(ctx, opts)
+	})
+}
+
 // List pages deployments the user has access to
 func (c *DeploymentClient) List(ctx context.Context, opts *DeploymentListOptions) ([]*resource.Deployment, *Pager, error) {
 	if opts == nil {
@@ -96,7 +163,7 @@ func (c *DeploymentClient) ListAll(ctx context.Context, opts *DeploymentListOpti
 	if opts == nil {
 		opts = NewDeploymentListOptions()
 	}
-	return AutoPage[*DeploymentListOptions, *resource.Deployment](opts, func(opts *DeploymentListOptions) ([]*resource.Deployment, *Pager, error) {
+	return autoPage[*DeploymentListOptions, *resource.Deployment](ctx, c.client, opts, func(ctx context.Context, opts *DeploymentListOptions) ([]*resource.Deployment, *Pager, error) {
 		return c.List(ctx, opts)
 	})
 }
@@ -108,6 +175,153 @@ func (c *DeploymentClient) Single(ctx context.Context, opts *DeploymentListOptio
 	})
 }
 
+// Watch returns an iterator over the changes to the deployment until it's finalized.
+//
+// The deployment is polled every CheckInterval, backing off while nothing changes, and an event is sent for its
+// initial status, each status change, each canary step it pauses at and each new process. The iteration ends after
+// the deployment is finalized, the final event is paired with a *DeploymentFailedError if it was cancelled or
+// superseded. Polling errors, the context being done or the polling timeout end the iteration with an error. Options
+// default to NewPollingOptions when nil.
+func (c *DeploymentClient) Watch(ctx context.Context, guid string, opts *PollingOptions) iter.Seq2[*DeploymentEvent, error] {
+	if opts == nil {
+		opts = NewPollingOptions()
+	}
+	return func(yield func(*DeploymentEvent, error) bool) {
+		pollCtx := ctx
+		if opts.Timeout > 0 {
+			var cancel context.CancelFunc
+			pollCtx, cancel = context.WithTimeout(ctx, opts.Timeout)
+			defer cancel()
+		}
+		timedOut := func(last *resource.Deployment) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			timeoutErr := &AsyncProcessTimeoutError{Timeout: opts.Timeout}
+			if last != nil {
+				timeoutErr.LastState = last.Status.Reason
+			}
+			return timeoutErr
+		}
+
+		var last *resource.Deployment
+		checkInterval := opts.CheckInterval
+		if checkInterval <= 0 {
+			checkInterval = time.Second
+		}
+		interval := checkInterval
+		for {
+			d, err := c.Get(pollCtx, guid)
+			if err != nil {
+				if pollCtx.Err() != nil {
+					err = timedOut(last)
+				}
+				yield(nil, err)
+				return
+			}
+
+			events := deploymentEvents(last, d)
+			last = d
+			for _, event := range events {
+				if !yield(event, deploymentEventErr(event)) {
+					return
+				}
+			}
+			if d.Status.Value == resource.DeploymentStatusValueFinalized {
+				return
+			}
+
+			// check again quickly after a change, otherwise back off
+			if len(events) > 0 {
+				interval = checkInterval
+			} else {
+				interval = opts.nextCheckInterval(interval)
+			}
+			timer := time.NewTimer(interval)
+			select {
+			case <-pollCtx.Done():
+				timer.Stop()
+				yield(nil, timedOut(last))
+				return
+			case <-timer.C:
+			}
+		}
+	}
+}
+
+// deploymentEvents returns the events for the changes between the previous and current deployment
+func deploymentEvents(prev, cur *resource.Deployment) []*DeploymentEvent {
+	var events []*DeploymentEvent
+	step := deploymentCanaryStep(cur)
+
+	var newProcesses []resource.ProcessReference
+	for _, proc := range cur.NewProcesses {
+		if prev == nil || !slices.ContainsFunc(prev.NewProcesses, func(p resource.ProcessReference) bool {
+			return p.GUID == proc.GUID
+		}) {
+			newProcesses = append(newProcesses, proc)
+		}
+	}
+	if len(newProcesses) > 0 {
+		events = append(events, &DeploymentEvent{
+			Type:         DeploymentEventNewProcesses,
+			Deployment:   cur,
+			CanaryStep:   step,
+			NewProcesses: newProcesses,
+		})
+	}
+
+	if prev != nil && prev.Status.Value == cur.Status.Value && prev.Status.Reason == cur.Status.Reason &&
+		deploymentCanaryStep(prev) == step {
+		return events
+	}
+	event := &DeploymentEvent{
+		Type:       DeploymentEventActive,
+		Deployment: cur,
+		CanaryStep: step,
+	}
+	switch {
+	case cur.Status.Value == resource.DeploymentStatusValueFinalized:
+		switch cur.Status.Reason {
+		case resource.DeploymentStatusReasonDeployed:
+			event.Type = DeploymentEventDeployed
+		case resource.DeploymentStatusReasonCanceled:
+			event.Type = DeploymentEventCanceled
+		case resource.DeploymentStatusReasonSuperseded:
+			event.Type = DeploymentEventSuperseded
+		default:
+			event.Type = DeploymentEventFinalized
+		}
+	case cur.Status.Reason == resource.DeploymentStatusReasonPaused:
+		event.Type = DeploymentEventPaused
+	}
+	return append(events, event)
+}
+
+// deploymentEventErr returns a *DeploymentFailedError for the event of a deployment that finished without
+// being deployed
+func deploymentEventErr(event *DeploymentEvent) error {
+	switch event.Type {
+	case DeploymentEventCanceled, DeploymentEventSuperseded, DeploymentEventFinalized:
+	default:
+		return nil
+	}
+	d := event.Deployment
+	err := &DeploymentFailedError{Deployment: d, Reason: d.Status.Reason}
+	if d.Status.Details != nil {
+		err.Detail = d.Status.Details.Error
+	}
+	return err
+}
+
+// deploymentCanaryStep returns the current step of a canary deployment, or 0 if the CC doesn't report steps
+func deploymentCanaryStep(d *resource.Deployment) int {
+	if d.Status.Canary == nil || d.Status.Canary.Steps == nil {
+		return 0
+	}
+	return d.Status.Canary.Steps.Current
+}
+
 // Update the specified attributes of the deployment
 func (c *DeploymentClient) Update(ctx context.Context, guid string, r *resource.DeploymentUpdate) (*resource.Deployment, error) {
 	var d resource.Deployment
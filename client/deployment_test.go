@@ -2,8 +2,10 @@ package client
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/cloudfoundry/go-cfclient/v3/resource"
 	"github.com/cloudfoundry/go-cfclient/v3/testutil"
@@ -17,6 +19,21 @@ func TestDeployments(t *testing.T) {
 	deployment2 := g.Deployment().JSON
 	deployment3 := g.Deployment().JSON
 	deployment4 := g.Deployment().JSON
+	watchedDeployment := func(value, reason string, step int, processes ...string) string {
+		procs := ""
+		for i, guid := range processes {
+			if i > 0 {
+				procs += ","
+			}
+			procs += fmt.Sprintf(`{"guid":"%s","type":"web"}`, guid)
+		}
+		return fmt.Sprintf(`{"guid":"59c3d133-2b83-46f3-960e-7765a129aea4","status":{"value":"%s","reason":"%s","details":{"error":"health check failed"},"canary":{"steps":{"current":%d,"total":2}}},"new_processes":[%s]}`,
+			value, reason, step, procs)
+	}
+	watchOpts := &PollingOptions{
+		Timeout:       time.Second,
+		CheckInterval: time.Nanosecond,
+	}
 
 	tests := []RouteTest{
 		{
@@ -201,6 +218,81 @@ func TestDeployments(t *testing.T) {
 				return c.Deployments.Update(context.Background(), "2b56dc7b-2a14-49ea-be29-ca182b14a998", r)
 			},
 		},
+		{
+			Description: "Watch canary deployment until deployed",
+			Route: testutil.MockRoute{
+				Method:   "GET",
+				Endpoint: "/v3/deployments/59c3d133-2b83-46f3-960e-7765a129aea4",
+				Output: []string{
+					watchedDeployment("ACTIVE", "DEPLOYING", 1),
+					watchedDeployment("ACTIVE", "DEPLOYING", 1, "p1"),
+					watchedDeployment("ACTIVE", "PAUSED", 1, "p1"),
+					watchedDeployment("ACTIVE", "PAUSED", 1, "p1"),
+					watchedDeployment("ACTIVE", "PAUSED", 2, "p1"),
+					watchedDeployment("FINALIZED", "DEPLOYED", 2, "p1"),
+				},
+				Statuses: []int{http.StatusOK, http.StatusOK, http.StatusOK, http.StatusOK, http.StatusOK, http.StatusOK},
+			},
+			Action: func(c *Client, t *testing.T) (any, error) {
+				var events []string
+				for event, err := range c.Deployments.Watch(context.Background(), "59c3d133-2b83-46f3-960e-7765a129aea4", watchOpts) {
+					require.NoError(t, err)
+					desc := fmt.Sprintf("%s %d", event.Type, event.CanaryStep)
+					for _, p := range event.NewProcesses {
+						desc += " " + p.GUID
+					}
+					events = append(events, desc)
+				}
+				require.Equal(t, []string{"ACTIVE 1", "NEW_PROCESSES 1 p1", "PAUSED 1", "PAUSED 2", "DEPLOYED 2"}, events)
+				return nil, nil
+			},
+		},
+		{
+			Description: "Watch deployment that is cancelled",
+			Route: testutil.MockRoute{
+				Method:   "GET",
+				Endpoint: "/v3/deployments/59c3d133-2b83-46f3-960e-7765a129aea4",
+				Output: []string{
+					watchedDeployment("ACTIVE", "DEPLOYING", 0),
+					watchedDeployment("FINALIZED", "CANCELED", 0),
+				},
+				Statuses: []int{http.StatusOK, http.StatusOK},
+			},
+			Action: func(c *Client, t *testing.T) (any, error) {
+				var last *DeploymentEvent
+				var lastErr error
+				for event, err := range c.Deployments.Watch(context.Background(), "59c3d133-2b83-46f3-960e-7765a129aea4", watchOpts) {
+					last, lastErr = event, err
+				}
+				require.Equal(t, DeploymentEventCanceled, last.Type)
+				var failedErr *DeploymentFailedError
+				require.ErrorAs(t, lastErr, &failedErr)
+				require.Equal(t, "CANCELED", failedErr.Reason)
+				require.EqualError(t, lastErr, "deployment 59c3d133-2b83-46f3-960e-7765a129aea4 finished with reason CANCELED: health check failed")
+				return nil, nil
+			},
+		},
+		{
+			Description: "Watch deployment that times out",
+			Route: testutil.MockRoute{
+				Method:   "GET",
+				Endpoint: "/v3/deployments/59c3d133-2b83-46f3-960e-7765a129aea4",
+				Output:   []string{watchedDeployment("ACTIVE", "DEPLOYING", 0)},
+				Status:   http.StatusOK,
+			},
+			Action: func(c *Client, t *testing.T) (any, error) {
+				opts := &PollingOptions{Timeout: 10 * time.Millisecond, CheckInterval: time.Second}
+				var errs []error
+				for _, err := range c.Deployments.Watch(context.Background(), "59c3d133-2b83-46f3-960e-7765a129aea4", opts) {
+					errs = append(errs, err)
+				}
+				require.Len(t, errs, 2)
+				require.NoError(t, errs[0])
+				require.ErrorIs(t, errs[1], ErrAsyncProcessTimeout)
+				require.ErrorContains(t, errs[1], "last state was DEPLOYING")
+				return nil, nil
+			},
+		},
 	}
 	ExecuteTests(tests, t)
 }
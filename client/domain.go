@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"iter"
 	"net/url"
 
 	"github.com/cloudfoundry/go-cfclient/v3/internal/path"
@@ -69,6 +70,16 @@ func (c *DomainClient) Get(ctx context.Context, guid string) (*resource.Domain,
 	return &d, nil
 }
 
+// Iter returns an iterator over all domains the user has access to, fetching each page only as needed
+func (c *DomainClient) Iter(ctx context.Context, opts *DomainListOptions) iter.Seq2[*resource.Domain, error] {
+	if opts == nil {
+		opts = NewDomainListOptions()
+	}
+	return AutoPageIter[*DomainListOptions, *resource.Domain](ctx, opts, func(opts *DomainListOptions) ([]*resource.Domain, *Pager, error) {
+		return c.List(ctx, opts)
+	})
+}
+
 // List pages Domains the user has access to
 func (c *DomainClient) List(ctx context.Context, opts *DomainListOptions) ([]*resource.Domain, *Pager, error) {
 	var res resource.DomainList
@@ -85,7 +96,7 @@ func (c *DomainClient) ListAll(ctx context.Context, opts *DomainListOptions) ([]
 	if opts == nil {
 		opts = NewDomainListOptions()
 	}
-	return AutoPage[*DomainListOptions, *resource.Domain](opts, func(opts *DomainListOptions) ([]*resource.Domain, *Pager, error) {
+	return autoPage[*DomainListOptions, *resource.Domain](ctx, c.client, opts, func(ctx context.Context, opts *DomainListOptions) ([]*resource.Domain, *Pager, error) {
 		return c.List(ctx, opts)
 	})
 }
@@ -109,7 +120,7 @@ func (c *DomainClient) ListForOrganizationAll(ctx context.Context, organizationG
 	if opts == nil {
 		opts = NewDomainListOptions()
 	}
-	return AutoPage[*DomainListOptions, *resource.Domain](opts, func(opts *DomainListOptions) ([]*resource.Domain, *Pager, error) {
+	return autoPage[*DomainListOptions, *resource.Domain](ctx, c.client, opts, func(ctx context.Context, opts *DomainListOptions) ([]*resource.Domain, *Pager, error) {
 		return c.ListForOrganization(ctx, organizationGUID, opts)
 	})
 }
@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"io"
+	"iter"
 	"net/url"
 
 	"github.com/cloudfoundry/go-cfclient/v3/internal/path"
@@ -138,6 +139,16 @@ func (c *DropletClient) Get(ctx context.Context, guid string) (*resource.Droplet
 	return &d, nil
 }
 
+// Iter returns an iterator over all droplets the user has access to, fetching each page only as needed
+func (c *DropletClient) Iter(ctx context.Context, opts *DropletListOptions) iter.Seq2[*resource.Droplet, error] {
+	if opts == nil {
+		opts = NewDropletListOptions()
+	}
+	return AutoPageIter[*DropletListOptions, *resource.Droplet](ctx, opts, func(opts *DropletListOptions) ([]*resource.Droplet, *Pager, error) {
+		return c.List(ctx, opts)
+	})
+}
+
 // List pages all droplets the user has access to
 func (c *DropletClient) List(ctx context.Context, opts *DropletListOptions) ([]*resource.Droplet, *Pager, error) {
 	if opts == nil {
@@ -157,7 +168,7 @@ func (c *DropletClient) ListAll(ctx context.Context, opts *DropletListOptions) (
 	if opts == nil {
 		opts = NewDropletListOptions()
 	}
-	return AutoPage[*DropletListOptions, *resource.Droplet](opts, func(opts *DropletListOptions) ([]*resource.Droplet, *Pager, error) {
+	return autoPage[*DropletListOptions, *resource.Droplet](ctx, c.client, opts, func(ctx context.Context, opts *DropletListOptions) ([]*resource.Droplet, *Pager, error) {
 		return c.List(ctx, opts)
 	})
 }
@@ -181,7 +192,7 @@ func (c *DropletClient) ListForAppAll(ctx context.Context, appGUID string, opts
 	if opts == nil {
 		opts = NewDropletAppListOptions()
 	}
-	return AutoPage[*DropletAppListOptions, *resource.Droplet](opts, func(opts *DropletAppListOptions) ([]*resource.Droplet, *Pager, error) {
+	return autoPage[*DropletAppListOptions, *resource.Droplet](ctx, c.client, opts, func(ctx context.Context, opts *DropletAppListOptions) ([]*resource.Droplet, *Pager, error) {
 		return c.ListForApp(ctx, appGUID, opts)
 	})
 }
@@ -205,7 +216,7 @@ func (c *DropletClient) ListForPackageAll(ctx context.Context, packageGUID strin
 	if opts == nil {
 		opts = NewDropletPackageListOptions()
 	}
-	return AutoPage[*DropletPackageListOptions, *resource.Droplet](opts, func(opts *DropletPackageListOptions) ([]*resource.Droplet, *Pager, error) {
+	return autoPage[*DropletPackageListOptions, *resource.Droplet](ctx, c.client, opts, func(ctx context.Context, opts *DropletPackageListOptions) ([]*resource.Droplet, *Pager, error) {
 		return c.ListForPackage(ctx, packageGUID, opts)
 	})
 }
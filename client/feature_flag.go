@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"iter"
 	"net/url"
 
 	"github.com/cloudfoundry/go-cfclient/v3/internal/path"
@@ -36,6 +37,16 @@ func (c *FeatureFlagClient) Get(ctx context.Context, featureFlag resource.Featur
 	return &ff, nil
 }
 
+// Iter returns an iterator over all feature flags, fetching each page only as needed
+func (c *FeatureFlagClient) Iter(ctx context.Context, opts *FeatureFlagListOptions) iter.Seq2[*resource.FeatureFlag, error] {
+	if opts == nil {
+		opts = NewFeatureFlagListOptions()
+	}
+	return AutoPageIter[*FeatureFlagListOptions, *resource.FeatureFlag](ctx, opts, func(opts *FeatureFlagListOptions) ([]*resource.FeatureFlag, *Pager, error) {
+		return c.List(ctx, opts)
+	})
+}
+
 // List pages feature flags
 func (c *FeatureFlagClient) List(ctx context.Context, opts *FeatureFlagListOptions) ([]*resource.FeatureFlag, *Pager, error) {
 	if opts == nil {
@@ -55,7 +66,7 @@ func (c *FeatureFlagClient) ListAll(ctx context.Context, opts *FeatureFlagListOp
 	if opts == nil {
 		opts = NewFeatureFlagListOptions()
 	}
-	return AutoPage[*FeatureFlagListOptions, *resource.FeatureFlag](opts, func(opts *FeatureFlagListOptions) ([]*resource.FeatureFlag, *Pager, error) {
+	return autoPage[*FeatureFlagListOptions, *resource.FeatureFlag](ctx, c.client, opts, func(ctx context.Context, opts *FeatureFlagListOptions) ([]*resource.FeatureFlag, *Pager, error) {
 		return c.List(ctx, opts)
 	})
 }
@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"iter"
 	"net/url"
 
 	"github.com/cloudfoundry/go-cfclient/v3/internal/path"
@@ -89,6 +90,19 @@ func (c *IsolationSegmentClient) Get(ctx context.Context, guid string) (*resourc
 	return &iso, nil
 }
 
+// Iter returns an iterator over all isolation segments the user has access to, fetching each page only as needed
+//
+// For admin, this is all the isolation segments in the system. For anyone else,  this is
+// the isolation segments in the allowed list for any organization to which the user belongs.
+func (c *IsolationSegmentClient) Iter(ctx context.Context, opts *IsolationSegmentListOptions) iter.Seq2[*resource.IsolationSegment, error] {
+	if opts == nil {
+		opts = NewIsolationSegmentOptions()
+	}
+	return AutoPageIter[*IsolationSegmentListOptions, *resource.IsolationSegment](ctx, opts, func(opts *IsolationSegmentListOptions) ([]*resource.IsolationSegment, *Pager, error) {
+		return c.List(ctx, opts)
+	})
+}
+
 // List all isolation segments the user has access to in paged results
 //
 // For admin, this is all the isolation segments in the system. For anyone else,  this is
@@ -115,7 +129,7 @@ func (c *IsolationSegmentClient) ListAll(ctx context.Context, opts *IsolationSeg
 	if opts == nil {
 		opts = NewIsolationSegmentOptions()
 	}
-	return AutoPage[*IsolationSegmentListOptions, *resource.IsolationSegment](opts, func(opts *IsolationSegmentListOptions) ([]*resource.IsolationSegment, *Pager, error) {
+	return autoPage[*IsolationSegmentListOptions, *resource.IsolationSegment](ctx, c.client, opts, func(ctx context.Context, opts *IsolationSegmentListOptions) ([]*resource.IsolationSegment, *Pager, error) {
 		return c.List(ctx, opts)
 	})
 }
@@ -2,6 +2,9 @@ package client
 
 import (
 	"context"
+	"fmt"
+	"strings"
+
 	"github.com/cloudfoundry/go-cfclient/v3/internal/path"
 	"github.com/cloudfoundry/go-cfclient/v3/resource"
 )
@@ -18,21 +21,73 @@ func (c *JobClient) Get(ctx context.Context, guid string) (*resource.Job, error)
 	return &job, nil
 }
 
-// PollComplete waits until the job completes, fails, or times out
+// JobFailedError is returned when polling a job that ends in the FAILED state. It unwraps to each of the
+// job's errors, so errors.As and the resource.IsXxxError functions can be used to inspect the failure cause.
+type JobFailedError struct {
+	Job       *resource.Job
+	Operation string
+	Errors    []resource.CloudFoundryError
+	Warnings  []resource.JobWarning
+}
+
+// NewJobFailedError creates a JobFailedError from the failed job
+func NewJobFailedError(job *resource.Job) *JobFailedError {
+	return &JobFailedError{
+		Job:       job,
+		Operation: job.Operation,
+		Errors:    job.Errors,
+		Warnings:  job.Warnings,
+	}
+}
+
+func (e *JobFailedError) Error() string {
+	var sb strings.Builder
+	sb.WriteString("job ")
+	if e.Operation != "" {
+		sb.WriteString(e.Operation + " ")
+	}
+	sb.WriteString("failed")
+	for _, err := range e.Errors {
+		sb.WriteString("\n" + err.Error())
+	}
+	for _, w := range e.Warnings {
+		sb.WriteString("\nwarning: " + w.Detail)
+	}
+	return sb.String()
+}
+
+func (e *JobFailedError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+	return errs
+}
+
+// PollComplete waits until the job completes, fails, times out, or the context is done
+//
+// If the job fails the returned error wraps a *JobFailedError.
 func (c *JobClient) PollComplete(ctx context.Context, jobGUID string, opts *PollingOptions) error {
-	err := PollForStateOrTimeout(func() (string, string, error) {
-		job, err := c.Get(ctx, jobGUID)
-		if job != nil {
-			var cfErrors string
-			for _, e := range job.Errors {
-				cfErrors += "\n" + e.Error()
-			}
-			for _, e := range job.Warnings {
-				cfErrors += "\n" + e.Detail
-			}
-			return string(job.State), cfErrors, err
-		}
-		return "", "", err
-	}, string(resource.JobStateComplete), opts)
+	_, err := c.PollCompleteJob(ctx, jobGUID, opts)
 	return err
 }
+
+// PollCompleteJob waits until the job completes, fails, times out, or the context is done and returns the last
+// retrieved job, so warnings from a successful job can be inspected.
+//
+// If the job fails the returned error wraps a *JobFailedError.
+func (c *JobClient) PollCompleteJob(ctx context.Context, jobGUID string, opts *PollingOptions) (*resource.Job, error) {
+	var job *resource.Job
+	err := c.client.pollForState(ctx, func(ctx context.Context) (string, string, error) {
+		j, err := c.Get(ctx, jobGUID)
+		if err != nil {
+			return "", "", err
+		}
+		job = j
+		return string(job.State), "", nil
+	}, string(resource.JobStateComplete), opts.withFailedStates(string(resource.JobStateFailed)))
+	if err != nil && job != nil && job.State == resource.JobStateFailed {
+		return job, fmt.Errorf("received state %s while waiting for async process: %w", job.State, NewJobFailedError(job))
+	}
+	return job, err
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"github.com/stretchr/testify/require"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/cloudfoundry/go-cfclient/v3/resource"
 	"github.com/cloudfoundry/go-cfclient/v3/testutil"
 )
 
@@ -15,6 +17,7 @@ func TestJobs(t *testing.T) {
 	job := g.Job("COMPLETE").JSON
 	jobProcessing := g.Job("PROCESSING").JSON
 	jobFailed := g.JobFailed().JSON
+	jobWithWarnings := strings.Replace(jobFailed, `"state": "FAILED"`, `"state": "COMPLETE"`, 1)
 	pollingOpts := &PollingOptions{
 		FailedState:   "FAILED",
 		Timeout:       time.Second,
@@ -66,6 +69,49 @@ func TestJobs(t *testing.T) {
 				return nil, nil
 			},
 		},
+		{
+			Description: "Poll job that succeeds with warnings",
+			Route: testutil.MockRoute{
+				Method:   "GET",
+				Endpoint: "/v3/jobs/c33a5caf-77e0-4d6e-b587-5555d339bc9a",
+				Output:   []string{jobProcessing, jobWithWarnings},
+				Statuses: []int{http.StatusOK, http.StatusOK},
+			},
+			Action: func(c *Client, t *testing.T) (any, error) {
+				job, err := c.Jobs.PollCompleteJob(context.Background(), "c33a5caf-77e0-4d6e-b587-5555d339bc9a", pollingOpts)
+				require.NoError(t, err)
+				require.Equal(t, resource.JobStateComplete, job.State)
+				require.Equal(t, []resource.JobWarning{{Detail: "some warning"}}, job.Warnings)
+				return nil, nil
+			},
+		},
+		{
+			Description: "Poll job that fails with structured errors",
+			Route: testutil.MockRoute{
+				Method:   "GET",
+				Endpoint: "/v3/jobs/40e49716-44a3-4ae9-9926-d1a804acf70c",
+				Output:   []string{jobFailed},
+				Statuses: []int{http.StatusOK},
+			},
+			Action: func(c *Client, t *testing.T) (any, error) {
+				job, err := c.Jobs.PollCompleteJob(context.Background(), "40e49716-44a3-4ae9-9926-d1a804acf70c", pollingOpts)
+				require.Error(t, err)
+				require.Equal(t, resource.JobStateFailed, job.State)
+
+				var jobErr *JobFailedError
+				require.ErrorAs(t, err, &jobErr)
+				require.Equal(t, "app.create", jobErr.Operation)
+				require.Len(t, jobErr.Errors, 2)
+				require.Len(t, jobErr.Warnings, 1)
+				require.Same(t, job, jobErr.Job)
+				require.True(t, resource.IsUnprocessableEntityError(err))
+
+				var cfErr resource.CloudFoundryError
+				require.ErrorAs(t, err, &cfErr)
+				require.Equal(t, 10008, cfErr.Code)
+				return nil, nil
+			},
+		},
 	}
 	ExecuteTests(tests, t)
 }
@@ -0,0 +1,303 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	internal "github.com/cloudfoundry/go-cfclient/v3/internal/http"
+	"github.com/cloudfoundry/go-cfclient/v3/internal/ios"
+	"github.com/cloudfoundry/go-cfclient/v3/internal/path"
+	"github.com/cloudfoundry/go-cfclient/v3/internal/telemetry"
+	"github.com/cloudfoundry/go-cfclient/v3/resource"
+)
+
+// LogCacheClient reads the logs and metrics of apps from log-cache
+type LogCacheClient commonClient
+
+// LogCacheReadOptions filters the envelopes read from log-cache
+type LogCacheReadOptions struct {
+	// StartTime is the time of the oldest envelope to read, inclusive
+	StartTime time.Time
+
+	// EndTime is the time of the newest envelope to read, exclusive. Zero means now.
+	EndTime time.Time
+
+	// EnvelopeTypes limits the envelopes to the given types, all types are read when empty
+	EnvelopeTypes []resource.EnvelopeType
+
+	// NameFilter is a regular expression that metric envelopes' names must match
+	NameFilter string
+
+	// Limit is the maximum number of envelopes to read, log-cache defaults to 100 and allows up to 1000
+	Limit int
+
+	// Descending reads the newest envelopes first
+	Descending bool
+}
+
+// NewLogCacheReadOptions creates new options to pass to Read
+func NewLogCacheReadOptions() *LogCacheReadOptions {
+	return &LogCacheReadOptions{}
+}
+
+// ToQueryString converts the options to the log-cache read query parameters
+func (o *LogCacheReadOptions) ToQueryString() url.Values {
+	values := url.Values{}
+	if !o.StartTime.IsZero() {
+		values.Set("start_time", strconv.FormatInt(o.StartTime.UnixNano(), 10))
+	}
+	if !o.EndTime.IsZero() {
+		values.Set("end_time", strconv.FormatInt(o.EndTime.UnixNano(), 10))
+	}
+	for _, t := range o.EnvelopeTypes {
+		values.Add("envelope_types", t.String())
+	}
+	if o.NameFilter != "" {
+		values.Set("name_filter", o.NameFilter)
+	}
+	if o.Limit > 0 {
+		values.Set("limit", strconv.Itoa(o.Limit))
+	}
+	if o.Descending {
+		values.Set("descending", "true")
+	}
+	return values
+}
+
+// LogCacheTailOptions controls which envelopes Tail returns
+type LogCacheTailOptions struct {
+	// Lines is the number of recent envelopes returned first
+	Lines int
+
+	// Follow keeps returning new envelopes as they're written to log-cache until the context is done
+	Follow bool
+
+	// PollInterval is how often log-cache is checked for new envelopes when following
+	PollInterval time.Duration
+
+	// EnvelopeTypes limits the envelopes to the given types, all types are returned when empty
+	EnvelopeTypes []resource.EnvelopeType
+}
+
+// NewLogCacheTailOptions creates new options to pass to Tail that return the last 10 envelopes
+func NewLogCacheTailOptions() *LogCacheTailOptions {
+	return &LogCacheTailOptions{
+		Lines:        10,
+		PollInterval: time.Second,
+	}
+}
+
+// logCacheReadLimit is the maximum number of envelopes log-cache returns from a single read
+const logCacheReadLimit = 1000
+
+// logCacheEndpoint caches the log-cache URL advertised by the API root
+type logCacheEndpoint struct {
+	mu  sync.Mutex
+	url string
+}
+
+// Read returns the envelopes of the source, usually an app GUID, that match the options
+func (c *LogCacheClient) Read(ctx context.Context, sourceGUID string, opts *LogCacheReadOptions) ([]*resource.Envelope, error) {
+	if opts == nil {
+		opts = NewLogCacheReadOptions()
+	}
+	var batch resource.EnvelopeBatch
+	err := c.get(ctx, path.Format("/api/v1/read/%s?%s", sourceGUID, opts.ToQueryString()), &batch)
+	if err != nil {
+		return nil, err
+	}
+	return batch.Envelopes.Batch, nil
+}
+
+// envelopeCursor tracks where reading new envelopes from log-cache resumes. The start time of a read is inclusive
+// and envelopes can share a timestamp, so the envelopes already read at the latest timestamp are remembered and
+// skipped when they're read again, instead of skipping the rest of the timestamp.
+type envelopeCursor struct {
+	next time.Time
+	seen map[string]struct{}
+}
+
+func newEnvelopeCursor(start time.Time) *envelopeCursor {
+	return &envelopeCursor{next: start, seen: make(map[string]struct{})}
+}
+
+// add moves the cursor past the envelope, returning false if the envelope is before the cursor or was already added
+func (c *envelopeCursor) add(envelope *resource.Envelope) bool {
+	t := envelope.Time()
+	if t.Before(c.next) {
+		return false
+	}
+	if t.After(c.next) {
+		c.next = t
+		clear(c.seen)
+	}
+	key, _ := json.Marshal(envelope)
+	if _, ok := c.seen[string(key)]; ok {
+		return false
+	}
+	c.seen[string(key)] = struct{}{}
+	return true
+}
+
+// Tail returns an iterator of the most recent envelopes of the source, oldest first. With Follow, the iterator
+// then returns each new envelope until the context is done, ending with the context's error.
+func (c *LogCacheClient) Tail(ctx context.Context, sourceGUID string, opts *LogCacheTailOptions) iter.Seq2[*resource.Envelope, error] {
+	if opts == nil {
+		opts = NewLogCacheTailOptions()
+	}
+	return func(yield func(*resource.Envelope, error) bool) {
+		// envelopes written after the recent ones were read are returned when following
+		cursor := newEnvelopeCursor(time.Now())
+		if opts.Lines > 0 {
+			readOpts := NewLogCacheReadOptions()
+			readOpts.EnvelopeTypes = opts.EnvelopeTypes
+			readOpts.EndTime = cursor.next
+			readOpts.Limit = min(opts.Lines, logCacheReadLimit)
+			readOpts.Descending = true
+			envelopes, err := c.Read(ctx, sourceGUID, readOpts)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, envelope := range slices.Backward(envelopes) {
+				cursor.add(envelope)
+				if !yield(envelope, nil) {
+					return
+				}
+			}
+		}
+		if !opts.Follow {
+			return
+		}
+
+		interval := opts.PollInterval
+		if interval <= 0 {
+			interval = time.Second
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			readOpts := NewLogCacheReadOptions()
+			readOpts.EnvelopeTypes = opts.EnvelopeTypes
+			readOpts.StartTime = cursor.next
+			readOpts.Limit = logCacheReadLimit
+			envelopes, err := c.Read(ctx, sourceGUID, readOpts)
+			if err != nil {
+				if ctxErr := ctx.Err(); ctxErr != nil {
+					err = ctxErr
+				}
+				yield(nil, err)
+				return
+			}
+			added := false
+			for _, envelope := range envelopes {
+				if !cursor.add(envelope) {
+					continue
+				}
+				added = true
+				if !yield(envelope, nil) {
+					return
+				}
+			}
+			if len(envelopes) == logCacheReadLimit && added {
+				continue
+			}
+			select {
+			case <-ctx.Done():
+				yield(nil, ctx.Err())
+				return
+			case <-ticker.C:
+			}
+		}
+	}
+}
+
+// Query evaluates a PromQL expression against the metrics in log-cache at a single point in time, or now
+// when the time is zero
+//
+// The metrics of an app are selected by its GUID, i.e. cpu{source_id="<app guid>"}
+func (c *LogCacheClient) Query(ctx context.Context, query string, at time.Time) (*resource.PromQLResult, error) {
+	values := url.Values{}
+	values.Set("query", query)
+	if !at.IsZero() {
+		values.Set("time", formatPromQLTime(at))
+	}
+	var resp resource.PromQLResponse
+	if err := c.get(ctx, path.Format("/api/v1/query?%s", values), &resp); err != nil {
+		return nil, err
+	}
+	return resp.Result()
+}
+
+// QueryRange evaluates a PromQL expression against the metrics in log-cache at each step over a time range
+func (c *LogCacheClient) QueryRange(ctx context.Context, query string, start, end time.Time, step time.Duration) (*resource.PromQLResult, error) {
+	if step <= 0 {
+		return nil, errors.New("the query step must be greater than zero")
+	}
+	values := url.Values{}
+	values.Set("query", query)
+	values.Set("start", formatPromQLTime(start))
+	values.Set("end", formatPromQLTime(end))
+	values.Set("step", strconv.FormatFloat(step.Seconds(), 'f', -1, 64))
+	var resp resource.PromQLResponse
+	if err := c.get(ctx, path.Format("/api/v1/query_range?%s", values), &resp); err != nil {
+		return nil, err
+	}
+	return resp.Result()
+}
+
+// formatPromQLTime formats the time as decimal Unix seconds
+func formatPromQLTime(t time.Time) string {
+	return strconv.FormatFloat(float64(t.UnixNano())/float64(time.Second), 'f', -1, 64)
+}
+
+// get does an authenticated HTTP GET to the log-cache endpoint and unmarshalls the result JSON body
+func (c *LogCacheClient) get(ctx context.Context, resourcePath string, result any) (err error) {
+	ctx, span := c.client.startOperation(ctx)
+	defer func() { telemetry.End(span, err) }()
+
+	logCacheURL, err := c.url(ctx)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, logCacheURL+resourcePath, nil)
+	if err != nil {
+		return fmt.Errorf("error creating log-cache GET request for %s: %w", resourcePath, err)
+	}
+	resp, err := c.client.ExecuteAuthRequest(req)
+	if err != nil {
+		return fmt.Errorf("error executing log-cache GET request for %s: %w", resourcePath, err)
+	}
+	defer ios.Close(resp.Body)
+	return internal.DecodeBody(resp, result)
+}
+
+// url returns the log-cache URL from the API root, which is only looked up once
+func (c *LogCacheClient) url(ctx context.Context) (string, error) {
+	endpoint := &c.client.logCache
+	endpoint.mu.Lock()
+	defer endpoint.mu.Unlock()
+	if endpoint.url != "" {
+		return endpoint.url, nil
+	}
+
+	root, err := c.client.Root.Get(ctx)
+	if err != nil {
+		return "", fmt.Errorf("error looking up the log-cache URL: %w", err)
+	}
+	if root.Links.LogCache.Href == "" {
+		return "", errors.New("the API doesn't advertise a log-cache URL")
+	}
+	endpoint.url = strings.TrimSuffix(root.Links.LogCache.Href, "/")
+	return endpoint.url, nil
+}
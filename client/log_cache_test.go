@@ -0,0 +1,216 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudfoundry/go-cfclient/v3/resource"
+	"github.com/cloudfoundry/go-cfclient/v3/testutil"
+)
+
+func TestLogCache(t *testing.T) {
+	logs := `{"envelopes":{"batch":[
+		{"timestamp":"1700000000000000001","source_id":"app-guid","instance_id":"0","tags":{"source_type":"STG"},"log":{"payload":"U3RhZ2luZy4uLg==","type":"OUT"}},
+		{"timestamp":"1700000000000000002","source_id":"app-guid","instance_id":"0","tags":{"source_type":"APP/PROC/WEB"},"log":{"payload":"ZmFpbGVk","type":"ERR"}}
+	]}}`
+	expected := `[
+		{"timestamp":"1700000000000000001","source_id":"app-guid","instance_id":"0","tags":{"source_type":"STG"},"log":{"payload":"U3RhZ2luZy4uLg==","type":"OUT"}},
+		{"timestamp":"1700000000000000002","source_id":"app-guid","instance_id":"0","tags":{"source_type":"APP/PROC/WEB"},"log":{"payload":"ZmFpbGVk","type":"ERR"}}
+	]`
+
+	metrics := `{"envelopes":{"batch":[
+		{"timestamp":"1700000000000000001","source_id":"app-guid","instance_id":"0","tags":{},"gauge":{"metrics":{"cpu":{"unit":"percentage","value":2.5}}}},
+		{"timestamp":"1700000000000000002","source_id":"app-guid","instance_id":"0","tags":{},"counter":{"name":"requests","delta":"1","total":"42"}},
+		{"timestamp":"1700000000000000003","source_id":"app-guid","instance_id":"0","tags":{},"timer":{"name":"http","start":"1700000000000000000","stop":"1700000000005000000"}},
+		{"timestamp":"1700000000000000004","source_id":"app-guid","instance_id":"0","tags":{},"event":{"title":"crash","body":"exited with 1"}}
+	]}}`
+	recentLogs := `{"envelopes":{"batch":[
+		{"timestamp":"1700000000000000002","source_id":"app-guid","instance_id":"0","tags":{},"log":{"payload":"c2Vjb25k","type":"OUT"}},
+		{"timestamp":"1700000000000000001","source_id":"app-guid","instance_id":"0","tags":{},"log":{"payload":"Zmlyc3Q=","type":"OUT"}}
+	]}}`
+	newLogs := `{"envelopes":{"batch":[
+		{"timestamp":"1700000000000000002","source_id":"app-guid","instance_id":"0","tags":{},"log":{"payload":"c2Vjb25k","type":"OUT"}},
+		{"timestamp":"4000000000000000000","source_id":"app-guid","instance_id":"0","tags":{},"log":{"payload":"dGhpcmQ=","type":"OUT"}}
+	]}}`
+	sameTimestampLogs := `{"envelopes":{"batch":[
+		{"timestamp":"4000000000000000000","source_id":"app-guid","instance_id":"0","tags":{},"log":{"payload":"dGhpcmQ=","type":"OUT"}},
+		{"timestamp":"4000000000000000000","source_id":"app-guid","instance_id":"1","tags":{},"log":{"payload":"Zm91cnRo","type":"OUT"}}
+	]}}`
+	vector := `{"status":"success","data":{"resultType":"vector","result":[
+		{"metric":{"source_id":"app-guid","instance_id":"0"},"value":[1700000000,"2.5"]},
+		{"metric":{"source_id":"app-guid","instance_id":"1"},"value":[1700000000,"3"]}
+	]}}`
+	matrix := `{"status":"success","data":{"resultType":"matrix","result":[
+		{"metric":{"source_id":"app-guid"},"values":[[1700000000,"1"],[1700000060.5,"2"]]}
+	]}}`
+
+	tests := []RouteTest{
+		{
+			Description: "Read envelopes",
+			Route: testutil.MockRoute{
+				Method:      "GET",
+				Endpoint:    "/api/v1/read/app-guid",
+				Output:      []string{logs},
+				Status:      http.StatusOK,
+				QueryString: "descending=true&end_time=1700000060000000000&envelope_types=LOG&limit=1000&start_time=1700000000000000000",
+			},
+			Expected: expected,
+			Action: func(c *Client, t *testing.T) (any, error) {
+				opts := NewLogCacheReadOptions()
+				opts.StartTime = time.Unix(1700000000, 0)
+				opts.EndTime = time.Unix(1700000060, 0)
+				opts.EnvelopeTypes = []resource.EnvelopeType{resource.EnvelopeTypeLog}
+				opts.Limit = 1000
+				opts.Descending = true
+				return c.LogCache.Read(context.Background(), "app-guid", opts)
+			},
+		},
+		{
+			Description: "Read envelopes without options",
+			Route: testutil.MockRoute{
+				Method:   "GET",
+				Endpoint: "/api/v1/read/app-guid",
+				Output:   []string{`{"envelopes":{"batch":[]}}`},
+				Status:   http.StatusOK,
+			},
+			Expected: "[]",
+			Action: func(c *Client, t *testing.T) (any, error) {
+				return c.LogCache.Read(context.Background(), "app-guid", nil)
+			},
+		},
+		{
+			Description: "Read metric envelopes",
+			Route: testutil.MockRoute{
+				Method:      "GET",
+				Endpoint:    "/api/v1/read/app-guid",
+				Output:      []string{metrics},
+				Status:      http.StatusOK,
+				QueryString: "envelope_types=GAUGE&envelope_types=COUNTER&envelope_types=TIMER&envelope_types=EVENT&name_filter=cpu|requests",
+			},
+			Action: func(c *Client, t *testing.T) (any, error) {
+				opts := NewLogCacheReadOptions()
+				opts.EnvelopeTypes = []resource.EnvelopeType{resource.EnvelopeTypeGauge, resource.EnvelopeTypeCounter,
+					resource.EnvelopeTypeTimer, resource.EnvelopeTypeEvent}
+				opts.NameFilter = "cpu|requests"
+				envelopes, err := c.LogCache.Read(context.Background(), "app-guid", opts)
+				require.NoError(t, err)
+				require.Len(t, envelopes, 4)
+				require.Equal(t, resource.GaugeValue{Unit: "percentage", Value: 2.5}, envelopes[0].Gauge.Metrics["cpu"])
+				require.Equal(t, &resource.CounterEnvelope{Name: "requests", Delta: 1, Total: 42}, envelopes[1].Counter)
+				require.Equal(t, 5*time.Millisecond, envelopes[2].Timer.Duration())
+				require.Equal(t, &resource.EventEnvelope{Title: "crash", Body: "exited with 1"}, envelopes[3].Event)
+				require.Nil(t, envelopes[0].Log)
+				return nil, err
+			},
+		},
+		{
+			Description: "Tail recent envelopes",
+			Route: testutil.MockRoute{
+				Method:   "GET",
+				Endpoint: "/api/v1/read/app-guid",
+				Output:   []string{recentLogs},
+				Status:   http.StatusOK,
+			},
+			Action: func(c *Client, t *testing.T) (any, error) {
+				var lines []string
+				for envelope, err := range c.LogCache.Tail(context.Background(), "app-guid", nil) {
+					require.NoError(t, err)
+					lines = append(lines, string(envelope.Log.Payload))
+				}
+				require.Equal(t, []string{"first", "second"}, lines)
+				return nil, nil
+			},
+		},
+		{
+			Description: "Tail and follow envelopes",
+			Route: testutil.MockRoute{
+				Method:   "GET",
+				Endpoint: "/api/v1/read/app-guid",
+				Output:   []string{recentLogs, newLogs},
+				Status:   http.StatusOK,
+			},
+			Action: func(c *Client, t *testing.T) (any, error) {
+				opts := NewLogCacheTailOptions()
+				opts.Follow = true
+				var lines []string
+				for envelope, err := range c.LogCache.Tail(context.Background(), "app-guid", opts) {
+					require.NoError(t, err)
+					lines = append(lines, string(envelope.Log.Payload))
+					if len(lines) == 3 {
+						break
+					}
+				}
+				require.Equal(t, []string{"first", "second", "third"}, lines)
+				return nil, nil
+			},
+		},
+		{
+			Description: "Tail and follow envelopes with the same timestamp",
+			Route: testutil.MockRoute{
+				Method:   "GET",
+				Endpoint: "/api/v1/read/app-guid",
+				Output:   []string{recentLogs, newLogs, sameTimestampLogs},
+				Status:   http.StatusOK,
+			},
+			Action: func(c *Client, t *testing.T) (any, error) {
+				opts := NewLogCacheTailOptions()
+				opts.Follow = true
+				opts.PollInterval = time.Millisecond
+				var lines []string
+				for envelope, err := range c.LogCache.Tail(context.Background(), "app-guid", opts) {
+					require.NoError(t, err)
+					lines = append(lines, string(envelope.Log.Payload))
+					if len(lines) == 4 {
+						break
+					}
+				}
+				require.Equal(t, []string{"first", "second", "third", "fourth"}, lines)
+				return nil, nil
+			},
+		},
+		{
+			Description: "Instant PromQL query",
+			Route: testutil.MockRoute{
+				Method:      "GET",
+				Endpoint:    "/api/v1/query",
+				Output:      []string{vector},
+				Status:      http.StatusOK,
+				QueryString: `query=cpu{source_id="app-guid"}&time=1700000000`,
+			},
+			Expected: `{"ResultType":"vector","Vector":[
+				{"metric":{"source_id":"app-guid","instance_id":"0"},"value":[1700000000,"2.5"]},
+				{"metric":{"source_id":"app-guid","instance_id":"1"},"value":[1700000000,"3"]}
+			],"Matrix":null,"Scalar":null}`,
+			Action: func(c *Client, t *testing.T) (any, error) {
+				return c.LogCache.Query(context.Background(), `cpu{source_id="app-guid"}`, time.Unix(1700000000, 0))
+			},
+		},
+		{
+			Description: "Range PromQL query",
+			Route: testutil.MockRoute{
+				Method:      "GET",
+				Endpoint:    "/api/v1/query_range",
+				Output:      []string{matrix},
+				Status:      http.StatusOK,
+				QueryString: `end=1700000120&query=sum(cpu{source_id="app-guid"})&start=1700000000&step=60`,
+			},
+			Action: func(c *Client, t *testing.T) (any, error) {
+				result, err := c.LogCache.QueryRange(context.Background(), `sum(cpu{source_id="app-guid"})`,
+					time.Unix(1700000000, 0), time.Unix(1700000120, 0), time.Minute)
+				require.NoError(t, err)
+				require.Equal(t, resource.PromQLResultTypeMatrix, result.ResultType)
+				require.Len(t, result.Matrix, 1)
+				require.Equal(t, []resource.PromQLPoint{
+					{Time: time.Unix(1700000000, 0).UTC(), Value: 1},
+					{Time: time.Unix(1700000060, 500000000).UTC(), Value: 2},
+				}, result.Matrix[0].Points)
+				return nil, err
+			},
+		},
+	}
+	ExecuteTests(tests, t)
+}
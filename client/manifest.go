@@ -10,13 +10,17 @@ import (
 	internalhttp "github.com/cloudfoundry/go-cfclient/v3/internal/http"
 	"github.com/cloudfoundry/go-cfclient/v3/internal/ios"
 	"github.com/cloudfoundry/go-cfclient/v3/internal/path"
+	"github.com/cloudfoundry/go-cfclient/v3/internal/telemetry"
 	"github.com/cloudfoundry/go-cfclient/v3/resource"
 )
 
 type ManifestClient commonClient
 
 // Generate the specified app manifest as a yaml text string
-func (c *ManifestClient) Generate(ctx context.Context, appGUID string) (string, error) {
+func (c *ManifestClient) Generate(ctx context.Context, appGUID string) (_ string, err error) {
+	ctx, span := c.client.startOperation(ctx)
+	defer func() { telemetry.End(span, err) }()
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.client.ApiURL(path.Format("/v3/apps/%s/manifest", appGUID)), nil)
 	if err != nil {
 		return "", fmt.Errorf("failed to create manifest request for app %s: %w", appGUID, err)
@@ -40,7 +44,10 @@ func (c *ManifestClient) Generate(ctx context.Context, appGUID string) (string,
 //
 // The apps must reside in the space. These changes are additive and will not modify any unspecified
 // properties or remove any existing environment variables, routes, or services.
-func (c *ManifestClient) ApplyManifest(ctx context.Context, spaceGUID string, manifest string) (string, error) {
+func (c *ManifestClient) ApplyManifest(ctx context.Context, spaceGUID string, manifest string) (_ string, err error) {
+	ctx, span := c.client.startOperation(ctx)
+	defer func() { telemetry.End(span, err) }()
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.client.ApiURL(path.Format("/v3/spaces/%s/actions/apply_manifest", spaceGUID)), strings.NewReader(manifest))
 	if err != nil {
 		return "", fmt.Errorf("failed to create manifest apply request for space %s: %w", spaceGUID, err)
@@ -56,7 +63,10 @@ func (c *ManifestClient) ApplyManifest(ctx context.Context, spaceGUID string, ma
 }
 
 // ManifestDiff compares the provided manifest against the current state of the space.
-func (c *ManifestClient) ManifestDiff(ctx context.Context, spaceGUID string, manifest string) (*resource.ManifestDiff, error) {
+func (c *ManifestClient) ManifestDiff(ctx context.Context, spaceGUID string, manifest string) (_ *resource.ManifestDiff, err error) {
+	ctx, span := c.client.startOperation(ctx)
+	defer func() { telemetry.End(span, err) }()
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.client.ApiURL(path.Format("/v3/spaces/%s/manifest_diff", spaceGUID)), strings.NewReader(manifest))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create manifest diff request for space %s: %w", spaceGUID, err)
@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"iter"
 	"net/url"
 
 	"github.com/cloudfoundry/go-cfclient/v3/internal/path"
@@ -117,6 +118,16 @@ func (c *OrganizationClient) GetUsageSummary(ctx context.Context, guid string) (
 	return &summary, nil
 }
 
+// Iter returns an iterator over all organizations the user has access to, fetching each page only as needed
+func (c *OrganizationClient) Iter(ctx context.Context, opts *OrganizationListOptions) iter.Seq2[*resource.Organization, error] {
+	if opts == nil {
+		opts = NewOrganizationListOptions()
+	}
+	return AutoPageIter[*OrganizationListOptions, *resource.Organization](ctx, opts, func(opts *OrganizationListOptions) ([]*resource.Organization, *Pager, error) {
+		return c.List(ctx, opts)
+	})
+}
+
 // List pages all organizations the user has access to
 func (c *OrganizationClient) List(ctx context.Context, opts *OrganizationListOptions) ([]*resource.Organization, *Pager, error) {
 	if opts == nil {
@@ -136,7 +147,7 @@ func (c *OrganizationClient) ListAll(ctx context.Context, opts *OrganizationList
 	if opts == nil {
 		opts = NewOrganizationListOptions()
 	}
-	return AutoPage[*OrganizationListOptions, *resource.Organization](opts, func(opts *OrganizationListOptions) ([]*resource.Organization, *Pager, error) {
+	return autoPage[*OrganizationListOptions, *resource.Organization](ctx, c.client, opts, func(ctx context.Context, opts *OrganizationListOptions) ([]*resource.Organization, *Pager, error) {
 		return c.List(ctx, opts)
 	})
 }
@@ -160,7 +171,7 @@ func (c *OrganizationClient) ListForIsolationSegmentAll(ctx context.Context, iso
 	if opts == nil {
 		opts = NewOrganizationListOptions()
 	}
-	return AutoPage[*OrganizationListOptions, *resource.Organization](opts, func(opts *OrganizationListOptions) ([]*resource.Organization, *Pager, error) {
+	return autoPage[*OrganizationListOptions, *resource.Organization](ctx, c.client, opts, func(ctx context.Context, opts *OrganizationListOptions) ([]*resource.Organization, *Pager, error) {
 		return c.ListForIsolationSegment(ctx, isolationSegmentGUID, opts)
 	})
 }
@@ -184,7 +195,7 @@ func (c *OrganizationClient) ListUsersAll(ctx context.Context, guid string, opts
 	if opts == nil {
 		opts = NewUserListOptions()
 	}
-	return AutoPage[*UserListOptions, *resource.User](opts, func(opts *UserListOptions) ([]*resource.User, *Pager, error) {
+	return autoPage[*UserListOptions, *resource.User](ctx, c.client, opts, func(ctx context.Context, opts *UserListOptions) ([]*resource.User, *Pager, error) {
 		return c.ListUsers(ctx, guid, opts)
 	})
 }
@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"iter"
 	"net/url"
 
 	"github.com/cloudfoundry/go-cfclient/v3/internal/path"
@@ -77,6 +78,16 @@ func (c *OrganizationQuotaClient) Get(ctx context.Context, guid string) (*resour
 	return &app, nil
 }
 
+// Iter returns an iterator over all organization quotas the user has access to, fetching each page only as needed
+func (c *OrganizationQuotaClient) Iter(ctx context.Context, opts *OrganizationQuotaListOptions) iter.Seq2[*resource.OrganizationQuota, error] {
+	if opts == nil {
+		opts = NewOrganizationQuotaListOptions()
+	}
+	return AutoPageIter[*OrganizationQuotaListOptions, *resource.OrganizationQuota](ctx, opts, func(opts *OrganizationQuotaListOptions) ([]*resource.OrganizationQuota, *Pager, error) {
+		return c.List(ctx, opts)
+	})
+}
+
 // List pages all organization quotas the user has access to
 func (c *OrganizationQuotaClient) List(ctx context.Context, opts *OrganizationQuotaListOptions) ([]*resource.OrganizationQuota, *Pager, error) {
 	if opts == nil {
@@ -97,7 +108,7 @@ func (c *OrganizationQuotaClient) ListAll(ctx context.Context, opts *Organizatio
 	if opts == nil {
 		opts = NewOrganizationQuotaListOptions()
 	}
-	return AutoPage[*OrganizationQuotaListOptions, *resource.OrganizationQuota](opts, func(opts *OrganizationQuotaListOptions) ([]*resource.OrganizationQuota, *Pager, error) {
+	return autoPage[*OrganizationQuotaListOptions, *resource.OrganizationQuota](ctx, c.client, opts, func(ctx context.Context, opts *OrganizationQuotaListOptions) ([]*resource.OrganizationQuota, *Pager, error) {
 		return c.List(ctx, opts)
 	})
 }
@@ -2,7 +2,10 @@ package client
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"io"
+	"iter"
 	"net/url"
 
 	"github.com/cloudfoundry/go-cfclient/v3/internal/path"
@@ -94,6 +97,16 @@ func (c *PackageClient) Get(ctx context.Context, guid string) (*resource.Package
 	return &p, nil
 }
 
+// Iter returns an iterator over all packages the user has access to, fetching each page only as needed
+func (c *PackageClient) Iter(ctx context.Context, opts *PackageListOptions) iter.Seq2[*resource.Package, error] {
+	if opts == nil {
+		opts = NewPackageListOptions()
+	}
+	return AutoPageIter[*PackageListOptions, *resource.Package](ctx, opts, func(opts *PackageListOptions) ([]*resource.Package, *Pager, error) {
+		return c.List(ctx, opts)
+	})
+}
+
 // List pages all the packages the user has access to
 func (c *PackageClient) List(ctx context.Context, opts *PackageListOptions) ([]*resource.Package, *Pager, error) {
 	if opts == nil {
@@ -112,7 +125,7 @@ func (c *PackageClient) ListAll(ctx context.Context, opts *PackageListOptions) (
 	if opts == nil {
 		opts = NewPackageListOptions()
 	}
-	return AutoPage[*PackageListOptions, *resource.Package](opts, func(opts *PackageListOptions) ([]*resource.Package, *Pager, error) {
+	return autoPage[*PackageListOptions, *resource.Package](ctx, c.client, opts, func(ctx context.Context, opts *PackageListOptions) ([]*resource.Package, *Pager, error) {
 		return c.List(ctx, opts)
 	})
 }
@@ -136,20 +149,21 @@ func (c *PackageClient) ListForAppAll(ctx context.Context, appGUID string, opts
 	if opts == nil {
 		opts = NewPackageListOptions()
 	}
-	return AutoPage[*PackageListOptions, *resource.Package](opts, func(opts *PackageListOptions) ([]*resource.Package, *Pager, error) {
+	return autoPage[*PackageListOptions, *resource.Package](ctx, c.client, opts, func(ctx context.Context, opts *PackageListOptions) ([]*resource.Package, *Pager, error) {
 		return c.ListForApp(ctx, appGUID, opts)
 	})
 }
 
-// PollReady waits until the package is ready, fails, or times out
+// PollReady waits until the package is ready, fails, times out, or the context is done
 func (c *PackageClient) PollReady(ctx context.Context, guid string, opts *PollingOptions) error {
-	return PollForStateOrTimeout(func() (string, string, error) {
+	return c.client.pollForState(ctx, func(ctx context.Context) (string, string, error) {
 		pkg, err := c.Get(ctx, guid)
 		if pkg != nil {
 			return string(pkg.State), "", err
 		}
 		return "", "", err
-	}, string(resource.PackageStateReady), opts)
+	}, string(resource.PackageStateReady), opts.withFailedStates(
+		string(resource.PackageStateFailed), string(resource.PackageStateExpired)))
 }
 
 // Single returns a single package matching the options or an error if not exactly 1 match
@@ -183,3 +197,21 @@ func (c *PackageClient) Upload(ctx context.Context, guid string, zipFile io.Read
 	_, err := c.client.postFileUpload(ctx, p, "bits", "package.zip", zipFile, &pkg)
 	return &pkg, err
 }
+
+// UploadWithResources uploads an app's zip file contents along with the resources the CF API already has
+// cached, as reported by ResourceMatchClient.Create, so they don't need to be included in the zip
+//
+// The zip file may be nil if all the app's files are cached.
+func (c *PackageClient) UploadWithResources(ctx context.Context, guid string, zipFile io.Reader, cached []resource.ResourceMatch) (*resource.Package, error) {
+	if cached == nil {
+		cached = []resource.ResourceMatch{}
+	}
+	resources, err := json.Marshal(cached)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling the cached package resources: %w", err)
+	}
+	p := path.Format("/v3/packages/%s/upload", guid)
+	var pkg resource.Package
+	_, err = c.client.postMultipartUpload(ctx, p, map[string]string{"resources": string(resources)}, "bits", "package.zip", zipFile, &pkg)
+	return &pkg, err
+}
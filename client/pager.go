@@ -1,9 +1,14 @@
 package client
 
 import (
+	"context"
 	"errors"
+	"iter"
+	"reflect"
+	"sync"
 
 	"github.com/cloudfoundry/go-cfclient/v3/internal/path"
+	"github.com/cloudfoundry/go-cfclient/v3/internal/telemetry"
 	"github.com/cloudfoundry/go-cfclient/v3/resource"
 )
 
@@ -56,6 +61,8 @@ func (p *Pager) PreviousPage(opts ListOptioner) {
 
 type ListFunc[T ListOptioner, R any] func(opts T) ([]R, *Pager, error)
 
+// AutoPage returns all objects returned by list, fetching each page in turn. The opts are advanced as pages are
+// fetched, so they're left at the last page.
 func AutoPage[T ListOptioner, R any](opts T, list ListFunc[T, R]) ([]R, error) {
 	var all []R
 	for {
@@ -72,6 +79,125 @@ func AutoPage[T ListOptioner, R any](opts T, list ListFunc[T, R]) ([]R, error) {
 	return all, nil
 }
 
+// AutoPageConcurrent is like AutoPage, but after fetching the first page it uses the total page count to fetch
+// the remaining pages concurrently with at most the specified number of workers. The results are returned in
+// page order.
+//
+// Each worker pages a copy of opts, so opts must be a pointer to a list options struct. If it isn't, or
+// workers is less than 2, the pages are fetched sequentially. Either way the opts are left at the last page
+// like AutoPage.
+func AutoPageConcurrent[T ListOptioner, R any](opts T, workers int, list ListFunc[T, R]) ([]R, error) {
+	first, pager, err := list(opts)
+	if err != nil {
+		return nil, err
+	}
+	if !pager.HasNextPage() {
+		return first, nil
+	}
+	startPage := pager.NextPageReader.Int(PageField)
+	perPage := pager.NextPageReader.Int(PerPageField)
+	if _, ok := cloneListOptions(opts); !ok || workers < 2 || startPage >= pager.TotalPages {
+		pager.NextPage(opts)
+		rest, err := AutoPage[T, R](opts, list)
+		if err != nil {
+			return nil, err
+		}
+		return append(first, rest...), nil
+	}
+
+	// fetch pages [startPage, TotalPages] concurrently, each into its own slot
+	pages := make([][]R, pager.TotalPages-startPage+1)
+	pageNums := make(chan int)
+	done := make(chan struct{})
+	var lastPager *Pager
+	var firstErr error
+	var errOnce sync.Once
+	var wg sync.WaitGroup
+	for i := 0; i < min(workers, len(pages)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for page := range pageNums {
+				pageOpts, _ := cloneListOptions(opts)
+				pageOpts.CurrentPage(page, perPage)
+				results, p, err := list(pageOpts)
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						close(done)
+					})
+					return
+				}
+				pages[page-startPage] = results
+				if page == pager.TotalPages {
+					lastPager = p
+				}
+			}
+		}()
+	}
+
+dispatch:
+	for page := startPage; page <= pager.TotalPages; page++ {
+		select {
+		case pageNums <- page:
+		case <-done:
+			break dispatch
+		}
+	}
+	close(pageNums)
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	all := first
+	for _, page := range pages {
+		all = append(all, page...)
+	}
+
+	// resources may have been created while listing, so pick up any pages beyond the original total
+	opts.CurrentPage(pager.TotalPages, perPage)
+	if lastPager != nil && lastPager.HasNextPage() {
+		lastPager.NextPage(opts)
+		rest, err := AutoPage[T, R](opts, list)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, rest...)
+	}
+	return all, nil
+}
+
+// AutoPageIter returns an iterator over all objects returned by list, lazily fetching the next page only
+// once the caller has consumed the current one.
+//
+// Iteration stops as soon as the caller breaks out of the loop, the context is done, or a page request
+// fails, in which case the error is yielded as the final element. The opts are advanced as pages are fetched.
+func AutoPageIter[T ListOptioner, R any](ctx context.Context, opts T, list ListFunc[T, R]) iter.Seq2[R, error] {
+	return func(yield func(R, error) bool) {
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(*new(R), err)
+				return
+			}
+			page, pager, err := list(opts)
+			if err != nil {
+				yield(*new(R), err)
+				return
+			}
+			for _, r := range page {
+				if !yield(r, nil) {
+					return
+				}
+			}
+			if !pager.HasNextPage() {
+				return
+			}
+			pager.NextPage(opts)
+		}
+	}
+}
+
 // Single returns a single object from the call to list or an error if matches > 1 or matches < 1
 func Single[T ListOptioner, R any](opts T, list ListFunc[T, R]) (R, error) {
 	matches, _, err := list(opts)
@@ -95,3 +221,86 @@ func First[T ListOptioner, R any](opts T, list ListFunc[T, R]) (R, error) {
 	}
 	return matches[0], nil
 }
+
+// autoPage fetches all pages using AutoPageConcurrent if the client is configured for concurrent
+// ListAll requests, otherwise it uses AutoPage. The pages are fetched in a single operation span.
+func autoPage[T ListOptioner, R any](ctx context.Context, c *Client, opts T, list func(ctx context.Context, opts T) ([]R, *Pager, error)) (_ []R, err error) {
+	ctx, span := c.startOperation(ctx)
+	defer func() { telemetry.End(span, err) }()
+
+	listPage := func(opts T) ([]R, *Pager, error) {
+		return list(ctx, opts)
+	}
+	if workers := c.ListAllConcurrency(); workers > 1 {
+		return AutoPageConcurrent[T, R](opts, workers, listPage)
+	}
+	return AutoPage[T, R](opts, listPage)
+}
+
+// includedPage is a page of resources along with the resources included with them
+type includedPage[R, I, J any] struct {
+	resources []R
+	included  []I
+	included2 []J
+}
+
+// autoPageInclude fetches all pages of resources and the resources included with them like autoPage, so pages
+// are fetched concurrently if the client is configured for it
+func autoPageInclude[T ListOptioner, R, I any](ctx context.Context, c *Client, opts T, list func(ctx context.Context, opts T) ([]R, []I, *Pager, error)) ([]R, []I, error) {
+	all, included, _, err := autoPageInclude2(ctx, c, opts, func(ctx context.Context, opts T) ([]R, []I, []struct{}, *Pager, error) {
+		page, included, pager, err := list(ctx, opts)
+		return page, included, nil, pager, err
+	})
+	return all, included, err
+}
+
+// autoPageInclude2 is like autoPageInclude for lists that include two types of resources
+func autoPageInclude2[T ListOptioner, R, I, J any](ctx context.Context, c *Client, opts T, list func(ctx context.Context, opts T) ([]R, []I, []J, *Pager, error)) ([]R, []I, []J, error) {
+	pages, err := autoPage(ctx, c, opts, func(ctx context.Context, opts T) ([]includedPage[R, I, J], *Pager, error) {
+		page, included, included2, pager, err := list(ctx, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+		return []includedPage[R, I, J]{{resources: page, included: included, included2: included2}}, pager, nil
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var all []R
+	var allIncluded []I
+	var allIncluded2 []J
+	for _, page := range pages {
+		all = append(all, page.resources...)
+		allIncluded = append(allIncluded, page.included...)
+		allIncluded2 = append(allIncluded2, page.included2...)
+	}
+	return all, allIncluded, allIncluded2, nil
+}
+
+// cloneListOptions returns a copy of opts that can be paged independently of the original. Embedded
+// pointers, like *ListOptions, are copied too. False is returned if opts isn't a pointer to a struct.
+func cloneListOptions[T ListOptioner](opts T) (T, bool) {
+	v := reflect.ValueOf(opts)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return opts, false
+	}
+	c := reflect.New(v.Elem().Type())
+	c.Elem().Set(v.Elem())
+	cloneEmbeddedPointers(c.Elem())
+	return c.Interface().(T), true
+}
+
+func cloneEmbeddedPointers(v reflect.Value) {
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Field(i)
+		if !v.Type().Field(i).Anonymous || !f.CanSet() ||
+			f.Kind() != reflect.Ptr || f.IsNil() || f.Elem().Kind() != reflect.Struct {
+			continue
+		}
+		c := reflect.New(f.Elem().Type())
+		c.Elem().Set(f.Elem())
+		cloneEmbeddedPointers(c.Elem())
+		f.Set(c)
+	}
+}
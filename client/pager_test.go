@@ -1,7 +1,11 @@
 package client
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/cloudfoundry/go-cfclient/v3/resource"
 
@@ -107,3 +111,144 @@ func TestPager(t *testing.T) {
 	require.Equal(t, 1, listOpts.Page)
 	require.Equal(t, 50, listOpts.PerPage)
 }
+
+func TestAutoPageIter(t *testing.T) {
+	pages := [][]int{{1, 2}, {3, 4}, {5}}
+	var calls int
+	list := func(opts *AppListOptions) ([]int, *Pager, error) {
+		calls++
+		pagination := resource.Pagination{TotalPages: len(pages)}
+		if opts.Page < len(pages) {
+			pagination.Next.Href = fmt.Sprintf("https://api.example.org/v3/apps?page=%d&per_page=2", opts.Page+1)
+		}
+		return pages[opts.Page-1], NewPager(pagination), nil
+	}
+
+	t.Run("iterates all pages", func(t *testing.T) {
+		calls = 0
+		var all []int
+		for i, err := range AutoPageIter(context.Background(), NewAppListOptions(), list) {
+			require.NoError(t, err)
+			all = append(all, i)
+		}
+		require.Equal(t, []int{1, 2, 3, 4, 5}, all)
+		require.Equal(t, 3, calls)
+	})
+
+	t.Run("stops fetching when the caller breaks", func(t *testing.T) {
+		calls = 0
+		for i, err := range AutoPageIter(context.Background(), NewAppListOptions(), list) {
+			require.NoError(t, err)
+			if i == 2 {
+				break
+			}
+		}
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("yields list errors", func(t *testing.T) {
+		listErr := errors.New("list failed")
+		failing := func(opts *AppListOptions) ([]int, *Pager, error) {
+			return nil, nil, listErr
+		}
+		var errs []error
+		for _, err := range AutoPageIter(context.Background(), NewAppListOptions(), failing) {
+			errs = append(errs, err)
+		}
+		require.Equal(t, []error{listErr}, errs)
+	})
+
+	t.Run("stops when the context is cancelled", func(t *testing.T) {
+		calls = 0
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var lastErr error
+		for i, err := range AutoPageIter(ctx, NewAppListOptions(), list) {
+			if err != nil {
+				lastErr = err
+				break
+			}
+			if i == 2 {
+				cancel()
+			}
+		}
+		require.ErrorIs(t, lastErr, context.Canceled)
+		require.Equal(t, 1, calls)
+	})
+}
+
+func TestAutoPageConcurrent(t *testing.T) {
+	const totalPages = 7
+	list := func(opts *AppListOptions) ([]int, *Pager, error) {
+		pagination := resource.Pagination{TotalPages: totalPages}
+		if opts.Page < totalPages {
+			pagination.Next.Href = fmt.Sprintf("https://api.example.org/v3/apps?page=%d&per_page=2", opts.Page+1)
+		}
+		if opts.Page > 1 {
+			// make later pages finish first
+			time.Sleep(time.Duration(totalPages-opts.Page) * time.Millisecond)
+		}
+		return []int{opts.Page*2 - 1, opts.Page * 2}, NewPager(pagination), nil
+	}
+	expected := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14}
+
+	t.Run("fetches pages concurrently in order", func(t *testing.T) {
+		opts := NewAppListOptions()
+		all, err := AutoPageConcurrent(opts, 3, list)
+		require.NoError(t, err)
+		require.Equal(t, expected, all)
+		require.Equal(t, totalPages, opts.Page)
+		require.Equal(t, 2, opts.PerPage)
+	})
+
+	t.Run("falls back to sequential paging", func(t *testing.T) {
+		opts := NewAppListOptions()
+		all, err := AutoPageConcurrent(opts, 1, list)
+		require.NoError(t, err)
+		require.Equal(t, expected, all)
+		require.Equal(t, totalPages, opts.Page)
+	})
+
+	t.Run("returns the first error", func(t *testing.T) {
+		listErr := errors.New("page 4 failed")
+		failing := func(opts *AppListOptions) ([]int, *Pager, error) {
+			if opts.Page == 4 {
+				return nil, nil, listErr
+			}
+			return list(opts)
+		}
+		_, err := AutoPageConcurrent(NewAppListOptions(), 3, failing)
+		require.ErrorIs(t, err, listErr)
+	})
+
+	t.Run("picks up pages added while listing", func(t *testing.T) {
+		growing := func(opts *AppListOptions) ([]int, *Pager, error) {
+			total := totalPages - 2
+			if opts.Page > 1 {
+				total = totalPages
+			}
+			pagination := resource.Pagination{TotalPages: total}
+			if opts.Page < total {
+				pagination.Next.Href = fmt.Sprintf("https://api.example.org/v3/apps?page=%d&per_page=2", opts.Page+1)
+			}
+			return []int{opts.Page*2 - 1, opts.Page * 2}, NewPager(pagination), nil
+		}
+		opts := NewAppListOptions()
+		all, err := AutoPageConcurrent(opts, 3, growing)
+		require.NoError(t, err)
+		require.Equal(t, expected, all)
+		require.Equal(t, totalPages, opts.Page)
+	})
+}
+
+func TestCloneListOptions(t *testing.T) {
+	opts := NewAppListOptions()
+	opts.Names.EqualTo("app1")
+	clone, ok := cloneListOptions(opts)
+	require.True(t, ok)
+	clone.CurrentPage(5, 10)
+	require.Equal(t, 1, opts.Page)
+	require.Equal(t, 50, opts.PerPage)
+	require.Equal(t, 5, clone.Page)
+	require.Equal(t, opts.Names, clone.Names)
+}
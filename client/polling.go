@@ -1,9 +1,13 @@
 package client
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
+
+	"github.com/cloudfoundry/go-cfclient/v3/internal/telemetry"
 )
 
 var ErrAsyncProcessTimeout = errors.New("timed out after waiting for async process")
@@ -12,18 +16,116 @@ type PollingOptions struct {
 	Timeout       time.Duration
 	CheckInterval time.Duration
 	FailedState   string
+
+	// FailedStates are additional terminal states, besides FailedState, that stop polling with an error
+	FailedStates []string
+
+	// BackoffMultiplier grows the CheckInterval after each check, values <= 1 keep a fixed interval
+	BackoffMultiplier float64
+
+	// MaxCheckInterval caps the CheckInterval when using a BackoffMultiplier, zero means no cap
+	MaxCheckInterval time.Duration
 }
 
 func NewPollingOptions() *PollingOptions {
 	return &PollingOptions{
-		FailedState:   "FAILED",
-		Timeout:       time.Minute * 5,
-		CheckInterval: time.Second,
+		FailedState:       "FAILED",
+		Timeout:           time.Minute * 5,
+		CheckInterval:     time.Second,
+		BackoffMultiplier: 1.5,
+		MaxCheckInterval:  time.Second * 15,
 	}
 }
 
+// AsyncProcessTimeoutError is returned when an async process doesn't reach a terminal state before the
+// polling timeout. It matches ErrAsyncProcessTimeout when using errors.Is.
+type AsyncProcessTimeoutError struct {
+	Timeout   time.Duration
+	LastState string
+}
+
+func (e *AsyncProcessTimeoutError) Error() string {
+	if e.LastState == "" {
+		return fmt.Sprintf("%s after %s", ErrAsyncProcessTimeout.Error(), e.Timeout)
+	}
+	return fmt.Sprintf("%s after %s, last state was %s", ErrAsyncProcessTimeout.Error(), e.Timeout, e.LastState)
+}
+
+func (e *AsyncProcessTimeoutError) Is(target error) bool {
+	return target == ErrAsyncProcessTimeout
+}
+
 type getStateFunc func() (string, string, error)
 
+// StateFunc returns the current state of an async process along with any error detail reported by the API
+type StateFunc func(ctx context.Context) (state string, detail string, err error)
+
+// PollForState checks the state of an async process until it reaches the success state, a failed state, the
+// polling timeout expires, or the context is done.
+//
+// The first check happens immediately, after that the check interval grows by the BackoffMultiplier up to the
+// MaxCheckInterval. A timeout returns an *AsyncProcessTimeoutError with the last observed state.
+func PollForState(ctx context.Context, getState StateFunc, successState string, opts *PollingOptions) error {
+	if opts == nil {
+		opts = NewPollingOptions()
+	}
+
+	pollCtx := ctx
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		pollCtx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+	timedOut := func(lastState string) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return &AsyncProcessTimeoutError{Timeout: opts.Timeout, LastState: lastState}
+	}
+
+	var lastState string
+	interval := opts.CheckInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	for {
+		state, detail, err := getState(pollCtx)
+		if err != nil {
+			if pollCtx.Err() != nil {
+				return timedOut(lastState)
+			}
+			return err
+		}
+		lastState = state
+		if state == successState {
+			return nil
+		}
+		if opts.isFailedState(state) {
+			return fmt.Errorf("received state %s while waiting for async process: %s", state, detail)
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-pollCtx.Done():
+			timer.Stop()
+			return timedOut(lastState)
+		case <-timer.C:
+		}
+		interval = opts.nextCheckInterval(interval)
+	}
+}
+
+// pollForState is PollForState in a single operation span, so every check is a child of it
+func (c *Client) pollForState(ctx context.Context, getState StateFunc, successState string, opts *PollingOptions) (err error) {
+	ctx, span := c.startOperation(ctx)
+	defer func() { telemetry.End(span, err) }()
+	return PollForState(ctx, getState, successState, opts)
+}
+
+// PollForStateOrTimeout checks the state of an async process every CheckInterval until it reaches the success
+// state, the failed state or times out.
+//
+// Deprecated: use PollForState which supports cancellation, backoff and multiple failed states.
 func PollForStateOrTimeout(getState getStateFunc, successState string, opts *PollingOptions) error {
 	if opts == nil {
 		opts = NewPollingOptions()
@@ -51,3 +153,28 @@ func PollForStateOrTimeout(getState getStateFunc, successState string, opts *Pol
 		}
 	}
 }
+
+// withFailedStates returns a copy of the options that also treats the specified states as failures
+func (o *PollingOptions) withFailedStates(states ...string) *PollingOptions {
+	if o == nil {
+		o = NewPollingOptions()
+	}
+	c := *o
+	c.FailedStates = append(slices.Clone(o.FailedStates), states...)
+	return &c
+}
+
+func (o *PollingOptions) isFailedState(state string) bool {
+	return (o.FailedState != "" && state == o.FailedState) || slices.Contains(o.FailedStates, state)
+}
+
+func (o *PollingOptions) nextCheckInterval(interval time.Duration) time.Duration {
+	if o.BackoffMultiplier <= 1 {
+		return interval
+	}
+	next := time.Duration(float64(interval) * o.BackoffMultiplier)
+	if o.MaxCheckInterval > 0 && next > o.MaxCheckInterval {
+		return o.MaxCheckInterval
+	}
+	return next
+}
@@ -1,6 +1,8 @@
 package client
 
 import (
+	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -41,3 +43,84 @@ func TestPollForStateOrTimeout(t *testing.T) {
 	err = PollForStateOrTimeout(timeoutFn, "SUCCESS", noWaitOpts)
 	require.Equal(t, ErrAsyncProcessTimeout, err)
 }
+
+func TestPollForState(t *testing.T) {
+	noWaitOpts := NewPollingOptions()
+	noWaitOpts.Timeout = 50 * time.Millisecond
+	noWaitOpts.CheckInterval = time.Millisecond
+
+	stateFn := func(states ...string) StateFunc {
+		var calls int
+		return func(ctx context.Context) (string, string, error) {
+			state := states[min(calls, len(states)-1)]
+			calls++
+			return state, CustomStagingErr, nil
+		}
+	}
+
+	t.Run("checks immediately", func(t *testing.T) {
+		opts := NewPollingOptions()
+		opts.CheckInterval = time.Hour
+		err := PollForState(context.Background(), stateFn("SUCCESS"), "SUCCESS", opts)
+		require.NoError(t, err)
+	})
+
+	t.Run("succeeds after processing", func(t *testing.T) {
+		err := PollForState(context.Background(), stateFn("PROCESSING", "PROCESSING", "SUCCESS"), "SUCCESS", noWaitOpts)
+		require.NoError(t, err)
+	})
+
+	t.Run("fails on any failed state", func(t *testing.T) {
+		opts := noWaitOpts.withFailedStates("EXPIRED")
+		err := PollForState(context.Background(), stateFn("PROCESSING", "EXPIRED"), "SUCCESS", opts)
+		require.EqualError(t, err, "received state EXPIRED while waiting for async process: "+CustomStagingErr)
+		require.Empty(t, noWaitOpts.FailedStates)
+
+		err = PollForState(context.Background(), stateFn("FAILED"), "SUCCESS", opts)
+		require.EqualError(t, err, "received state FAILED while waiting for async process: "+CustomStagingErr)
+	})
+
+	t.Run("returns get state errors", func(t *testing.T) {
+		getErr := errors.New("get failed")
+		err := PollForState(context.Background(), func(ctx context.Context) (string, string, error) {
+			return "", "", getErr
+		}, "SUCCESS", noWaitOpts)
+		require.ErrorIs(t, err, getErr)
+	})
+
+	t.Run("times out with the last state", func(t *testing.T) {
+		err := PollForState(context.Background(), stateFn("PROCESSING"), "SUCCESS", noWaitOpts)
+		require.ErrorIs(t, err, ErrAsyncProcessTimeout)
+		var timeoutErr *AsyncProcessTimeoutError
+		require.ErrorAs(t, err, &timeoutErr)
+		require.Equal(t, "PROCESSING", timeoutErr.LastState)
+		require.Equal(t, noWaitOpts.Timeout, timeoutErr.Timeout)
+		require.EqualError(t, err, "timed out after waiting for async process after 50ms, last state was PROCESSING")
+	})
+
+	t.Run("stops when the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		opts := NewPollingOptions()
+		opts.CheckInterval = time.Hour
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			cancel()
+		}()
+		err := PollForState(ctx, stateFn("PROCESSING"), "SUCCESS", opts)
+		require.ErrorIs(t, err, context.Canceled)
+		require.NotErrorIs(t, err, ErrAsyncProcessTimeout)
+	})
+
+	t.Run("backs off up to the max interval", func(t *testing.T) {
+		opts := &PollingOptions{
+			CheckInterval:     time.Second,
+			BackoffMultiplier: 2,
+			MaxCheckInterval:  5 * time.Second,
+		}
+		require.Equal(t, 2*time.Second, opts.nextCheckInterval(time.Second))
+		require.Equal(t, 5*time.Second, opts.nextCheckInterval(4*time.Second))
+
+		opts.BackoffMultiplier = 0
+		require.Equal(t, time.Second, opts.nextCheckInterval(time.Second))
+	})
+}
@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"iter"
 	"net/url"
 
 	"github.com/cloudfoundry/go-cfclient/v3/internal/path"
@@ -76,6 +77,16 @@ func (c *ProcessClient) GetStatsForApp(ctx context.Context, appGUID, processType
 	return &stats, nil
 }
 
+// Iter returns an iterator over all processes, fetching each page only as needed
+func (c *ProcessClient) Iter(ctx context.Context, opts *ProcessListOptions) iter.Seq2[*resource.Process, error] {
+	if opts == nil {
+		opts = NewProcessOptions()
+	}
+	return AutoPageIter[*ProcessListOptions, *resource.Process](ctx, opts, func(opts *ProcessListOptions) ([]*resource.Process, *Pager, error) {
+		return c.List(ctx, opts)
+	})
+}
+
 // List pages all processes
 func (c *ProcessClient) List(ctx context.Context, opts *ProcessListOptions) ([]*resource.Process, *Pager, error) {
 	if opts == nil {
@@ -96,7 +107,7 @@ func (c *ProcessClient) ListAll(ctx context.Context, opts *ProcessListOptions) (
 	if opts == nil {
 		opts = NewProcessOptions()
 	}
-	return AutoPage[*ProcessListOptions, *resource.Process](opts, func(opts *ProcessListOptions) ([]*resource.Process, *Pager, error) {
+	return autoPage[*ProcessListOptions, *resource.Process](ctx, c.client, opts, func(ctx context.Context, opts *ProcessListOptions) ([]*resource.Process, *Pager, error) {
 		return c.List(ctx, opts)
 	})
 }
@@ -121,7 +132,7 @@ func (c *ProcessClient) ListForAppAll(ctx context.Context, appGUID string, opts
 	if opts == nil {
 		opts = NewProcessOptions()
 	}
-	return AutoPage[*ProcessListOptions, *resource.Process](opts, func(opts *ProcessListOptions) ([]*resource.Process, *Pager, error) {
+	return autoPage[*ProcessListOptions, *resource.Process](ctx, c.client, opts, func(ctx context.Context, opts *ProcessListOptions) ([]*resource.Process, *Pager, error) {
 		return c.ListForApp(ctx, appGUID, opts)
 	})
 }
@@ -0,0 +1,148 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// RateLimitStats reports how requests have been throttled by the client-side rate limiter and concurrency cap
+type RateLimitStats struct {
+	// Requests is the total number of requests that passed through the limiter
+	Requests uint64
+
+	// Delayed is the number of requests that had to wait before being sent
+	Delayed uint64
+
+	// TotalWait is the cumulative time requests spent waiting
+	TotalWait time.Duration
+
+	// MaxWait is the longest time a single request spent waiting
+	MaxWait time.Duration
+
+	// InFlight is the number of requests currently waiting for their response headers
+	InFlight int64
+}
+
+// requestLimiter combines a token bucket rate limiter with a semaphore that caps in-flight requests
+type requestLimiter struct {
+	// token bucket, nil limit disables rate limiting
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+
+	// in-flight semaphore, nil disables the concurrency cap
+	slots chan struct{}
+
+	requests  atomic.Uint64
+	delayed   atomic.Uint64
+	totalWait atomic.Int64
+	maxWait   atomic.Int64
+	inFlight  atomic.Int64
+}
+
+// newRequestLimiter creates a requestLimiter or returns nil if neither rate limiting nor a concurrency cap
+// is configured
+func newRequestLimiter(requestsPerSecond float64, burst, maxInFlight int) *requestLimiter {
+	if requestsPerSecond <= 0 && maxInFlight <= 0 {
+		return nil
+	}
+	l := &requestLimiter{
+		rate:   requestsPerSecond,
+		burst:  float64(max(burst, 1)),
+		tokens: float64(max(burst, 1)),
+		last:   time.Now(),
+	}
+	if maxInFlight > 0 {
+		l.slots = make(chan struct{}, maxInFlight)
+	}
+	return l
+}
+
+// acquire blocks until the request is allowed to be sent or the context is done. The returned release func
+// must be called exactly once when the request completes.
+func (l *requestLimiter) acquire(ctx context.Context) (func(), error) {
+	start := time.Now()
+	if err := l.waitForToken(ctx); err != nil {
+		return nil, err
+	}
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	l.record(time.Since(start))
+
+	l.inFlight.Add(1)
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.inFlight.Add(-1)
+			if l.slots != nil {
+				<-l.slots
+			}
+		})
+	}, nil
+}
+
+// waitForToken reserves a token from the bucket and waits until it becomes available
+func (l *requestLimiter) waitForToken(ctx context.Context) error {
+	if l.rate <= 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	l.tokens--
+	var wait time.Duration
+	if l.tokens < 0 {
+		wait = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+
+	if err := sleepWithContext(ctx, wait); err != nil {
+		// give back the reservation since the request won't be sent
+		l.mu.Lock()
+		l.tokens = min(l.burst, l.tokens+1)
+		l.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// record updates the wait time metrics
+func (l *requestLimiter) record(waited time.Duration) {
+	l.requests.Add(1)
+	if waited < time.Millisecond {
+		return
+	}
+	l.delayed.Add(1)
+	l.totalWait.Add(int64(waited))
+	for {
+		current := l.maxWait.Load()
+		if int64(waited) <= current || l.maxWait.CompareAndSwap(current, int64(waited)) {
+			return
+		}
+	}
+}
+
+// stats returns a snapshot of the limiter metrics
+func (l *requestLimiter) stats() RateLimitStats {
+	if l == nil {
+		return RateLimitStats{}
+	}
+	return RateLimitStats{
+		Requests:  l.requests.Load(),
+		Delayed:   l.delayed.Load(),
+		TotalWait: time.Duration(l.totalWait.Load()),
+		MaxWait:   time.Duration(l.maxWait.Load()),
+		InFlight:  l.inFlight.Load(),
+	}
+}
@@ -0,0 +1,137 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cloudfoundry/go-cfclient/v3/config"
+	"github.com/cloudfoundry/go-cfclient/v3/testutil"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestLimiter(t *testing.T) {
+	t.Run("disabled when not configured", func(t *testing.T) {
+		require.Nil(t, newRequestLimiter(0, 0, 0))
+		require.Equal(t, RateLimitStats{}, (*requestLimiter)(nil).stats())
+	})
+
+	t.Run("rate limits after burst", func(t *testing.T) {
+		l := newRequestLimiter(100, 2, 0)
+		start := time.Now()
+		for i := 0; i < 5; i++ {
+			release, err := l.acquire(context.Background())
+			require.NoError(t, err)
+			release()
+		}
+		// 2 burst tokens, then 3 more at 10ms each
+		require.GreaterOrEqual(t, time.Since(start), 25*time.Millisecond)
+
+		stats := l.stats()
+		require.Equal(t, uint64(5), stats.Requests)
+		require.Positive(t, stats.Delayed)
+		require.Positive(t, stats.TotalWait)
+		require.Positive(t, stats.MaxWait)
+		require.Equal(t, int64(0), stats.InFlight)
+	})
+
+	t.Run("caps concurrent requests", func(t *testing.T) {
+		l := newRequestLimiter(0, 0, 1)
+		release, err := l.acquire(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, int64(1), l.stats().InFlight)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err = l.acquire(ctx)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		acquired := make(chan error)
+		go func() {
+			r, err := l.acquire(context.Background())
+			if err == nil {
+				r()
+			}
+			acquired <- err
+		}()
+		time.Sleep(5 * time.Millisecond)
+		release()
+		release() // releasing twice is a no-op
+		require.NoError(t, <-acquired)
+		require.Equal(t, int64(0), l.stats().InFlight)
+	})
+
+	t.Run("cancelled while waiting for a token", func(t *testing.T) {
+		l := newRequestLimiter(1, 1, 0)
+		release, err := l.acquire(context.Background())
+		require.NoError(t, err)
+		release()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err = l.acquire(ctx)
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestClientRateLimitStats(t *testing.T) {
+	g := testutil.NewObjectJSONGenerator()
+	app := g.Application()
+	serverURL := testutil.Setup(testutil.MockRoute{
+		Method:   "GET",
+		Endpoint: "/v3/apps/" + app.GUID,
+		Output:   []string{app.JSON, app.JSON},
+		Status:   200,
+	}, t)
+	defer testutil.Teardown()
+
+	cfg, err := config.New(serverURL,
+		config.Token("", "fake-refresh-token"),
+		config.RateLimit(1000, 1),
+		config.MaxConcurrentRequests(1))
+	require.NoError(t, err)
+	cf, err := New(cfg)
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		_, err = cf.Applications.Get(context.Background(), app.GUID)
+		require.NoError(t, err)
+	}
+	stats := cf.RateLimitStats()
+	require.Equal(t, uint64(2), stats.Requests)
+	require.Equal(t, int64(0), stats.InFlight)
+}
+
+func TestClientConcurrencyCapWithOpenBody(t *testing.T) {
+	g := testutil.NewObjectJSONGenerator()
+	app := g.Application()
+	serverURL := testutil.Setup(testutil.MockRoute{
+		Method:   "GET",
+		Endpoint: "/v3/apps/" + app.GUID,
+		Output:   []string{app.JSON, app.JSON},
+		Status:   200,
+	}, t)
+	defer testutil.Teardown()
+
+	cfg, err := config.New(serverURL,
+		config.Token("", "fake-refresh-token"),
+		config.MaxConcurrentRequests(1))
+	require.NoError(t, err)
+	cf, err := New(cfg)
+	require.NoError(t, err)
+
+	// keep the first response body open, like a caller streaming a download
+	req, err := http.NewRequest(http.MethodGet, serverURL+"/v3/apps/"+app.GUID, nil)
+	require.NoError(t, err)
+	resp, err := cf.ExecuteAuthRequest(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = cf.Applications.Get(ctx, app.GUID)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), cf.RateLimitStats().InFlight)
+}
@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"iter"
 	"net/url"
 
 	"github.com/cloudfoundry/go-cfclient/v3/internal/path"
@@ -166,6 +167,16 @@ func (c *RoleClient) GetIncludeUsers(ctx context.Context, guid string) (*resourc
 	return &role.Role, role.Included.Users, nil
 }
 
+// Iter returns an iterator over all roles the user has access to, fetching each page only as needed
+func (c *RoleClient) Iter(ctx context.Context, opts *RoleListOptions) iter.Seq2[*resource.Role, error] {
+	if opts == nil {
+		opts = NewRoleListOptions()
+	}
+	return AutoPageIter[*RoleListOptions, *resource.Role](ctx, opts, func(opts *RoleListOptions) ([]*resource.Role, *Pager, error) {
+		return c.List(ctx, opts)
+	})
+}
+
 // List all roles the user has access to in paged results
 func (c *RoleClient) List(ctx context.Context, opts *RoleListOptions) ([]*resource.Role, *Pager, error) {
 	if opts == nil {
@@ -185,7 +196,7 @@ func (c *RoleClient) ListAll(ctx context.Context, opts *RoleListOptions) ([]*res
 	if opts == nil {
 		opts = NewRoleListOptions()
 	}
-	return AutoPage[*RoleListOptions, *resource.Role](opts, func(opts *RoleListOptions) ([]*resource.Role, *Pager, error) {
+	return autoPage[*RoleListOptions, *resource.Role](ctx, c.client, opts, func(ctx context.Context, opts *RoleListOptions) ([]*resource.Role, *Pager, error) {
 		return c.List(ctx, opts)
 	})
 }
@@ -213,21 +224,9 @@ func (c *RoleClient) ListIncludeOrganizationsAll(ctx context.Context, opts *Role
 	}
 	opts.Include = resource.RoleIncludeOrganization
 
-	var all []*resource.Role
-	var allOrgs []*resource.Organization
-	for {
-		page, orgs, pager, err := c.ListIncludeOrganizations(ctx, opts)
-		if err != nil {
-			return nil, nil, err
-		}
-		all = append(all, page...)
-		allOrgs = append(allOrgs, orgs...)
-		if !pager.HasNextPage() {
-			break
-		}
-		pager.NextPage(opts)
-	}
-	return all, allOrgs, nil
+	return autoPageInclude(ctx, c.client, opts, func(ctx context.Context, opts *RoleListOptions) ([]*resource.Role, []*resource.Organization, *Pager, error) {
+		return c.ListIncludeOrganizations(ctx, opts)
+	})
 }
 
 // ListIncludeSpaces pages all roles and specified and includes spaces that have the roles
@@ -253,21 +252,9 @@ func (c *RoleClient) ListIncludeSpacesAll(ctx context.Context, opts *RoleListOpt
 	}
 	opts.Include = resource.RoleIncludeSpace
 
-	var all []*resource.Role
-	var allSpaces []*resource.Space
-	for {
-		page, spaces, pager, err := c.ListIncludeSpaces(ctx, opts)
-		if err != nil {
-			return nil, nil, err
-		}
-		all = append(all, page...)
-		allSpaces = append(allSpaces, spaces...)
-		if !pager.HasNextPage() {
-			break
-		}
-		pager.NextPage(opts)
-	}
-	return all, allSpaces, nil
+	return autoPageInclude(ctx, c.client, opts, func(ctx context.Context, opts *RoleListOptions) ([]*resource.Role, []*resource.Space, *Pager, error) {
+		return c.ListIncludeSpaces(ctx, opts)
+	})
 }
 
 // ListIncludeUsers pages all roles and specified and includes users that belong to the roles
@@ -293,21 +280,9 @@ func (c *RoleClient) ListIncludeUsersAll(ctx context.Context, opts *RoleListOpti
 	}
 	opts.Include = resource.RoleIncludeUser
 
-	var all []*resource.Role
-	var allUsers []*resource.User
-	for {
-		page, users, pager, err := c.ListIncludeUsers(ctx, opts)
-		if err != nil {
-			return nil, nil, err
-		}
-		all = append(all, page...)
-		allUsers = append(allUsers, users...)
-		if !pager.HasNextPage() {
-			break
-		}
-		pager.NextPage(opts)
-	}
-	return all, allUsers, nil
+	return autoPageInclude(ctx, c.client, opts, func(ctx context.Context, opts *RoleListOptions) ([]*resource.Role, []*resource.User, *Pager, error) {
+		return c.ListIncludeUsers(ctx, opts)
+	})
 }
 
 // Single returns a single role matching the options or an error if not exactly 1 match
@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"iter"
 	"net/url"
 
 	"github.com/cloudfoundry/go-cfclient/v3/internal/path"
@@ -183,6 +184,16 @@ func (c *RouteClient) IsRouteReserved(ctx context.Context, domainGUID string, op
 	return match["matching_route"], nil
 }
 
+// Iter returns an iterator over all routes the user has access to, fetching each page only as needed
+func (c *RouteClient) Iter(ctx context.Context, opts *RouteListOptions) iter.Seq2[*resource.Route, error] {
+	if opts == nil {
+		opts = NewRouteListOptions()
+	}
+	return AutoPageIter[*RouteListOptions, *resource.Route](ctx, opts, func(opts *RouteListOptions) ([]*resource.Route, *Pager, error) {
+		return c.List(ctx, opts)
+	})
+}
+
 // List pages routes the user has access to
 func (c *RouteClient) List(ctx context.Context, opts *RouteListOptions) ([]*resource.Route, *Pager, error) {
 	if opts == nil {
@@ -204,7 +215,7 @@ func (c *RouteClient) ListAll(ctx context.Context, opts *RouteListOptions) ([]*r
 	if opts == nil {
 		opts = NewRouteListOptions()
 	}
-	return AutoPage[*RouteListOptions, *resource.Route](opts, func(opts *RouteListOptions) ([]*resource.Route, *Pager, error) {
+	return autoPage[*RouteListOptions, *resource.Route](ctx, c.client, opts, func(ctx context.Context, opts *RouteListOptions) ([]*resource.Route, *Pager, error) {
 		return c.List(ctx, opts)
 	})
 }
@@ -230,7 +241,7 @@ func (c *RouteClient) ListForAppAll(ctx context.Context, appGUID string, opts *R
 	if opts == nil {
 		opts = NewRouteListOptions()
 	}
-	return AutoPage[*RouteListOptions, *resource.Route](opts, func(opts *RouteListOptions) ([]*resource.Route, *Pager, error) {
+	return autoPage[*RouteListOptions, *resource.Route](ctx, c.client, opts, func(ctx context.Context, opts *RouteListOptions) ([]*resource.Route, *Pager, error) {
 		return c.ListForApp(ctx, appGUID, opts)
 	})
 }
@@ -257,21 +268,9 @@ func (c *RouteClient) ListIncludeDomainsAll(ctx context.Context, opts *RouteList
 		opts = NewRouteListOptions()
 	}
 
-	var all []*resource.Route
-	var allDomains []*resource.Domain
-	for {
-		page, domains, pager, err := c.ListIncludeDomains(ctx, opts)
-		if err != nil {
-			return nil, nil, err
-		}
-		all = append(all, page...)
-		allDomains = append(allDomains, domains...)
-		if !pager.HasNextPage() {
-			break
-		}
-		pager.NextPage(opts)
-	}
-	return all, allDomains, nil
+	return autoPageInclude(ctx, c.client, opts, func(ctx context.Context, opts *RouteListOptions) ([]*resource.Route, []*resource.Domain, *Pager, error) {
+		return c.ListIncludeDomains(ctx, opts)
+	})
 }
 
 // ListIncludeSpaces page all routes the user has access to and include the parent spaces
@@ -296,21 +295,9 @@ func (c *RouteClient) ListIncludeSpacesAll(ctx context.Context, opts *RouteListO
 		opts = NewRouteListOptions()
 	}
 
-	var all []*resource.Route
-	var allSpaces []*resource.Space
-	for {
-		page, spaces, pager, err := c.ListIncludeSpaces(ctx, opts)
-		if err != nil {
-			return nil, nil, err
-		}
-		all = append(all, page...)
-		allSpaces = append(allSpaces, spaces...)
-		if !pager.HasNextPage() {
-			break
-		}
-		pager.NextPage(opts)
-	}
-	return all, allSpaces, nil
+	return autoPageInclude(ctx, c.client, opts, func(ctx context.Context, opts *RouteListOptions) ([]*resource.Route, []*resource.Space, *Pager, error) {
+		return c.ListIncludeSpaces(ctx, opts)
+	})
 }
 
 // ListIncludeSpacesAndOrganizations page all routes the user has access to and include the parent spaces and organizations
@@ -335,23 +322,9 @@ func (c *RouteClient) ListIncludeSpacesAndOrganizationsAll(ctx context.Context,
 		opts = NewRouteListOptions()
 	}
 
-	var all []*resource.Route
-	var allSpaces []*resource.Space
-	var allOrgs []*resource.Organization
-	for {
-		page, spaces, orgs, pager, err := c.ListIncludeSpacesAndOrganizations(ctx, opts)
-		if err != nil {
-			return nil, nil, nil, err
-		}
-		all = append(all, page...)
-		allSpaces = append(allSpaces, spaces...)
-		allOrgs = append(allOrgs, orgs...)
-		if !pager.HasNextPage() {
-			break
-		}
-		pager.NextPage(opts)
-	}
-	return all, allSpaces, allOrgs, nil
+	return autoPageInclude2(ctx, c.client, opts, func(ctx context.Context, opts *RouteListOptions) ([]*resource.Route, []*resource.Space, []*resource.Organization, *Pager, error) {
+		return c.ListIncludeSpacesAndOrganizations(ctx, opts)
+	})
 }
 
 // RemoveDestination removes a destination from a route
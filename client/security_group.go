@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"iter"
 	"net/url"
 
 	"github.com/cloudfoundry/go-cfclient/v3/internal/path"
@@ -127,6 +128,16 @@ func (c *SecurityGroupClient) Get(ctx context.Context, guid string) (*resource.S
 	return &d, nil
 }
 
+// Iter returns an iterator over all SecurityGroups the user has access to, fetching each page only as needed
+func (c *SecurityGroupClient) Iter(ctx context.Context, opts *SecurityGroupListOptions) iter.Seq2[*resource.SecurityGroup, error] {
+	if opts == nil {
+		opts = NewSecurityGroupListOptions()
+	}
+	return AutoPageIter[*SecurityGroupListOptions, *resource.SecurityGroup](ctx, opts, func(opts *SecurityGroupListOptions) ([]*resource.SecurityGroup, *Pager, error) {
+		return c.List(ctx, opts)
+	})
+}
+
 // List pages SecurityGroups the user has access to
 func (c *SecurityGroupClient) List(ctx context.Context, opts *SecurityGroupListOptions) ([]*resource.SecurityGroup, *Pager, error) {
 	if opts == nil {
@@ -146,7 +157,7 @@ func (c *SecurityGroupClient) ListAll(ctx context.Context, opts *SecurityGroupLi
 	if opts == nil {
 		opts = NewSecurityGroupListOptions()
 	}
-	return AutoPage[*SecurityGroupListOptions, *resource.SecurityGroup](opts, func(opts *SecurityGroupListOptions) ([]*resource.SecurityGroup, *Pager, error) {
+	return autoPage[*SecurityGroupListOptions, *resource.SecurityGroup](ctx, c.client, opts, func(ctx context.Context, opts *SecurityGroupListOptions) ([]*resource.SecurityGroup, *Pager, error) {
 		return c.List(ctx, opts)
 	})
 }
@@ -177,7 +188,7 @@ func (c *SecurityGroupClient) ListRunningForSpaceAll(ctx context.Context, spaceG
 	if opts == nil {
 		opts = NewSecurityGroupSpaceListOptions()
 	}
-	return AutoPage[*SecurityGroupSpaceListOptions, *resource.SecurityGroup](opts, func(opts *SecurityGroupSpaceListOptions) ([]*resource.SecurityGroup, *Pager, error) {
+	return autoPage[*SecurityGroupSpaceListOptions, *resource.SecurityGroup](ctx, c.client, opts, func(ctx context.Context, opts *SecurityGroupSpaceListOptions) ([]*resource.SecurityGroup, *Pager, error) {
 		return c.ListRunningForSpace(ctx, spaceGUID, opts)
 	})
 }
@@ -201,7 +212,7 @@ func (c *SecurityGroupClient) ListStagingForSpaceAll(ctx context.Context, spaceG
 	if opts == nil {
 		opts = NewSecurityGroupSpaceListOptions()
 	}
-	return AutoPage[*SecurityGroupSpaceListOptions, *resource.SecurityGroup](opts, func(opts *SecurityGroupSpaceListOptions) ([]*resource.SecurityGroup, *Pager, error) {
+	return autoPage[*SecurityGroupSpaceListOptions, *resource.SecurityGroup](ctx, c.client, opts, func(ctx context.Context, opts *SecurityGroupSpaceListOptions) ([]*resource.SecurityGroup, *Pager, error) {
 		return c.ListStagingForSpace(ctx, spaceGUID, opts)
 	})
 }
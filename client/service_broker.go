@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"iter"
 	"net/url"
 
 	"github.com/cloudfoundry/go-cfclient/v3/internal/path"
@@ -56,6 +57,16 @@ func (c *ServiceBrokerClient) Get(ctx context.Context, guid string) (*resource.S
 	return &sb, nil
 }
 
+// Iter returns an iterator over all service brokers the user has access to, fetching each page only as needed
+func (c *ServiceBrokerClient) Iter(ctx context.Context, opts *ServiceBrokerListOptions) iter.Seq2[*resource.ServiceBroker, error] {
+	if opts == nil {
+		opts = NewServiceBrokerListOptions()
+	}
+	return AutoPageIter[*ServiceBrokerListOptions, *resource.ServiceBroker](ctx, opts, func(opts *ServiceBrokerListOptions) ([]*resource.ServiceBroker, *Pager, error) {
+		return c.List(ctx, opts)
+	})
+}
+
 // List pages all the service brokers the user has access to
 func (c *ServiceBrokerClient) List(ctx context.Context, opts *ServiceBrokerListOptions) ([]*resource.ServiceBroker, *Pager, error) {
 	if opts == nil {
@@ -76,7 +87,7 @@ func (c *ServiceBrokerClient) ListAll(ctx context.Context, opts *ServiceBrokerLi
 	if opts == nil {
 		opts = NewServiceBrokerListOptions()
 	}
-	return AutoPage[*ServiceBrokerListOptions, *resource.ServiceBroker](opts, func(opts *ServiceBrokerListOptions) ([]*resource.ServiceBroker, *Pager, error) {
+	return autoPage[*ServiceBrokerListOptions, *resource.ServiceBroker](ctx, c.client, opts, func(ctx context.Context, opts *ServiceBrokerListOptions) ([]*resource.ServiceBroker, *Pager, error) {
 		return c.List(ctx, opts)
 	})
 }
@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"iter"
 	"net/url"
 
 	"github.com/cloudfoundry/go-cfclient/v3/internal/path"
@@ -116,6 +117,16 @@ func (c *ServiceCredentialBindingClient) GetIncludeServiceInstance(ctx context.C
 	return &r.ServiceCredentialBinding, r.Included.ServiceInstances[0], nil
 }
 
+// Iter returns an iterator over all ServiceCredentialBindings the user has access to, fetching each page only as needed
+func (c *ServiceCredentialBindingClient) Iter(ctx context.Context, opts *ServiceCredentialBindingListOptions) iter.Seq2[*resource.ServiceCredentialBinding, error] {
+	if opts == nil {
+		opts = NewServiceCredentialBindingListOptions()
+	}
+	return AutoPageIter[*ServiceCredentialBindingListOptions, *resource.ServiceCredentialBinding](ctx, opts, func(opts *ServiceCredentialBindingListOptions) ([]*resource.ServiceCredentialBinding, *Pager, error) {
+		return c.List(ctx, opts)
+	})
+}
+
 // List pages ServiceCredentialBindings the user has access to
 func (c *ServiceCredentialBindingClient) List(ctx context.Context, opts *ServiceCredentialBindingListOptions) ([]*resource.ServiceCredentialBinding, *Pager, error) {
 	var res resource.ServiceCredentialBindingList
@@ -132,7 +143,7 @@ func (c *ServiceCredentialBindingClient) ListAll(ctx context.Context, opts *Serv
 	if opts == nil {
 		opts = NewServiceCredentialBindingListOptions()
 	}
-	return AutoPage[*ServiceCredentialBindingListOptions, *resource.ServiceCredentialBinding](opts, func(opts *ServiceCredentialBindingListOptions) ([]*resource.ServiceCredentialBinding, *Pager, error) {
+	return autoPage[*ServiceCredentialBindingListOptions, *resource.ServiceCredentialBinding](ctx, c.client, opts, func(ctx context.Context, opts *ServiceCredentialBindingListOptions) ([]*resource.ServiceCredentialBinding, *Pager, error) {
 		return c.List(ctx, opts)
 	})
 }
@@ -159,21 +170,9 @@ func (c *ServiceCredentialBindingClient) ListIncludeAppsAll(ctx context.Context,
 		opts = NewServiceCredentialBindingListOptions()
 	}
 
-	var all []*resource.ServiceCredentialBinding
-	var allApps []*resource.App
-	for {
-		page, apps, pager, err := c.ListIncludeApps(ctx, opts)
-		if err != nil {
-			return nil, nil, err
-		}
-		all = append(all, page...)
-		allApps = append(allApps, apps...)
-		if !pager.HasNextPage() {
-			break
-		}
-		pager.NextPage(opts)
-	}
-	return all, allApps, nil
+	return autoPageInclude(ctx, c.client, opts, func(ctx context.Context, opts *ServiceCredentialBindingListOptions) ([]*resource.ServiceCredentialBinding, []*resource.App, *Pager, error) {
+		return c.ListIncludeApps(ctx, opts)
+	})
 }
 
 // ListIncludeServiceInstances pages all service credential bindings the user has access to and include the associated SIs
@@ -198,21 +197,9 @@ func (c *ServiceCredentialBindingClient) ListIncludeServiceInstancesAll(ctx cont
 		opts = NewServiceCredentialBindingListOptions()
 	}
 
-	var all []*resource.ServiceCredentialBinding
-	var allServiceInstances []*resource.ServiceInstance
-	for {
-		page, serviceInstances, pager, err := c.ListIncludeServiceInstances(ctx, opts)
-		if err != nil {
-			return nil, nil, err
-		}
-		all = append(all, page...)
-		allServiceInstances = append(allServiceInstances, serviceInstances...)
-		if !pager.HasNextPage() {
-			break
-		}
-		pager.NextPage(opts)
-	}
-	return all, allServiceInstances, nil
+	return autoPageInclude(ctx, c.client, opts, func(ctx context.Context, opts *ServiceCredentialBindingListOptions) ([]*resource.ServiceCredentialBinding, []*resource.ServiceInstance, *Pager, error) {
+		return c.ListIncludeServiceInstances(ctx, opts)
+	})
 }
 
 // Single returns a single service credential binding matching the options or an error if not exactly 1 match
@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"iter"
 	"net/url"
 
 	"github.com/cloudfoundry/go-cfclient/v3/internal/path"
@@ -138,6 +139,16 @@ func (c *ServiceInstanceClient) GetSharedSpaceUsageSummary(ctx context.Context,
 	return &usage, nil
 }
 
+// Iter returns an iterator over all service instances the user has access to, fetching each page only as needed
+func (c *ServiceInstanceClient) Iter(ctx context.Context, opts *ServiceInstanceListOptions) iter.Seq2[*resource.ServiceInstance, error] {
+	if opts == nil {
+		opts = NewServiceInstanceListOptions()
+	}
+	return AutoPageIter[*ServiceInstanceListOptions, *resource.ServiceInstance](ctx, opts, func(opts *ServiceInstanceListOptions) ([]*resource.ServiceInstance, *Pager, error) {
+		return c.List(ctx, opts)
+	})
+}
+
 // List pages all service instances the user has access to
 func (c *ServiceInstanceClient) List(ctx context.Context, opts *ServiceInstanceListOptions) ([]*resource.ServiceInstance, *Pager, error) {
 	if opts == nil {
@@ -157,7 +168,7 @@ func (c *ServiceInstanceClient) ListAll(ctx context.Context, opts *ServiceInstan
 	if opts == nil {
 		opts = NewServiceInstanceListOptions()
 	}
-	return AutoPage[*ServiceInstanceListOptions, *resource.ServiceInstance](opts, func(opts *ServiceInstanceListOptions) ([]*resource.ServiceInstance, *Pager, error) {
+	return autoPage[*ServiceInstanceListOptions, *resource.ServiceInstance](ctx, c.client, opts, func(ctx context.Context, opts *ServiceInstanceListOptions) ([]*resource.ServiceInstance, *Pager, error) {
 		return c.List(ctx, opts)
 	})
 }
@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"iter"
 	"net/url"
 
 	"github.com/cloudfoundry/go-cfclient/v3/internal/path"
@@ -56,6 +57,16 @@ func (c *ServiceOfferingClient) Get(ctx context.Context, guid string) (*resource
 	return &ServiceOffering, nil
 }
 
+// Iter returns an iterator over all service offerings the user has access to, fetching each page only as needed
+func (c *ServiceOfferingClient) Iter(ctx context.Context, opts *ServiceOfferingListOptions) iter.Seq2[*resource.ServiceOffering, error] {
+	if opts == nil {
+		opts = NewServiceOfferingListOptions()
+	}
+	return AutoPageIter[*ServiceOfferingListOptions, *resource.ServiceOffering](ctx, opts, func(opts *ServiceOfferingListOptions) ([]*resource.ServiceOffering, *Pager, error) {
+		return c.List(ctx, opts)
+	})
+}
+
 // List pages service offerings the user has access to
 func (c *ServiceOfferingClient) List(ctx context.Context, opts *ServiceOfferingListOptions) ([]*resource.ServiceOffering, *Pager, error) {
 	if opts == nil {
@@ -76,7 +87,7 @@ func (c *ServiceOfferingClient) ListAll(ctx context.Context, opts *ServiceOfferi
 	if opts == nil {
 		opts = NewServiceOfferingListOptions()
 	}
-	return AutoPage[*ServiceOfferingListOptions, *resource.ServiceOffering](opts, func(opts *ServiceOfferingListOptions) ([]*resource.ServiceOffering, *Pager, error) {
+	return autoPage[*ServiceOfferingListOptions, *resource.ServiceOffering](ctx, c.client, opts, func(ctx context.Context, opts *ServiceOfferingListOptions) ([]*resource.ServiceOffering, *Pager, error) {
 		return c.List(ctx, opts)
 	})
 }
@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"iter"
 	"net/url"
 
 	"github.com/cloudfoundry/go-cfclient/v3/internal/path"
@@ -88,6 +89,16 @@ func (c *ServicePlanClient) GetIncludeSpaceAndOrganization(ctx context.Context,
 	return &servicePlan.ServicePlan, servicePlan.Included.Spaces[0], servicePlan.Included.Organizations[0], nil
 }
 
+// Iter returns an iterator over all service plans the user has access to, fetching each page only as needed
+func (c *ServicePlanClient) Iter(ctx context.Context, opts *ServicePlanListOptions) iter.Seq2[*resource.ServicePlan, error] {
+	if opts == nil {
+		opts = NewServicePlanListOptions()
+	}
+	return AutoPageIter[*ServicePlanListOptions, *resource.ServicePlan](ctx, opts, func(opts *ServicePlanListOptions) ([]*resource.ServicePlan, *Pager, error) {
+		return c.List(ctx, opts)
+	})
+}
+
 // List pages service plans the user has access to
 func (c *ServicePlanClient) List(ctx context.Context, opts *ServicePlanListOptions) ([]*resource.ServicePlan, *Pager, error) {
 	if opts == nil {
@@ -108,7 +119,7 @@ func (c *ServicePlanClient) ListAll(ctx context.Context, opts *ServicePlanListOp
 	if opts == nil {
 		opts = NewServicePlanListOptions()
 	}
-	return AutoPage[*ServicePlanListOptions, *resource.ServicePlan](opts, func(opts *ServicePlanListOptions) ([]*resource.ServicePlan, *Pager, error) {
+	return autoPage[*ServicePlanListOptions, *resource.ServicePlan](ctx, c.client, opts, func(ctx context.Context, opts *ServicePlanListOptions) ([]*resource.ServicePlan, *Pager, error) {
 		return c.List(ctx, opts)
 	})
 }
@@ -135,21 +146,9 @@ func (c *ServicePlanClient) ListIncludeServiceOfferingAll(ctx context.Context, o
 		opts = NewServicePlanListOptions()
 	}
 
-	var all []*resource.ServicePlan
-	var allServiceOfferings []*resource.ServiceOffering
-	for {
-		page, serviceOfferings, pager, err := c.ListIncludeServiceOffering(ctx, opts)
-		if err != nil {
-			return nil, nil, err
-		}
-		all = append(all, page...)
-		allServiceOfferings = append(allServiceOfferings, serviceOfferings...)
-		if !pager.HasNextPage() {
-			break
-		}
-		pager.NextPage(opts)
-	}
-	return all, allServiceOfferings, nil
+	return autoPageInclude(ctx, c.client, opts, func(ctx context.Context, opts *ServicePlanListOptions) ([]*resource.ServicePlan, []*resource.ServiceOffering, *Pager, error) {
+		return c.ListIncludeServiceOffering(ctx, opts)
+	})
 }
 
 // ListIncludeSpacesAndOrganizations page all service plans the user has access to and include the associated spaces and organizations
@@ -174,23 +173,9 @@ func (c *ServicePlanClient) ListIncludeSpacesAndOrganizationsAll(ctx context.Con
 		opts = NewServicePlanListOptions()
 	}
 
-	var all []*resource.ServicePlan
-	var allSpaces []*resource.Space
-	var allOrgs []*resource.Organization
-	for {
-		page, spaces, orgs, pager, err := c.ListIncludeSpacesAndOrganizations(ctx, opts)
-		if err != nil {
-			return nil, nil, nil, err
-		}
-		all = append(all, page...)
-		allSpaces = append(allSpaces, spaces...)
-		allOrgs = append(allOrgs, orgs...)
-		if !pager.HasNextPage() {
-			break
-		}
-		pager.NextPage(opts)
-	}
-	return all, allSpaces, allOrgs, nil
+	return autoPageInclude2(ctx, c.client, opts, func(ctx context.Context, opts *ServicePlanListOptions) ([]*resource.ServicePlan, []*resource.Space, []*resource.Organization, *Pager, error) {
+		return c.ListIncludeSpacesAndOrganizations(ctx, opts)
+	})
 }
 
 // Single returns a single service plan matching the options or an error if not exactly 1 match
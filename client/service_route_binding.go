@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"iter"
 	"net/url"
 
 	"github.com/cloudfoundry/go-cfclient/v3/internal/path"
@@ -100,6 +101,16 @@ func (c *ServiceRouteBindingClient) GetParameters(ctx context.Context, guid stri
 	return srbEnv, nil
 }
 
+// Iter returns an iterator over all service route bindings the user has access to, fetching each page only as needed
+func (c *ServiceRouteBindingClient) Iter(ctx context.Context, opts *ServiceRouteBindingListOptions) iter.Seq2[*resource.ServiceRouteBinding, error] {
+	if opts == nil {
+		opts = NewServiceRouteBindingListOptions()
+	}
+	return AutoPageIter[*ServiceRouteBindingListOptions, *resource.ServiceRouteBinding](ctx, opts, func(opts *ServiceRouteBindingListOptions) ([]*resource.ServiceRouteBinding, *Pager, error) {
+		return c.List(ctx, opts)
+	})
+}
+
 // List pages all the service route bindings the user has access to
 func (c *ServiceRouteBindingClient) List(ctx context.Context, opts *ServiceRouteBindingListOptions) ([]*resource.ServiceRouteBinding, *Pager, error) {
 	if opts == nil {
@@ -121,7 +132,7 @@ func (c *ServiceRouteBindingClient) ListAll(ctx context.Context, opts *ServiceRo
 	if opts == nil {
 		opts = NewServiceRouteBindingListOptions()
 	}
-	return AutoPage[*ServiceRouteBindingListOptions, *resource.ServiceRouteBinding](opts, func(opts *ServiceRouteBindingListOptions) ([]*resource.ServiceRouteBinding, *Pager, error) {
+	return autoPage[*ServiceRouteBindingListOptions, *resource.ServiceRouteBinding](ctx, c.client, opts, func(ctx context.Context, opts *ServiceRouteBindingListOptions) ([]*resource.ServiceRouteBinding, *Pager, error) {
 		return c.List(ctx, opts)
 	})
 }
@@ -148,21 +159,9 @@ func (c *ServiceRouteBindingClient) ListIncludeRoutesAll(ctx context.Context, op
 		opts = NewServiceRouteBindingListOptions()
 	}
 
-	var all []*resource.ServiceRouteBinding
-	var allRoutes []*resource.Route
-	for {
-		page, routes, pager, err := c.ListIncludeRoutes(ctx, opts)
-		if err != nil {
-			return nil, nil, err
-		}
-		all = append(all, page...)
-		allRoutes = append(allRoutes, routes...)
-		if !pager.HasNextPage() {
-			break
-		}
-		pager.NextPage(opts)
-	}
-	return all, allRoutes, nil
+	return autoPageInclude(ctx, c.client, opts, func(ctx context.Context, opts *ServiceRouteBindingListOptions) ([]*resource.ServiceRouteBinding, []*resource.Route, *Pager, error) {
+		return c.ListIncludeRoutes(ctx, opts)
+	})
 }
 
 // ListIncludeServiceInstances page all service route bindings the user has access to and include the
@@ -189,21 +188,9 @@ func (c *ServiceRouteBindingClient) ListIncludeServiceInstancesAll(ctx context.C
 		opts = NewServiceRouteBindingListOptions()
 	}
 
-	var all []*resource.ServiceRouteBinding
-	var allSIs []*resource.ServiceInstance
-	for {
-		page, sis, pager, err := c.ListIncludeServiceInstances(ctx, opts)
-		if err != nil {
-			return nil, nil, err
-		}
-		all = append(all, page...)
-		allSIs = append(allSIs, sis...)
-		if !pager.HasNextPage() {
-			break
-		}
-		pager.NextPage(opts)
-	}
-	return all, allSIs, nil
+	return autoPageInclude(ctx, c.client, opts, func(ctx context.Context, opts *ServiceRouteBindingListOptions) ([]*resource.ServiceRouteBinding, []*resource.ServiceInstance, *Pager, error) {
+		return c.ListIncludeServiceInstances(ctx, opts)
+	})
 }
 
 // Single returns a single service route binding matching the options or an error if not exactly 1 match
@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"iter"
 	"net/url"
 
 	"github.com/cloudfoundry/go-cfclient/v3/internal/path"
@@ -48,6 +49,16 @@ func (c *ServiceUsageClient) Get(ctx context.Context, guid string) (*resource.Se
 	return &a, nil
 }
 
+// Iter returns an iterator over all service usage events, fetching each page only as needed
+func (c *ServiceUsageClient) Iter(ctx context.Context, opts *ServiceUsageListOptions) iter.Seq2[*resource.ServiceUsage, error] {
+	if opts == nil {
+		opts = NewServiceUsageOptions()
+	}
+	return AutoPageIter[*ServiceUsageListOptions, *resource.ServiceUsage](ctx, opts, func(opts *ServiceUsageListOptions) ([]*resource.ServiceUsage, *Pager, error) {
+		return c.List(ctx, opts)
+	})
+}
+
 // List pages all service usage events
 func (c *ServiceUsageClient) List(ctx context.Context, opts *ServiceUsageListOptions) ([]*resource.ServiceUsage, *Pager, error) {
 	if opts == nil {
@@ -67,7 +78,7 @@ func (c *ServiceUsageClient) ListAll(ctx context.Context, opts *ServiceUsageList
 	if opts == nil {
 		opts = NewServiceUsageOptions()
 	}
-	return AutoPage[*ServiceUsageListOptions, *resource.ServiceUsage](opts, func(opts *ServiceUsageListOptions) ([]*resource.ServiceUsage, *Pager, error) {
+	return autoPage[*ServiceUsageListOptions, *resource.ServiceUsage](ctx, c.client, opts, func(ctx context.Context, opts *ServiceUsageListOptions) ([]*resource.ServiceUsage, *Pager, error) {
 		return c.List(ctx, opts)
 	})
 }
@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"iter"
 	"net/url"
 
 	"github.com/cloudfoundry/go-cfclient/v3/internal/path"
@@ -104,6 +105,16 @@ func (c *SpaceClient) GetIncludeOrganization(ctx context.Context, guid string) (
 	return &space.Space, space.Included.Organizations[0], nil
 }
 
+// Iter returns an iterator over all spaces the user has access to, fetching each page only as needed
+func (c *SpaceClient) Iter(ctx context.Context, opts *SpaceListOptions) iter.Seq2[*resource.Space, error] {
+	if opts == nil {
+		opts = NewSpaceListOptions()
+	}
+	return AutoPageIter[*SpaceListOptions, *resource.Space](ctx, opts, func(opts *SpaceListOptions) ([]*resource.Space, *Pager, error) {
+		return c.List(ctx, opts)
+	})
+}
+
 // List pages all spaces the user has access to
 func (c *SpaceClient) List(ctx context.Context, opts *SpaceListOptions) ([]*resource.Space, *Pager, error) {
 	if opts == nil {
@@ -125,7 +136,7 @@ func (c *SpaceClient) ListAll(ctx context.Context, opts *SpaceListOptions) ([]*r
 	if opts == nil {
 		opts = NewSpaceListOptions()
 	}
-	return AutoPage[*SpaceListOptions, *resource.Space](opts, func(opts *SpaceListOptions) ([]*resource.Space, *Pager, error) {
+	return autoPage[*SpaceListOptions, *resource.Space](ctx, c.client, opts, func(ctx context.Context, opts *SpaceListOptions) ([]*resource.Space, *Pager, error) {
 		return c.List(ctx, opts)
 	})
 }
@@ -152,21 +163,9 @@ func (c *SpaceClient) ListIncludeOrganizationsAll(ctx context.Context, opts *Spa
 		opts = NewSpaceListOptions()
 	}
 
-	var all []*resource.Space
-	var allOrgs []*resource.Organization
-	for {
-		page, orgs, pager, err := c.ListIncludeOrganizations(ctx, opts)
-		if err != nil {
-			return nil, nil, err
-		}
-		all = append(all, page...)
-		allOrgs = append(allOrgs, orgs...)
-		if !pager.HasNextPage() {
-			break
-		}
-		pager.NextPage(opts)
-	}
-	return all, allOrgs, nil
+	return autoPageInclude(ctx, c.client, opts, func(ctx context.Context, opts *SpaceListOptions) ([]*resource.Space, []*resource.Organization, *Pager, error) {
+		return c.ListIncludeOrganizations(ctx, opts)
+	})
 }
 
 // ListUsers pages users by space GUID
@@ -188,7 +187,7 @@ func (c *SpaceClient) ListUsersAll(ctx context.Context, spaceGUID string, opts *
 	if opts == nil {
 		opts = NewUserListOptions()
 	}
-	return AutoPage[*UserListOptions, *resource.User](opts, func(opts *UserListOptions) ([]*resource.User, *Pager, error) {
+	return autoPage[*UserListOptions, *resource.User](ctx, c.client, opts, func(ctx context.Context, opts *UserListOptions) ([]*resource.User, *Pager, error) {
 		return c.ListUsers(ctx, spaceGUID, opts)
 	})
 }
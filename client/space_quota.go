@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"iter"
 	"net/url"
 
 	"github.com/cloudfoundry/go-cfclient/v3/internal/path"
@@ -78,6 +79,16 @@ func (c *SpaceQuotaClient) Get(ctx context.Context, guid string) (*resource.Spac
 	return &q, nil
 }
 
+// Iter returns an iterator over all space quotas the user has access to, fetching each page only as needed
+func (c *SpaceQuotaClient) Iter(ctx context.Context, opts *SpaceQuotaListOptions) iter.Seq2[*resource.SpaceQuota, error] {
+	if opts == nil {
+		opts = NewSpaceQuotaListOptions()
+	}
+	return AutoPageIter[*SpaceQuotaListOptions, *resource.SpaceQuota](ctx, opts, func(opts *SpaceQuotaListOptions) ([]*resource.SpaceQuota, *Pager, error) {
+		return c.List(ctx, opts)
+	})
+}
+
 // List pages all space quotas the user has access to
 func (c *SpaceQuotaClient) List(ctx context.Context, opts *SpaceQuotaListOptions) ([]*resource.SpaceQuota, *Pager, error) {
 	if opts == nil {
@@ -98,7 +109,7 @@ func (c *SpaceQuotaClient) ListAll(ctx context.Context, opts *SpaceQuotaListOpti
 	if opts == nil {
 		opts = NewSpaceQuotaListOptions()
 	}
-	return AutoPage[*SpaceQuotaListOptions, *resource.SpaceQuota](opts, func(opts *SpaceQuotaListOptions) ([]*resource.SpaceQuota, *Pager, error) {
+	return autoPage[*SpaceQuotaListOptions, *resource.SpaceQuota](ctx, c.client, opts, func(ctx context.Context, opts *SpaceQuotaListOptions) ([]*resource.SpaceQuota, *Pager, error) {
 		return c.List(ctx, opts)
 	})
 }
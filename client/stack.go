@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"iter"
 	"net/url"
 
 	"github.com/cloudfoundry/go-cfclient/v3/internal/path"
@@ -62,6 +63,16 @@ func (c *StackClient) Get(ctx context.Context, guid string) (*resource.Stack, er
 	return &stack, nil
 }
 
+// Iter returns an iterator over all stacks the user has access to, fetching each page only as needed
+func (c *StackClient) Iter(ctx context.Context, opts *StackListOptions) iter.Seq2[*resource.Stack, error] {
+	if opts == nil {
+		opts = NewStackListOptions()
+	}
+	return AutoPageIter[*StackListOptions, *resource.Stack](ctx, opts, func(opts *StackListOptions) ([]*resource.Stack, *Pager, error) {
+		return c.List(ctx, opts)
+	})
+}
+
 // List pages all stacks the user has access to
 func (c *StackClient) List(ctx context.Context, opts *StackListOptions) ([]*resource.Stack, *Pager, error) {
 	if opts == nil {
@@ -81,7 +92,7 @@ func (c *StackClient) ListAll(ctx context.Context, opts *StackListOptions) ([]*r
 	if opts == nil {
 		opts = NewStackListOptions()
 	}
-	return AutoPage[*StackListOptions, *resource.Stack](opts, func(opts *StackListOptions) ([]*resource.Stack, *Pager, error) {
+	return autoPage[*StackListOptions, *resource.Stack](ctx, c.client, opts, func(ctx context.Context, opts *StackListOptions) ([]*resource.Stack, *Pager, error) {
 		return c.List(ctx, opts)
 	})
 }
@@ -105,7 +116,7 @@ func (c *StackClient) ListAppsOnStackAll(ctx context.Context, guid string, opts
 	if opts == nil {
 		opts = NewStackListOptions()
 	}
-	return AutoPage[*StackListOptions, *resource.App](opts, func(opts *StackListOptions) ([]*resource.App, *Pager, error) {
+	return autoPage[*StackListOptions, *resource.App](ctx, c.client, opts, func(ctx context.Context, opts *StackListOptions) ([]*resource.App, *Pager, error) {
 		return c.ListAppsOnStack(ctx, guid, opts)
 	})
 }
@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"iter"
 	"net/url"
 
 	"github.com/cloudfoundry/go-cfclient/v3/internal/path"
@@ -81,6 +82,17 @@ func (c *TaskClient) Get(ctx context.Context, guid string) (*resource.Task, erro
 	return &task, nil
 }
 
+// Iter returns an iterator over all tasks the user has access to, fetching each page only as needed. The command
+// field is excluded in the response.
+func (c *TaskClient) Iter(ctx context.Context, opts *TaskListOptions) iter.Seq2[*resource.Task, error] {
+	if opts == nil {
+		opts = NewTaskListOptions()
+	}
+	return AutoPageIter[*TaskListOptions, *resource.Task](ctx, opts, func(opts *TaskListOptions) ([]*resource.Task, *Pager, error) {
+		return c.List(ctx, opts)
+	})
+}
+
 // List pages all the tasks the user has access to. The command field is excluded in the response.
 func (c *TaskClient) List(ctx context.Context, opts *TaskListOptions) ([]*resource.Task, *Pager, error) {
 	if opts == nil {
@@ -101,7 +113,7 @@ func (c *TaskClient) ListAll(ctx context.Context, opts *TaskListOptions) ([]*res
 	if opts == nil {
 		opts = NewTaskListOptions()
 	}
-	return AutoPage[*TaskListOptions, *resource.Task](opts, func(opts *TaskListOptions) ([]*resource.Task, *Pager, error) {
+	return autoPage[*TaskListOptions, *resource.Task](ctx, c.client, opts, func(ctx context.Context, opts *TaskListOptions) ([]*resource.Task, *Pager, error) {
 		return c.List(ctx, opts)
 	})
 }
@@ -128,7 +140,7 @@ func (c *TaskClient) ListForAppAll(ctx context.Context, appGUID string, opts *Ta
 	if opts == nil {
 		opts = NewTaskListOptions()
 	}
-	return AutoPage[*TaskListOptions, *resource.Task](opts, func(opts *TaskListOptions) ([]*resource.Task, *Pager, error) {
+	return autoPage[*TaskListOptions, *resource.Task](ctx, c.client, opts, func(ctx context.Context, opts *TaskListOptions) ([]*resource.Task, *Pager, error) {
 		return c.ListForApp(ctx, appGUID, opts)
 	})
 }
@@ -0,0 +1,228 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/cloudfoundry/go-cfclient/v3/config"
+	internal "github.com/cloudfoundry/go-cfclient/v3/internal/http"
+	"github.com/cloudfoundry/go-cfclient/v3/internal/telemetry"
+	"github.com/cloudfoundry/go-cfclient/v3/resource"
+)
+
+// clientTelemetry records OpenTelemetry spans and metrics for each request sent to the CF API
+type clientTelemetry struct {
+	tracer   trace.Tracer // nil when tracing isn't enabled
+	requests metric.Int64Counter
+	duration metric.Float64Histogram
+}
+
+// newClientTelemetry creates the configured instruments or returns nil if neither tracing nor metrics are enabled
+func newClientTelemetry(cfg *config.Config) (*clientTelemetry, error) {
+	tp, mp := cfg.TracerProvider(), cfg.MeterProvider()
+	if tp == nil && mp == nil {
+		return nil, nil
+	}
+
+	t := &clientTelemetry{}
+	if tp != nil {
+		t.tracer = tp.Tracer(telemetry.InstrumentationName)
+	}
+	if mp != nil {
+		meter := mp.Meter(telemetry.InstrumentationName)
+		var err error
+		t.requests, err = meter.Int64Counter("cf.client.requests",
+			metric.WithDescription("Number of requests sent to the CF API"),
+			metric.WithUnit("{request}"))
+		if err != nil {
+			return nil, err
+		}
+		t.duration, err = meter.Float64Histogram("cf.client.request.duration",
+			metric.WithDescription("Duration of requests sent to the CF API, including retries"),
+			metric.WithUnit("s"))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+// Tracer returns the tracer used by the client, or a no-op tracer if tracing isn't enabled. Higher level
+// operations use it to group the client spans under a single parent span.
+func (c *Client) Tracer() trace.Tracer {
+	if c.telemetry == nil || c.telemetry.tracer == nil {
+		return noop.NewTracerProvider().Tracer(telemetry.InstrumentationName)
+	}
+	return c.telemetry.tracer
+}
+
+// operationKey marks a context that's already in an operation span, so the helpers called while performing
+// an operation don't start nested operation spans
+type operationKey struct{}
+
+// startOperation starts a span for the logical operation performed by the calling sub-client method, i.e.
+// Applications.ListAll, that's the parent of the spans of every request it sends. A no-op span is returned if
+// tracing isn't enabled, the context is already in an operation, or it wasn't called by a sub-client method.
+func (c *Client) startOperation(ctx context.Context) (context.Context, trace.Span) {
+	noopSpan := trace.SpanFromContext(context.Background())
+	if c.telemetry == nil || c.telemetry.tracer == nil || ctx.Value(operationKey{}) != nil {
+		return ctx, noopSpan
+	}
+	name := operationName()
+	if name == "" {
+		return ctx, noopSpan
+	}
+	ctx, span := telemetry.Start(ctx, c.telemetry.tracer, name)
+	return context.WithValue(ctx, operationKey{}, true), span
+}
+
+// operationName returns the name of the outermost sub-client method on the stack, i.e. Applications.ListAll,
+// so a method that calls other sub-client methods is named after the method the caller called
+func operationName() string {
+	pcs := make([]uintptr, 64)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+	var name string
+	for {
+		frame, more := frames.Next()
+		if op, ok := subClientMethod(frame.Function); ok {
+			name = op
+		}
+		if !more {
+			return name
+		}
+	}
+}
+
+// subClientMethod converts a function name like github.com/cloudfoundry/go-cfclient/v3/client.(*AppClient).List.func1
+// to the sub-client field and method name, i.e. Applications.List
+func subClientMethod(function string) (string, bool) {
+	method, ok := strings.CutPrefix(function, subClientFunctionPrefix)
+	if !ok {
+		return "", false
+	}
+	typeName, method, ok := strings.Cut(method, ").")
+	if !ok {
+		return "", false
+	}
+	field, ok := subClientFields()[typeName]
+	if !ok {
+		return "", false
+	}
+	method, _, _ = strings.Cut(method, ".")
+	return field + "." + method, true
+}
+
+// subClientFunctionPrefix is the prefix of the names of the methods of the pointer types in this package
+var subClientFunctionPrefix = reflect.TypeOf(Client{}).PkgPath() + ".(*"
+
+// subClientFields maps the name of each sub-client type to its field in the Client, i.e. AppClient to
+// Applications
+var subClientFields = sync.OnceValue(func() map[string]string {
+	fields := make(map[string]string)
+	t := reflect.TypeOf(Client{})
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.IsExported() && f.Type.Kind() == reflect.Ptr && f.Type.Elem().PkgPath() == t.PkgPath() {
+			fields[f.Type.Elem().Name()] = f.Name
+		}
+	}
+	return fields
+})
+
+// instrument sends the request using the send func while recording a span named after the request method and
+// endpoint template, and the request count and duration metrics
+func (t *clientTelemetry) instrument(req *http.Request, send func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	start := time.Now()
+	endpoint := telemetry.ParseEndpoint(req.URL.Path)
+
+	var span trace.Span
+	if t.tracer != nil {
+		attrs := []attribute.KeyValue{
+			telemetry.AttrHTTPMethod.String(req.Method),
+			telemetry.AttrURLTemplate.String(endpoint.Template),
+			telemetry.AttrServerAddress.String(req.URL.Host),
+		}
+		if endpoint.ResourceType != "" {
+			attrs = append(attrs, telemetry.AttrResourceType.String(endpoint.ResourceType))
+		}
+		if endpoint.GUID != "" {
+			attrs = append(attrs, telemetry.AttrResourceGUID.String(endpoint.GUID))
+		}
+		var ctx context.Context
+		ctx, span = t.tracer.Start(req.Context(), endpoint.SpanName(req.Method), trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+		req = req.WithContext(ctx)
+	}
+
+	resp, err := send(req)
+
+	statusCode, requestID := responseDetails(resp, err)
+	if span != nil {
+		if statusCode > 0 {
+			span.SetAttributes(telemetry.AttrHTTPStatusCode.Int(statusCode))
+		}
+		if requestID != "" {
+			span.SetAttributes(telemetry.AttrRequestID.String(requestID))
+		}
+		if jobGUID := internal.DecodeJobID(resp); jobGUID != "" {
+			span.SetAttributes(telemetry.AttrJobGUID.String(jobGUID))
+		}
+		telemetry.End(span, err)
+	}
+	if t.requests != nil {
+		attrs := []attribute.KeyValue{
+			telemetry.AttrHTTPMethod.String(req.Method),
+			telemetry.AttrURLTemplate.String(endpoint.Template),
+		}
+		if statusCode > 0 {
+			attrs = append(attrs, telemetry.AttrHTTPStatusCode.Int(statusCode))
+		}
+		if err != nil {
+			attrs = append(attrs, telemetry.AttrErrorType.String(errorType(err)))
+		}
+		opt := metric.WithAttributes(attrs...)
+		t.requests.Add(req.Context(), 1, opt)
+		t.duration.Record(req.Context(), time.Since(start).Seconds(), opt)
+	}
+	return resp, err
+}
+
+// responseDetails returns the status code and CF request ID from either the response or a decoded API error
+func responseDetails(resp *http.Response, err error) (int, string) {
+	if resp != nil {
+		return resp.StatusCode, resp.Header.Get("X-Vcap-Request-Id")
+	}
+	var cfErrs resource.CloudFoundryErrors
+	if errors.As(err, &cfErrs) {
+		return cfErrs.StatusCode, cfErrs.RequestID
+	}
+	var httpErr resource.CloudFoundryHTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode, httpErr.RequestID
+	}
+	return 0, ""
+}
+
+// errorType returns a low cardinality description of the error for use as a metric attribute
+func errorType(err error) string {
+	switch {
+	case errors.As(err, new(resource.CloudFoundryErrors)), errors.As(err, new(resource.CloudFoundryHTTPError)):
+		return "api"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	default:
+		return "transport"
+	}
+}
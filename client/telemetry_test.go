@@ -0,0 +1,165 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/cloudfoundry/go-cfclient/v3/client"
+	"github.com/cloudfoundry/go-cfclient/v3/config"
+	"github.com/cloudfoundry/go-cfclient/v3/testutil"
+)
+
+func TestClientTelemetry(t *testing.T) {
+	g := testutil.NewObjectJSONGenerator()
+	app := g.Application()
+
+	newClient := func(t *testing.T, serverURL string, options ...config.Option) *client.Client {
+		options = append(options, config.Token("", "fake-refresh-token"))
+		cfg, err := config.New(serverURL, options...)
+		require.NoError(t, err)
+		cf, err := client.New(cfg)
+		require.NoError(t, err)
+		return cf
+	}
+
+	t.Run("records a span per operation", func(t *testing.T) {
+		serverURL := testutil.SetupMultiple([]testutil.MockRoute{
+			{
+				Method:   http.MethodGet,
+				Endpoint: "/v3/apps/" + app.GUID,
+				Output:   g.Single(app.JSON),
+				Status:   http.StatusOK,
+			},
+			{
+				Method:   http.MethodGet,
+				Endpoint: "/v3/apps/not-found",
+				Output:   []string{`{"errors":[{"code":10010,"title":"CF-ResourceNotFound","detail":"App not found"}]}`},
+				Status:   http.StatusNotFound,
+			},
+		}, t)
+		defer testutil.Teardown()
+
+		exporter := tracetest.NewInMemoryExporter()
+		tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+		cf := newClient(t, serverURL, config.TracerProvider(tp))
+
+		_, err := cf.Applications.Get(context.Background(), app.GUID)
+		require.NoError(t, err)
+		_, err = cf.Applications.Get(context.Background(), "not-found")
+		require.Error(t, err)
+
+		// each request span ends before the span of its operation
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 4)
+		require.Equal(t, "GET /v3/apps/:guid", spans[0].Name)
+		require.Contains(t, spans[0].Attributes, attribute.String("url.template", "/v3/apps/:guid"))
+		require.Contains(t, spans[0].Attributes, attribute.String("cf.resource.type", "apps"))
+		require.Contains(t, spans[0].Attributes, attribute.String("cf.resource.guid", app.GUID))
+		require.Contains(t, spans[0].Attributes, attribute.Int("http.response.status_code", 200))
+		require.Equal(t, codes.Unset, spans[0].Status.Code)
+		require.Equal(t, "Applications.Get", spans[1].Name)
+		require.Equal(t, spans[1].SpanContext.SpanID(), spans[0].Parent.SpanID())
+		require.Equal(t, codes.Unset, spans[1].Status.Code)
+
+		require.Equal(t, "GET /v3/apps/not-found", spans[2].Name)
+		require.Contains(t, spans[2].Attributes, attribute.Int("http.response.status_code", 404))
+		require.Equal(t, codes.Error, spans[2].Status.Code)
+		require.Equal(t, "Applications.Get", spans[3].Name)
+		require.Equal(t, spans[3].SpanContext.SpanID(), spans[2].Parent.SpanID())
+		require.Equal(t, codes.Error, spans[3].Status.Code)
+	})
+
+	t.Run("records a single span for all the pages", func(t *testing.T) {
+		app2 := g.Application()
+		serverURL := testutil.Setup(testutil.MockRoute{
+			Method:   http.MethodGet,
+			Endpoint: "/v3/apps",
+			Output:   g.Paged([]string{app.JSON}, []string{app2.JSON}),
+			Status:   http.StatusOK,
+		}, t)
+		defer testutil.Teardown()
+
+		exporter := tracetest.NewInMemoryExporter()
+		tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+		cf := newClient(t, serverURL, config.TracerProvider(tp))
+
+		apps, err := cf.Applications.ListAll(context.Background(), nil)
+		require.NoError(t, err)
+		require.Len(t, apps, 2)
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 3)
+		listAll := spans[2]
+		require.Equal(t, "Applications.ListAll", listAll.Name)
+		for _, s := range spans[:2] {
+			require.Equal(t, "GET /v3/apps", s.Name)
+			require.Equal(t, listAll.SpanContext.SpanID(), s.Parent.SpanID())
+		}
+	})
+
+	t.Run("records request metrics by endpoint template", func(t *testing.T) {
+		serverURL := testutil.Setup(testutil.MockRoute{
+			Method:   http.MethodGet,
+			Endpoint: "/v3/apps/" + app.GUID,
+			Output:   []string{app.JSON, app.JSON},
+			Status:   http.StatusOK,
+		}, t)
+		defer testutil.Teardown()
+
+		reader := sdkmetric.NewManualReader()
+		mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+		cf := newClient(t, serverURL, config.MeterProvider(mp))
+
+		for range 2 {
+			_, err := cf.Applications.Get(context.Background(), app.GUID)
+			require.NoError(t, err)
+		}
+
+		var rm metricdata.ResourceMetrics
+		require.NoError(t, reader.Collect(context.Background(), &rm))
+		require.Len(t, rm.ScopeMetrics, 1)
+
+		metrics := make(map[string]metricdata.Metrics)
+		for _, m := range rm.ScopeMetrics[0].Metrics {
+			metrics[m.Name] = m
+		}
+
+		requests, ok := metrics["cf.client.requests"].Data.(metricdata.Sum[int64])
+		require.True(t, ok)
+		require.Len(t, requests.DataPoints, 1)
+		require.Equal(t, int64(2), requests.DataPoints[0].Value)
+		template, _ := requests.DataPoints[0].Attributes.Value("url.template")
+		require.Equal(t, "/v3/apps/:guid", template.AsString())
+
+		duration, ok := metrics["cf.client.request.duration"].Data.(metricdata.Histogram[float64])
+		require.True(t, ok)
+		require.Len(t, duration.DataPoints, 1)
+		require.Equal(t, uint64(2), duration.DataPoints[0].Count)
+	})
+
+	t.Run("is a no-op when not configured", func(t *testing.T) {
+		serverURL := testutil.Setup(testutil.MockRoute{
+			Method:   http.MethodGet,
+			Endpoint: "/v3/apps/" + app.GUID,
+			Output:   g.Single(app.JSON),
+			Status:   http.StatusOK,
+		}, t)
+		defer testutil.Teardown()
+
+		cf := newClient(t, serverURL)
+		_, err := cf.Applications.Get(context.Background(), app.GUID)
+		require.NoError(t, err)
+
+		_, span := cf.Tracer().Start(context.Background(), "test")
+		require.False(t, span.IsRecording())
+	})
+}
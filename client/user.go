@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"iter"
 	"net/url"
 
 	"github.com/cloudfoundry/go-cfclient/v3/internal/path"
@@ -83,6 +84,16 @@ func (c *UserClient) Get(ctx context.Context, guid string) (*resource.User, erro
 	return &user, nil
 }
 
+// Iter returns an iterator over all users the user has access to, fetching each page only as needed
+func (c *UserClient) Iter(ctx context.Context, opts *UserListOptions) iter.Seq2[*resource.User, error] {
+	if opts == nil {
+		opts = NewUserListOptions()
+	}
+	return AutoPageIter[*UserListOptions, *resource.User](ctx, opts, func(opts *UserListOptions) ([]*resource.User, *Pager, error) {
+		return c.List(ctx, opts)
+	})
+}
+
 // List pages all users the user has access to
 func (c *UserClient) List(ctx context.Context, opts *UserListOptions) ([]*resource.User, *Pager, error) {
 	if opts == nil {
@@ -102,7 +113,7 @@ func (c *UserClient) ListAll(ctx context.Context, opts *UserListOptions) ([]*res
 	if opts == nil {
 		opts = NewUserListOptions()
 	}
-	return AutoPage[*UserListOptions, *resource.User](opts, func(opts *UserListOptions) ([]*resource.User, *Pager, error) {
+	return autoPage[*UserListOptions, *resource.User](ctx, c.client, opts, func(ctx context.Context, opts *UserListOptions) ([]*resource.User, *Pager, error) {
 		return c.List(ctx, opts)
 	})
 }
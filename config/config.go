@@ -3,14 +3,19 @@ package config
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"os"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/clientcredentials"
 
@@ -26,12 +31,17 @@ const (
 	GrantTypeClientCredentials = "client_credentials"
 	GrantTypePassword          = "password"
 	GrantTypeJwtBearer         = "jwt_bearer"
+	GrantTypePasscode          = "passcode"
+	GrantTypeAuthorizationCode = "authorization_code"
 	DefaultRequestTimeout      = 30 * time.Second
 	DefaultUserAgent           = "Go-CF-Client/3.0"
 	DefaultClientID            = "cf"
 	DefaultSSHClientID         = "ssh-proxy"
 )
 
+// tokenRefreshLeeway is how long before a token expires it's refreshed
+const tokenRefreshLeeway = 30 * time.Second
+
 var ErrConfigInvalid = errors.New("configuration is invalid")
 
 // Config is used to configure the creation of a client
@@ -50,18 +60,54 @@ type Config struct {
 	scopes            []string
 	assertion         string
 	clientAssertion   string
+	passcode          string
+	authCodeLogin     *authCodeLogin
 	oAuthToken        *oauth2.Token
 	httpClient        *http.Client
 	httpAuthClient    *http.Client
 	skipTLSValidation bool
+	caCerts           []*x509.Certificate
+	clientCerts       []*clientCertificate
 	requestTimeout    time.Duration
 	userAgent         string
+	retryPolicy       *RetryPolicy
+	rateLimit         float64
+	rateLimitBurst    int
+	maxConcurrent     int
+	listConcurrency   int
+	middleware        []Middleware
+	trace             *httpTrace
+	tracerProvider    trace.TracerProvider
+	meterProvider     metric.MeterProvider
+	lazyInit          bool
+	tokenStore        TokenStore
+	onTokenRefresh    []TokenRefreshFunc
+
+	// tokenMu guards oAuthToken, which is replaced by each refreshed token so re-authenticating uses the latest
+	// refresh token, and tokenSourceCreated
+	tokenMu            sync.Mutex
+	tokenSourceCreated bool
 
 	initialized bool
+
+	// authMu serializes the discovery of the UAA endpoints and the creation of the token source, which happen
+	// on the first request with LazyInit. authReady is set once both succeed.
+	authMu        sync.Mutex
+	authReady     atomic.Bool
+	authTransport http.RoundTripper
 }
 
 // New creates a new Config with specified API root URL and options.
+//
+// Unless the LazyInit option is used, the UAA endpoints are discovered from the CF API and, for a password
+// grant, a token is fetched before returning. Use NewWithContext to cancel those requests or give them a deadline.
 func New(apiRootURL string, options ...Option) (*Config, error) {
+	return NewWithContext(context.Background(), apiRootURL, options...)
+}
+
+// NewWithContext creates a new Config like New, using the context for the requests made to discover the UAA
+// endpoints and fetch the first token. The context isn't used after NewWithContext returns.
+func NewWithContext(ctx context.Context, apiRootURL string, options ...Option) (*Config, error) {
 	u, err := url.Parse(apiRootURL)
 	if err != nil {
 		return nil, fmt.Errorf("expected an http(s) CF API root URI, but got %s: %w", apiRootURL, err)
@@ -73,7 +119,7 @@ func New(apiRootURL string, options ...Option) (*Config, error) {
 		clientID:       DefaultClientID,
 		sshOAuthClient: DefaultSSHClientID,
 	}
-	err = initConfig(cfg, options...)
+	err = initConfig(ctx, cfg, options...)
 	if err != nil {
 		return nil, err
 	}
@@ -87,12 +133,22 @@ func New(apiRootURL string, options ...Option) (*Config, error) {
 //
 // If CF_USERNAME and CF_PASSWORD env vars are set then those credentials will be used to get an oauth2 token. If
 // those env vars are not set then the stored oauth2 token is used.
+//
+// If the CF_TRACE env var is set to true, requests are logged to stderr with any secrets redacted. Any other value
+// besides false is treated as the path of a file to append the log to, which is opened once and stays open for the
+// life of the process.
 func NewFromCFHome(options ...Option) (*Config, error) {
+	return NewFromCFHomeWithContext(context.Background(), options...)
+}
+
+// NewFromCFHomeWithContext creates a client config from the CF CLI config like NewFromCFHome, using the context
+// for the requests made to discover the UAA endpoints and fetch the first token.
+func NewFromCFHomeWithContext(ctx context.Context, options ...Option) (*Config, error) {
 	dir, err := findCFHomeDir()
 	if err != nil {
 		return nil, err
 	}
-	return NewFromCFHomeDir(dir, options...)
+	return NewFromCFHomeDirWithContext(ctx, dir, options...)
 }
 
 // NewFromCFHomeDir creates a client config from the CF CLI config using the specified directory.
@@ -100,13 +156,20 @@ func NewFromCFHome(options ...Option) (*Config, error) {
 // This will attempt to read the CF CLI config from the specified directory only.
 //
 // If CF_USERNAME and CF_PASSWORD env vars are set then those credentials will be used to get an oauth2 token. If
-// those env vars are not set then the stored oauth2 token is used.
+// those env vars are not set then the stored oauth2 token is used. CF_TRACE is honored like NewFromCFHome.
 func NewFromCFHomeDir(cfHomeDir string, options ...Option) (*Config, error) {
+	return NewFromCFHomeDirWithContext(context.Background(), cfHomeDir, options...)
+}
+
+// NewFromCFHomeDirWithContext creates a client config from the CF CLI config in the specified directory like
+// NewFromCFHomeDir, using the context for the requests made to discover the UAA endpoints and fetch the first
+// token. The context isn't used after NewFromCFHomeDirWithContext returns.
+func NewFromCFHomeDirWithContext(ctx context.Context, cfHomeDir string, options ...Option) (*Config, error) {
 	cfg, err := createConfigFromCFCLIConfig(cfHomeDir)
 	if err != nil {
 		return nil, err
 	}
-	err = initConfig(cfg, options...)
+	err = initConfig(ctx, cfg, options...)
 	if err != nil {
 		return nil, err
 	}
@@ -117,15 +180,20 @@ func (c *Config) ApiURL(urlPath string) string {
 	return path.Join(c.apiEndpointURL, urlPath)
 }
 
+// AuthURL returns the URL of the path on the UAA. With LazyInit the UAA is only known after Initialize or the
+// first authenticated request.
 func (c *Config) AuthURL(urlPath string) string {
+	defer c.lockUnlessAuthReady()()
 	return path.Join(c.uaaEndpointURL, urlPath)
 }
 
 // CreateOAuth2TokenSource is used by the HTTP transport infrastructure to generate new TokenSource instances
 // on-demand.
 func (c *Config) CreateOAuth2TokenSource(ctx context.Context) (oauth2.TokenSource, error) {
-	// use our http.Client instance for token acquisition
-	oauthCtx := context.WithValue(ctx, oauth2.HTTPClient, c.httpClient)
+	// use our http.Client instance for token acquisition, the token source outlives the context so it's only
+	// used to cancel fetching the first token
+	fetchCtx := context.WithValue(ctx, oauth2.HTTPClient, c.httpClient)
+	oauthCtx := context.WithValue(context.WithoutCancel(ctx), oauth2.HTTPClient, c.httpClient)
 
 	twoLeggedAuthConfigFn := func() *clientcredentials.Config {
 		return &clientcredentials.Config{
@@ -143,7 +211,7 @@ func (c *Config) CreateOAuth2TokenSource(ctx context.Context) (oauth2.TokenSourc
 			ClientSecret: c.clientSecret,
 			Scopes:       c.scopes,
 			Endpoint: oauth2.Endpoint{
-				AuthURL:   c.loginEndpointURL + "/oauth/auth",
+				AuthURL:   c.loginEndpointURL + "/oauth/authorize",
 				TokenURL:  c.uaaEndpointURL + "/oauth/token",
 				AuthStyle: oauth2.AuthStyleInHeader,
 			},
@@ -164,19 +232,40 @@ func (c *Config) CreateOAuth2TokenSource(ctx context.Context) (oauth2.TokenSourc
 		}
 
 		// Login using user/pass
-		token, err := authConfig.PasswordCredentialsToken(oauthCtx, c.username, c.password)
+		token, err := authConfig.PasswordCredentialsToken(fetchCtx, c.username, c.password)
 		if err != nil {
 			return nil, err
 		}
 		tokenSource = authConfig.TokenSource(oauthCtx, token)
 	case GrantTypeRefreshToken:
 		authConfig := threeLeggedAuthConfigFn()
-		tokenSource = authConfig.TokenSource(oauthCtx, c.oAuthToken)
+		token, _ := c.tokenSourceToken()
+		tokenSource = authConfig.TokenSource(oauthCtx, token)
+	case GrantTypePasscode, GrantTypeAuthorizationCode:
+		authConfig := threeLeggedAuthConfigFn()
+
+		// the first token source signs in with the passcode or browser, even if a stored token was loaded. The
+		// passcode can only be used once and the user shouldn't have to sign in again each time the client
+		// re-authenticates, so later token sources use the refresh token of the signed in user.
+		token, reauth := c.tokenSourceToken()
+		if !reauth || token == nil || token.RefreshToken == "" {
+			var err error
+			if c.grantType == GrantTypePasscode {
+				token, err = c.passcodeToken(fetchCtx)
+			} else {
+				token, err = c.authorizationCodeToken(fetchCtx, authConfig)
+			}
+			if err != nil {
+				return nil, err
+			}
+			c.replaceOAuthToken(token)
+		}
+		tokenSource = authConfig.TokenSource(oauthCtx, token)
 	case GrantTypeJwtBearer:
-		var uaaEndpointURL string
+		uaaEndpointURL := c.uaaEndpointURL + "/oauth/token"
 		if c.origin != "" {
 			// Add optional login hint to the token URL
-			uaaEndpointURL = addLoginHintToURL(c.uaaEndpointURL+"/oauth/token", c.origin)
+			uaaEndpointURL = addLoginHintToURL(uaaEndpointURL, c.origin)
 		}
 		tokenSource = &jwt.JWTAssertionTokenSource{
 			Assertion:       c.assertion,
@@ -190,7 +279,58 @@ func (c *Config) CreateOAuth2TokenSource(ctx context.Context) (oauth2.TokenSourc
 	default:
 		return nil, fmt.Errorf("unsupported OAuth2 grant type '%s'", c.grantType)
 	}
-	return tokenSource, nil
+
+	// reuse the token until shortly before it expires, so it's refreshed before a request is sent with a token
+	// that expires in flight
+	tokenSource = oauth2.ReuseTokenSourceWithExpiry(nil, tokenSource, tokenRefreshLeeway)
+	last := c.markTokenSourceCreated()
+	return &notifyingTokenSource{config: c, source: tokenSource, last: last}, nil
+}
+
+// tokenSourceToken returns the token a new token source starts from and whether a token source was created
+// before, i.e. because the client is re-authenticating after a 401. Re-authenticating only keeps the refresh
+// token, since the access token was just rejected and would otherwise be reused until it expires.
+func (c *Config) tokenSourceToken() (*oauth2.Token, bool) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+	if c.oAuthToken == nil || !c.tokenSourceCreated {
+		return c.oAuthToken, c.tokenSourceCreated
+	}
+	return &oauth2.Token{RefreshToken: c.oAuthToken.RefreshToken}, true
+}
+
+// markTokenSourceCreated records that a token source was created and returns the latest token
+func (c *Config) markTokenSourceCreated() *oauth2.Token {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+	c.tokenSourceCreated = true
+	return c.oAuthToken
+}
+
+// currentOAuthToken returns the latest token, which is the configured token until it's refreshed
+func (c *Config) currentOAuthToken() *oauth2.Token {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+	return c.oAuthToken
+}
+
+// replaceOAuthToken replaces the token with the token of a new sign in, dropping the refresh token of the
+// configured or stored token since it may belong to a different user
+func (c *Config) replaceOAuthToken(token *oauth2.Token) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+	c.oAuthToken = token
+}
+
+// setOAuthToken replaces the token with a refreshed token, keeping the refresh token if the UAA didn't rotate it
+func (c *Config) setOAuthToken(token *oauth2.Token) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+	t := *token
+	if t.RefreshToken == "" && c.oAuthToken != nil {
+		t.RefreshToken = c.oAuthToken.RefreshToken
+	}
+	c.oAuthToken = &t
 }
 
 // HTTPClient returns the un-authenticated http.Client.
@@ -203,8 +343,79 @@ func (c *Config) HTTPAuthClient() *http.Client {
 	return c.httpAuthClient
 }
 
+// Initialize discovers the UAA endpoints and creates the token source if that was deferred by LazyInit, it's a
+// no-op once they've been created. The endpoints are cached after they're discovered, but a failure isn't, so
+// a later call or request tries again.
+//
+// The endpoints are discovered once for the life of the config and aren't refreshed, so create a new config to
+// pick up a change to the UAA or login endpoints of the CF API.
+func (c *Config) Initialize(ctx context.Context) error {
+	if c.authReady.Load() {
+		return nil
+	}
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+	if c.authReady.Load() {
+		return nil
+	}
+
+	// Query the CF API for UAA/Login endpoints
+	if err := discoverAuthConfig(ctx, c); err != nil {
+		return err
+	}
+
+	// Create the transport for making API calls that require authentication
+	if err := createHTTPAuthTransport(ctx, c); err != nil {
+		return err
+	}
+	c.authReady.Store(true)
+	return nil
+}
+
+// lockUnlessAuthReady locks authMu until the UAA endpoints have been discovered, so they aren't read while
+// Initialize writes them, and returns the func to unlock it
+func (c *Config) lockUnlessAuthReady() func() {
+	if c.authReady.Load() {
+		return func() {}
+	}
+	c.authMu.Lock()
+	return c.authMu.Unlock
+}
+
+// MeterProvider returns the configured OpenTelemetry meter provider, or nil if metrics aren't recorded.
+func (c *Config) MeterProvider() metric.MeterProvider {
+	return c.meterProvider
+}
+
+// TracerProvider returns the configured OpenTelemetry tracer provider, or nil if tracing isn't enabled.
+func (c *Config) TracerProvider() trace.TracerProvider {
+	return c.tracerProvider
+}
+
+// RateLimit returns the configured maximum requests per second and burst size, zero if requests aren't rate limited.
+func (c *Config) RateLimit() (float64, int) {
+	return c.rateLimit, c.rateLimitBurst
+}
+
+// ListAllConcurrency returns the number of pages ListAll methods fetch concurrently, values less than 2 mean
+// pages are fetched sequentially.
+func (c *Config) ListAllConcurrency() int {
+	return c.listConcurrency
+}
+
+// MaxConcurrentRequests returns the configured maximum number of in-flight requests, zero if unlimited.
+func (c *Config) MaxConcurrentRequests() int {
+	return c.maxConcurrent
+}
+
+// RetryPolicy returns the configured request retry policy, or nil if failed requests aren't retried.
+func (c *Config) RetryPolicy() *RetryPolicy {
+	return c.retryPolicy
+}
+
 // SSHOAuthClientID returns the clientID used to request an SSH code, typically 'ssh-proxy'.
 func (c *Config) SSHOAuthClientID() string {
+	defer c.lockUnlessAuthReady()()
 	return c.sshOAuthClient
 }
 
@@ -222,13 +433,24 @@ func (c *Config) Validate() error {
 }
 
 // initConfig fully populates and validates the provided base config
-func initConfig(cfg *Config, options ...Option) error {
+func initConfig(ctx context.Context, cfg *Config, options ...Option) error {
 	// Apply any user provided config overrides
 	err := applyOptions(cfg, options...)
 	if err != nil {
 		return err
 	}
 
+	// A stored token is newer than the configured one since it's replaced each time the token is refreshed
+	if cfg.tokenStore != nil {
+		token, err := cfg.tokenStore.Load()
+		if err != nil {
+			return fmt.Errorf("error loading the stored token: %w", err)
+		}
+		if token != nil {
+			cfg.oAuthToken = token
+		}
+	}
+
 	// Find the appropriate grant type based on config
 	err = setGrantType(cfg)
 	if err != nil {
@@ -236,18 +458,17 @@ func initConfig(cfg *Config, options ...Option) error {
 	}
 
 	// Ensure a http.Client is available and properly configured
-	configureHTTPClient(cfg)
-
-	// Query the CF API for UAA/Login endpoints
-	err = discoverAuthConfig(context.Background(), cfg)
-	if err != nil {
+	if err = configureHTTPClient(cfg); err != nil {
 		return err
 	}
 
-	// Finally create a http.Client for making API calls that require authentication
-	err = createHTTPAuthClient(context.Background(), cfg)
-	if err != nil {
-		return err
+	// Finally create a http.Client for making API calls that require authentication, which with LazyInit
+	// initializes itself on the first request
+	createHTTPAuthClient(cfg)
+	if !cfg.lazyInit {
+		if err = cfg.Initialize(ctx); err != nil {
+			return err
+		}
 	}
 
 	cfg.initialized = true
@@ -269,6 +490,10 @@ func setGrantType(c *Config) error {
 	switch {
 	case c.username != "" && c.password != "":
 		c.grantType = GrantTypePassword
+	case c.passcode != "":
+		c.grantType = GrantTypePasscode
+	case c.authCodeLogin != nil:
+		c.grantType = GrantTypeAuthorizationCode
 	case c.assertion != "":
 		c.grantType = GrantTypeJwtBearer
 	case c.clientID != "" && (c.clientSecret != "" || c.clientAssertion != ""):
@@ -283,32 +508,79 @@ func setGrantType(c *Config) error {
 
 // configureHTTPClient creates a default http.Client if one wasn't supplied in the config and then
 // configures the base http.Client from the config.
-func configureHTTPClient(c *Config) {
-	// Ensure there is a client and transport configured
+func configureHTTPClient(c *Config) error {
+	// Ensure there is a client and transport configured. A client supplied with the HttpClient option is copied,
+	// so wrapping its transport doesn't change the caller's client, which may be shared with other configs.
 	if c.httpClient == nil {
 		c.httpClient = &http.Client{}
+	} else {
+		client := *c.httpClient
+		c.httpClient = &client
 	}
 	if c.httpClient.Transport == nil {
 		c.httpClient.Transport = http.DefaultTransport.(*http.Transport).Clone()
 	}
 
-	// Ensure there is a TLS config instance then configure it
-	if transport := getHTTPTransport(c.httpClient); transport != nil {
+	// Configure the TLS of a copy of the transport, the supplied transport and its TLS config may be shared
+	if roundTripper, transport := cloneHTTPTransport(c.httpClient.Transport); transport != nil {
 		if transport.TLSClientConfig == nil {
 			transport.TLSClientConfig = &tls.Config{}
 		}
-		transport.TLSClientConfig.InsecureSkipVerify = c.skipTLSValidation
+		configureTLS(c, transport.TLSClientConfig)
+		c.httpClient.Transport = roundTripper
+	} else if len(c.caCerts) > 0 || len(c.clientCerts) > 0 {
+		return fmt.Errorf("the CA and client certificate options require an *http.Transport, but the HTTP client's transport is a %T", c.httpClient.Transport)
+	}
+
+	// Wrap the base transport with any middleware after TLS has been configured, the auth client builds on
+	// this transport so the middleware applies to both clients. Trace logging is innermost so it logs each
+	// request exactly as it's sent.
+	if c.trace != nil {
+		c.httpClient.Transport = c.trace.middleware()(c.httpClient.Transport)
+	}
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		c.httpClient.Transport = c.middleware[i](c.httpClient.Transport)
 	}
 
 	// Use our configurable redirect function and the configured timeout
 	c.httpClient.CheckRedirect = internal.CheckRedirect
 	c.httpClient.Timeout = c.requestTimeout
+	return nil
 }
 
 // createHTTPAuthClient creates the http.Client used for any API calls that require authentication.
-func createHTTPAuthClient(ctx context.Context, c *Config) (err error) {
-	c.httpAuthClient, err = internal.NewAuthenticatedClient(ctx, c.httpClient, c)
-	return err
+func createHTTPAuthClient(c *Config) {
+	c.httpAuthClient = &http.Client{
+		Transport:     &initializingTransport{config: c},
+		Timeout:       c.httpClient.Timeout,
+		CheckRedirect: c.httpClient.CheckRedirect,
+		Jar:           c.httpClient.Jar,
+	}
+}
+
+// createHTTPAuthTransport creates the transport that authenticates API calls.
+func createHTTPAuthTransport(ctx context.Context, c *Config) error {
+	authClient, err := internal.NewAuthenticatedClient(ctx, c.httpClient, c)
+	if err != nil {
+		return err
+	}
+	c.authTransport = authClient.Transport
+	return nil
+}
+
+// initializingTransport initializes the config before sending the first authenticated request
+type initializingTransport struct {
+	config *Config
+}
+
+func (t *initializingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.config.Initialize(req.Context()); err != nil {
+		if req.Body != nil {
+			ios.Close(req.Body)
+		}
+		return nil, err
+	}
+	return t.config.authTransport.RoundTrip(req)
 }
 
 // discoverAuthConfig configures the UAA and Login config properties from the CF API if none were supplied in the
@@ -375,20 +647,29 @@ func createConfigFromCFCLIConfig(cfHomeDir string) (*Config, error) {
 		password:          os.Getenv("CF_PASSWORD"),
 	}
 	cfg.oAuthToken, _ = jwt.ToOAuth2Token(cf.AccessToken, cf.RefreshToken)
+	cfg.trace, err = traceFromEnv()
+	if err != nil {
+		return nil, err
+	}
 
 	return cfg, nil
 }
 
-func getHTTPTransport(client *http.Client) *http.Transport {
-	switch t := client.Transport.(type) {
+// cloneHTTPTransport returns a copy of the round tripper whose *http.Transport, which is also returned, can be
+// configured without changing the original. A nil *http.Transport is returned if the round tripper isn't an
+// *http.Transport or an oauth2.Transport wrapping one.
+func cloneHTTPTransport(rt http.RoundTripper) (http.RoundTripper, *http.Transport) {
+	switch t := rt.(type) {
 	case *http.Transport:
-		return t
+		clone := t.Clone()
+		return clone, clone
 	case *oauth2.Transport:
 		if httpTransport, ok := t.Base.(*http.Transport); ok {
-			return httpTransport
+			clone := httpTransport.Clone()
+			return &oauth2.Transport{Source: t.Source, Base: clone}, clone
 		}
 	}
-	return nil
+	return rt, nil
 }
 
 func addLoginHintToURL(tokenURL, origin string) string {
@@ -1,8 +1,13 @@
 package config
 
 import (
+	"context"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"os"
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/cloudfoundry/go-cfclient/v3/testutil"
@@ -236,6 +241,13 @@ func TestNewConfigFromCFHomeDir(t *testing.T) {
 		require.Equal(t, DefaultClientID, cfg.clientID)
 		require.Equal(t, GrantTypePassword, cfg.grantType)
 	})
+
+	t.Run("with canceled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := NewFromCFHomeDirWithContext(ctx, cfHomeDir, UserPassword("admin", "pass"))
+		require.ErrorIs(t, err, context.Canceled)
+	})
 }
 
 func TestJwBearerAssertion(t *testing.T) {
@@ -247,4 +259,119 @@ func TestJwBearerAssertion(t *testing.T) {
 		require.Equal(t, jwtAssertion, cfg.assertion)
 		require.Equal(t, GrantTypeJwtBearer, cfg.grantType)
 	})
+
+	t.Run("reuses the token until shortly before it expires", func(t *testing.T) {
+		for _, tc := range []struct {
+			expiresIn int
+			fetches   int32
+		}{
+			{expiresIn: 300, fetches: 1},
+			{expiresIn: 20, fetches: 3},
+		} {
+			var fetches atomic.Int32
+			uaa := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := fetches.Add(1)
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"bearer","expires_in":%d}`, n, tc.expiresIn)
+			}))
+			cfg, err := New("https://api.example.com",
+				JWTBearerAssertion(accessToken),
+				AuthTokenURL(uaa.URL, uaa.URL))
+			require.NoError(t, err)
+
+			ts, err := cfg.CreateOAuth2TokenSource(context.Background())
+			require.NoError(t, err)
+			for range 3 {
+				_, err = ts.Token()
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.fetches, fetches.Load(), "expires_in %d", tc.expiresIn)
+			uaa.Close()
+		}
+	})
+}
+
+func TestNewWithContext(t *testing.T) {
+	t.Run("cancels discovery", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := NewWithContext(ctx, "https://api.example.com", ClientCredentials("clientID", "clientSecret"))
+		require.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("the token source outlives the context", func(t *testing.T) {
+		uaaURL := testutil.SetupFakeUAAServer(300)
+		defer testutil.Teardown()
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer api.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		c, err := NewWithContext(ctx, api.URL, ClientCredentials("clientID", "clientSecret"), AuthTokenURL(uaaURL, uaaURL))
+		require.NoError(t, err)
+		cancel()
+		resp, err := c.HTTPAuthClient().Get(api.URL + "/v3/apps")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+}
+
+func TestLazyInit(t *testing.T) {
+	uaaURL := testutil.SetupFakeUAAServer(300)
+	defer testutil.Teardown()
+
+	var rootRequests atomic.Int32
+	var failRoot atomic.Bool
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			require.Equal(t, "Bearer foobar1", r.Header.Get("Authorization"))
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		rootRequests.Add(1)
+		if failRoot.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"links":{"login":{"href":"%s"},"uaa":{"href":"%s"},"app_ssh":{"meta":{"oauth_client":"ssh-client"}}}}`,
+			uaaURL, uaaURL)
+	}))
+	defer api.Close()
+
+	failRoot.Store(true)
+	c, err := New(api.URL, ClientCredentials("clientID", "clientSecret"), LazyInit())
+	require.NoError(t, err)
+	require.NoError(t, c.Validate())
+	require.Zero(t, rootRequests.Load())
+
+	// a failed discovery isn't cached
+	_, err = c.HTTPAuthClient().Get(api.URL + "/v3/apps")
+	require.ErrorContains(t, err, "error while discovering token service URL")
+	require.Equal(t, int32(1), rootRequests.Load())
+
+	failRoot.Store(false)
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := c.HTTPAuthClient().Get(api.URL + "/v3/apps")
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+		}()
+		// reading the endpoints while they're discovered doesn't race
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = c.AuthURL("/oauth/authorize")
+			_ = c.SSHOAuthClientID()
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, int32(2), rootRequests.Load())
+	require.Equal(t, uaaURL+"/oauth/authorize", c.AuthURL("/oauth/authorize"))
+	require.Equal(t, "ssh-client", c.SSHOAuthClientID())
+	require.NoError(t, c.Initialize(context.Background()))
+	require.Equal(t, int32(2), rootRequests.Load())
 }
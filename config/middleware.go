@@ -0,0 +1,45 @@
+package config
+
+import (
+	"net/http"
+)
+
+// Middleware wraps an http.RoundTripper to observe or modify requests sent to the CF API and UAA.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc is an adapter to allow the use of ordinary functions as an http.RoundTripper.
+type RoundTripperFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req)
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// RequestHook returns a Middleware that calls fn before each request is sent.
+//
+// The hook receives a clone of the request, so it may safely add headers, for example to propagate tracing
+// context. Returning an error aborts the request.
+func RequestHook(fn func(req *http.Request) error) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			req = req.Clone(req.Context())
+			if err := fn(req); err != nil {
+				return nil, err
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// ResponseHook returns a Middleware that calls fn after each response is received, or the request failed.
+//
+// The hook must not read or close the response body.
+func ResponseHook(fn func(req *http.Request, resp *http.Response, err error)) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			resp, err := next.RoundTrip(req)
+			fn(req, resp, err)
+			return resp, err
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package config
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/cloudfoundry/go-cfclient/v3/testutil"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoundTripperMiddleware(t *testing.T) {
+	var mu sync.Mutex
+	var calls []string
+	record := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				mu.Lock()
+				calls = append(calls, name+" "+req.URL.Path)
+				mu.Unlock()
+				return next.RoundTrip(req)
+			})
+		}
+	}
+
+	var authHeader, traceHeader string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authHeader = r.Header.Get("Authorization")
+		traceHeader = r.Header.Get("X-Trace-Id")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer api.Close()
+	uaaURL := testutil.SetupFakeUAAServer(300)
+	defer testutil.Teardown()
+
+	var responseStatus int
+	c, err := New(api.URL,
+		ClientCredentials("clientID", "clientSecret"),
+		AuthTokenURL(uaaURL, uaaURL),
+		RoundTripperMiddleware(record("outer"), record("inner")),
+		RoundTripperMiddleware(
+			RequestHook(func(req *http.Request) error {
+				req.Header.Set("X-Trace-Id", "trace-123")
+				return nil
+			}),
+			ResponseHook(func(req *http.Request, resp *http.Response, err error) {
+				if resp != nil {
+					responseStatus = resp.StatusCode
+				}
+			})))
+	require.NoError(t, err)
+
+	t.Run("applies to the authenticated client inside the oauth layer", func(t *testing.T) {
+		calls = nil
+		resp, err := c.HTTPAuthClient().Get(api.URL + "/v3/apps")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, []string{
+			"outer /oauth/token",
+			"inner /oauth/token",
+			"outer /v3/apps",
+			"inner /v3/apps",
+		}, calls)
+		require.Equal(t, "Bearer foobar1", authHeader)
+		require.Equal(t, "trace-123", traceHeader)
+		require.Equal(t, http.StatusOK, responseStatus)
+	})
+
+	t.Run("applies to the unauthenticated client", func(t *testing.T) {
+		calls = nil
+		resp, err := c.HTTPClient().Get(api.URL + "/")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, []string{"outer /", "inner /"}, calls)
+		require.Empty(t, authHeader)
+		require.Equal(t, "trace-123", traceHeader)
+	})
+
+	t.Run("request hook errors abort the request", func(t *testing.T) {
+		hookErr := errors.New("blocked")
+		c, err := New(api.URL,
+			Token(accessToken, refreshToken),
+			AuthTokenURL(uaaURL, uaaURL),
+			RoundTripperMiddleware(RequestHook(func(req *http.Request) error {
+				return hookErr
+			})))
+		require.NoError(t, err)
+		_, err = c.HTTPClient().Get(api.URL + "/")
+		require.ErrorIs(t, err, hookErr)
+	})
+
+	t.Run("doesn't change a shared client", func(t *testing.T) {
+		shared := &http.Client{Transport: http.DefaultTransport.(*http.Transport).Clone()}
+		transport := shared.Transport
+		for range 2 {
+			c, err := New(api.URL,
+				Token(accessToken, refreshToken),
+				AuthTokenURL(uaaURL, uaaURL),
+				HttpClient(shared),
+				RoundTripperMiddleware(record("shared")))
+			require.NoError(t, err)
+			calls = nil
+			_, err = c.HTTPClient().Get(api.URL + "/")
+			require.NoError(t, err)
+			require.Equal(t, []string{"shared /"}, calls)
+		}
+		require.Same(t, transport, shared.Transport)
+	})
+
+	t.Run("nil middleware is rejected", func(t *testing.T) {
+		_, err := New(api.URL,
+			Token(accessToken, refreshToken),
+			AuthTokenURL(uaaURL, uaaURL),
+			RoundTripperMiddleware(nil))
+		require.EqualError(t, err, "round tripper middleware must not be nil")
+	})
+}
@@ -1,13 +1,22 @@
 package config
 
 import (
+	"crypto/tls"
 	"errors"
 	"fmt"
+	"io"
+	"log/slog"
+	"net"
 	"net/http"
 	"net/url"
+	"os"
+	"slices"
 	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/cloudfoundry/go-cfclient/v3/internal/jwt"
 )
 
@@ -81,6 +90,49 @@ func JWTBearerAssertion(assertion string) Option {
 	}
 }
 
+// Passcode is a functional option to sign in an SSO user with a one-time passcode from the UAA's /passcode page,
+// like cf login --sso-passcode. It signs in even if a stored token was loaded, re-authentication then uses the
+// refresh token of the signed in user.
+func Passcode(passcode string) Option {
+	return func(c *Config) error {
+		if passcode = strings.TrimSpace(passcode); passcode == "" {
+			return errors.New("passcode is required when using a passcode")
+		}
+		c.passcode = passcode
+		return nil
+	}
+}
+
+// AuthorizationCodePKCE is a functional option to sign in a user in the browser with the authorization code grant
+// and PKCE. openURL is called with the URL the user signs in at, i.e. to open it in their browser or print it.
+//
+// The UAA then redirects the browser to http://<listenAddr>/callback, which must be a loopback address and match
+// a redirect URI registered for the client, i.e. localhost:8080. An empty address listens on a random port of
+// 127.0.0.1. The user signs in even if a stored token was loaded, re-authentication then uses the refresh token of
+// the signed in user.
+func AuthorizationCodePKCE(listenAddr string, openURL func(authURL string) error) Option {
+	return func(c *Config) error {
+		if openURL == nil {
+			return errors.New("a func to open the login URL is required when using the authorization code grant")
+		}
+		if listenAddr = strings.TrimSpace(listenAddr); listenAddr == "" {
+			listenAddr = defaultAuthCodeListenAddr
+		}
+		host, _, err := net.SplitHostPort(listenAddr)
+		if err != nil {
+			return fmt.Errorf("invalid redirect listen address %s: %w", listenAddr, err)
+		}
+		if !isLoopbackHost(host) {
+			return fmt.Errorf("the redirect listen address %s must be a loopback address", listenAddr)
+		}
+		c.authCodeLogin = &authCodeLogin{
+			listenAddr: listenAddr,
+			openURL:    openURL,
+		}
+		return nil
+	}
+}
+
 // Token is a functional option to set the access and refresh tokens.
 func Token(accessToken, refreshToken string) Option {
 	return func(c *Config) error {
@@ -147,6 +199,24 @@ func HttpClient(client *http.Client) Option {
 	}
 }
 
+// RoundTripperMiddleware is a functional option to wrap the HTTP transport used by both the unauthenticated
+// and authenticated clients with the specified middleware.
+//
+// The first middleware is the outermost. All middleware runs inside the OAuth2 layer, so requests already
+// carry the Authorization header, and every attempt made by the retry policy or a re-authentication passes
+// through the middleware separately. Requests to UAA for tokens also pass through the middleware.
+func RoundTripperMiddleware(middleware ...Middleware) Option {
+	return func(c *Config) error {
+		for _, m := range middleware {
+			if m == nil {
+				return errors.New("round tripper middleware must not be nil")
+			}
+		}
+		c.middleware = append(c.middleware, middleware...)
+		return nil
+	}
+}
+
 // RequestTimeout is a functional option to set the request timeout.
 func RequestTimeout(timeout time.Duration) Option {
 	return func(c *Config) error {
@@ -159,6 +229,150 @@ func RequestTimeout(timeout time.Duration) Option {
 	}
 }
 
+// RateLimit is a functional option to limit the rate of requests sent to the CF API using a token bucket.
+//
+// The limit is shared by every request made through the client. Burst is the maximum number of requests
+// that may be sent at once, values less than 1 default to 1.
+func RateLimit(requestsPerSecond float64, burst int) Option {
+	return func(c *Config) error {
+		if requestsPerSecond <= 0 {
+			return errors.New("rate limit requests per second must be greater than 0")
+		}
+		c.rateLimit = requestsPerSecond
+		c.rateLimitBurst = max(burst, 1)
+		return nil
+	}
+}
+
+// ListAllConcurrency is a functional option to have ListAll methods fetch the remaining pages concurrently
+// using up to the specified number of workers once the first page reports the total page count.
+func ListAllConcurrency(workers int) Option {
+	return func(c *Config) error {
+		if workers < 1 {
+			return errors.New("list all concurrency must be at least 1")
+		}
+		c.listConcurrency = workers
+		return nil
+	}
+}
+
+// MaxConcurrentRequests is a functional option to cap the number of requests in-flight to the CF API at once.
+// A request counts against the cap until its response headers arrive, reading a response body, i.e. a package
+// or droplet download, doesn't hold up other requests.
+func MaxConcurrentRequests(maxInFlight int) Option {
+	return func(c *Config) error {
+		if maxInFlight < 1 {
+			return errors.New("max concurrent requests must be at least 1")
+		}
+		c.maxConcurrent = maxInFlight
+		return nil
+	}
+}
+
+// Retry is a functional option to retry requests that fail with a transient error using the given policy.
+//
+// Use DefaultRetryPolicy as a starting point for a custom policy.
+func Retry(policy RetryPolicy) Option {
+	return func(c *Config) error {
+		if policy.MaxAttempts < 1 {
+			return errors.New("retry policy max attempts must be at least 1")
+		}
+		if policy.MaxBackoff > 0 && policy.InitialBackoff > policy.MaxBackoff {
+			return errors.New("retry policy initial backoff must not exceed the max backoff")
+		}
+		policy.RetryableStatusCodes = slices.Clone(policy.RetryableStatusCodes)
+		policy.RetryableMethods = slices.Clone(policy.RetryableMethods)
+		c.retryPolicy = &policy
+		return nil
+	}
+}
+
+// HTTPTraceLogger is a functional option to log each request sent to the CF API and UAA at debug level,
+// including the method, URL, status and duration.
+//
+// If logBodies is true, the headers along with any JSON, form or text bodies are also logged. Authorization
+// headers, tokens, passwords, client secrets and service credentials are always redacted.
+func HTTPTraceLogger(logger *slog.Logger, logBodies bool) Option {
+	return func(c *Config) error {
+		if logger == nil {
+			return errors.New("http trace logger must not be nil")
+		}
+		c.trace = &httpTrace{logger: logger, logBodies: logBodies}
+		return nil
+	}
+}
+
+// HTTPTraceWriter is a functional option to write a text log of each request sent to the CF API and UAA to
+// the writer. See HTTPTraceLogger for what is logged.
+func HTTPTraceWriter(w io.Writer, logBodies bool) Option {
+	return func(c *Config) error {
+		if w == nil {
+			return errors.New("http trace writer must not be nil")
+		}
+		c.trace = &httpTrace{logger: newTraceWriterLogger(w), logBodies: logBodies}
+		return nil
+	}
+}
+
+// MeterProvider is a functional option to record request count and latency metrics with OpenTelemetry.
+//
+// Metrics are broken down by HTTP method, response status code and endpoint template, i.e. /v3/apps/:guid,
+// rather than the raw URL.
+func MeterProvider(provider metric.MeterProvider) Option {
+	return func(c *Config) error {
+		if provider == nil {
+			return errors.New("meter provider must not be nil")
+		}
+		c.meterProvider = provider
+		return nil
+	}
+}
+
+// TracerProvider is a functional option to create OpenTelemetry spans for each request, i.e. GET /v3/apps/:guid,
+// and for each stage of the higher level operations like an app push.
+func TracerProvider(provider trace.TracerProvider) Option {
+	return func(c *Config) error {
+		if provider == nil {
+			return errors.New("tracer provider must not be nil")
+		}
+		c.tracerProvider = provider
+		return nil
+	}
+}
+
+// StoreTokens is a functional option to persist the token in the store each time it's refreshed. A stored token
+// replaces the token from the Token option or the CF CLI config, so a rotated refresh token survives a restart.
+func StoreTokens(store TokenStore) Option {
+	return func(c *Config) error {
+		if store == nil {
+			return errors.New("the token store can't be nil")
+		}
+		c.tokenStore = store
+		return nil
+	}
+}
+
+// OnTokenRefresh is a functional option to call fn with each new token, including the first one fetched. It's
+// called while the request that needed the token waits, so it shouldn't block.
+func OnTokenRefresh(fn TokenRefreshFunc) Option {
+	return func(c *Config) error {
+		if fn == nil {
+			return errors.New("the token refresh func can't be nil")
+		}
+		c.onTokenRefresh = append(c.onTokenRefresh, fn)
+		return nil
+	}
+}
+
+// LazyInit is a functional option to defer discovering the UAA endpoints and fetching the first token until the
+// first authenticated request, or an explicit call to Config.Initialize, so creating a config makes no requests.
+func LazyInit() Option {
+	return func(c *Config) error {
+		c.lazyInit = true
+		return nil
+	}
+}
+
 // SkipTLSValidation is a functional option to skip TLS validation.
 func SkipTLSValidation() Option {
 	return func(c *Config) error {
@@ -167,6 +381,66 @@ func SkipTLSValidation() Option {
 	}
 }
 
+// CACertificates is a functional option to trust the CA certificates in the PEM files in addition to the system's
+// root CAs, i.e. for a foundation that uses an internal CA. The files are read when the option is applied.
+func CACertificates(pemFiles ...string) Option {
+	return func(c *Config) error {
+		for _, file := range pemFiles {
+			b, err := os.ReadFile(file)
+			if err != nil {
+				return fmt.Errorf("error reading the CA certificates in %s: %w", file, err)
+			}
+			certs, err := parseCACertificates(b)
+			if err != nil {
+				return fmt.Errorf("error reading the CA certificates in %s: %w", file, err)
+			}
+			c.caCerts = append(c.caCerts, certs...)
+		}
+		return nil
+	}
+}
+
+// CACertificatesPEM is a functional option to trust the PEM encoded CA certificates in addition to the system's
+// root CAs.
+func CACertificatesPEM(pemCerts []byte) Option {
+	return func(c *Config) error {
+		certs, err := parseCACertificates(pemCerts)
+		if err != nil {
+			return err
+		}
+		c.caCerts = append(c.caCerts, certs...)
+		return nil
+	}
+}
+
+// ClientCertificate is a functional option to present the client certificate and key in the PEM files to the CF API
+// and UAA, i.e. for a UAA that uses tls_client_auth. The files are reloaded when they change, so a rotated
+// certificate is used for new connections without creating a new client. Each option adds a certificate, the first
+// one the server accepts is presented.
+func ClientCertificate(certFile, keyFile string) Option {
+	return func(c *Config) error {
+		cert, err := loadClientCertificate(certFile, keyFile)
+		if err != nil {
+			return err
+		}
+		c.clientCerts = append(c.clientCerts, cert)
+		return nil
+	}
+}
+
+// ClientCertificatePEM is a functional option to present the PEM encoded client certificate and key to the CF API
+// and UAA.
+func ClientCertificatePEM(certPEM, keyPEM []byte) Option {
+	return func(c *Config) error {
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return fmt.Errorf("error loading the client certificate: %w", err)
+		}
+		c.clientCerts = append(c.clientCerts, &clientCertificate{cert: &cert})
+		return nil
+	}
+}
+
 // SSHOAuthClient configures a clientID used to request an SSH code.
 func SSHOAuthClient(clientID string) Option {
 	return func(c *Config) error {
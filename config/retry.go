@@ -0,0 +1,110 @@
+package config
+
+import (
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"time"
+)
+
+const (
+	DefaultRetryMaxAttempts    = 3
+	DefaultRetryInitialBackoff = 500 * time.Millisecond
+	DefaultRetryMaxBackoff     = 10 * time.Second
+	DefaultRetryMaxRetryAfter  = time.Minute
+)
+
+// RetryPolicy controls how requests to the CF API are retried when they fail with a transient error.
+//
+// A request is only retried if its method is one of the RetryableMethods and it failed with either a transient
+// network error, i.e. a timeout or a reset connection, or one of the RetryableStatusCodes. POST and PATCH are not
+// idempotent and are never retried unless they're explicitly included in RetryableMethods.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of times a request is sent, including the first attempt
+	MaxAttempts int
+
+	// InitialBackoff is the base delay before the first retry, it doubles with each subsequent retry
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the computed delay between attempts
+	MaxBackoff time.Duration
+
+	// RetryableStatusCodes are the HTTP response status codes that cause a retry
+	RetryableStatusCodes []int
+
+	// RetryableMethods are the HTTP methods that are safe to retry
+	RetryableMethods []string
+
+	// IgnoreRetryAfter disables honoring the Retry-After response header
+	IgnoreRetryAfter bool
+
+	// MaxRetryAfter caps the delay a server can request with the Retry-After response header, so a server can't
+	// stall the client indefinitely. MaxBackoff is used when it's zero.
+	MaxRetryAfter time.Duration
+}
+
+// DefaultRetryPolicy returns a RetryPolicy that retries idempotent requests up to 3 times on 429, 502, 503,
+// 504 or a transient network error using exponential backoff with jitter, waiting at most a minute when the
+// server sends a Retry-After header.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    DefaultRetryMaxAttempts,
+		InitialBackoff: DefaultRetryInitialBackoff,
+		MaxBackoff:     DefaultRetryMaxBackoff,
+		MaxRetryAfter:  DefaultRetryMaxRetryAfter,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		RetryableMethods: []string{
+			http.MethodGet,
+			http.MethodHead,
+			http.MethodOptions,
+			http.MethodPut,
+			http.MethodDelete,
+		},
+	}
+}
+
+// ShouldRetryMethod returns true if requests with the specified HTTP method can be retried.
+func (p *RetryPolicy) ShouldRetryMethod(method string) bool {
+	return slices.Contains(p.RetryableMethods, method)
+}
+
+// ShouldRetryStatus returns true if a response with the specified status code should be retried.
+func (p *RetryPolicy) ShouldRetryStatus(statusCode int) bool {
+	return slices.Contains(p.RetryableStatusCodes, statusCode)
+}
+
+// Backoff returns how long to wait before sending the next attempt.
+//
+// Attempt is the 1 based number of the attempt that just failed. If the server sent a Retry-After delay
+// and the policy honors it, that delay is used up to MaxRetryAfter, or MaxBackoff if it's zero. Otherwise, the
+// delay is exponential with full jitter, i.e. a random duration between 0 and
+// min(MaxBackoff, InitialBackoff * 2^(attempt-1)).
+func (p *RetryPolicy) Backoff(attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 && !p.IgnoreRetryAfter {
+		maxRetryAfter := p.MaxRetryAfter
+		if maxRetryAfter <= 0 {
+			maxRetryAfter = p.MaxBackoff
+		}
+		if maxRetryAfter > 0 {
+			return min(retryAfter, maxRetryAfter)
+		}
+		return retryAfter
+	}
+	if p.InitialBackoff <= 0 {
+		return 0
+	}
+
+	backoff := p.InitialBackoff
+	for i := 1; i < attempt && (p.MaxBackoff <= 0 || backoff < p.MaxBackoff); i++ {
+		backoff *= 2
+	}
+	if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+		backoff = p.MaxBackoff
+	}
+	return rand.N(backoff + 1)
+}
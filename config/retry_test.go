@@ -0,0 +1,73 @@
+package config
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	t.Run("default policy excludes non-idempotent methods", func(t *testing.T) {
+		p := DefaultRetryPolicy()
+		require.True(t, p.ShouldRetryMethod(http.MethodGet))
+		require.True(t, p.ShouldRetryMethod(http.MethodDelete))
+		require.False(t, p.ShouldRetryMethod(http.MethodPost))
+		require.False(t, p.ShouldRetryMethod(http.MethodPatch))
+		require.True(t, p.ShouldRetryStatus(http.StatusServiceUnavailable))
+		require.True(t, p.ShouldRetryStatus(http.StatusTooManyRequests))
+		require.False(t, p.ShouldRetryStatus(http.StatusInternalServerError))
+	})
+
+	t.Run("backoff is capped and jittered", func(t *testing.T) {
+		p := RetryPolicy{
+			InitialBackoff: 100 * time.Millisecond,
+			MaxBackoff:     time.Second,
+		}
+		for attempt := 1; attempt < 10; attempt++ {
+			ceiling := min(time.Second, 100*time.Millisecond<<(attempt-1))
+			b := p.Backoff(attempt, 0)
+			require.GreaterOrEqual(t, b, time.Duration(0))
+			require.LessOrEqual(t, b, ceiling)
+		}
+	})
+
+	t.Run("backoff honors retry-after", func(t *testing.T) {
+		p := DefaultRetryPolicy()
+		require.Equal(t, 3*time.Second, p.Backoff(1, 3*time.Second))
+		require.Equal(t, DefaultRetryMaxRetryAfter, p.Backoff(1, time.Hour))
+
+		p.MaxRetryAfter = 0
+		require.Equal(t, DefaultRetryMaxBackoff, p.Backoff(1, time.Hour))
+
+		p.IgnoreRetryAfter = true
+		require.LessOrEqual(t, p.Backoff(1, 30*time.Second), DefaultRetryInitialBackoff)
+	})
+
+	t.Run("with retry option", func(t *testing.T) {
+		c, err := New("https://api.example.com",
+			Token(accessToken, refreshToken),
+			Retry(DefaultRetryPolicy()),
+			AuthTokenURL("https://login.cf.example.com", "https://token.cf.example.com")) // skip service discovery
+		require.NoError(t, err)
+		require.NotNil(t, c.RetryPolicy())
+		require.Equal(t, DefaultRetryMaxAttempts, c.RetryPolicy().MaxAttempts)
+	})
+
+	t.Run("without retry option", func(t *testing.T) {
+		c, err := New("https://api.example.com",
+			Token(accessToken, refreshToken),
+			AuthTokenURL("https://login.cf.example.com", "https://token.cf.example.com")) // skip service discovery
+		require.NoError(t, err)
+		require.Nil(t, c.RetryPolicy())
+	})
+
+	t.Run("with invalid max attempts", func(t *testing.T) {
+		_, err := New("https://api.example.com",
+			Token(accessToken, refreshToken),
+			Retry(RetryPolicy{}),
+			AuthTokenURL("https://login.cf.example.com", "https://token.cf.example.com")) // skip service discovery
+		require.EqualError(t, err, "retry policy max attempts must be at least 1")
+	})
+}
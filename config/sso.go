@@ -0,0 +1,141 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// authCodeCallbackPath is the path of the loopback redirect URI the UAA sends the authorization code to
+const authCodeCallbackPath = "/callback"
+
+// defaultAuthCodeListenAddr listens for the authorization code on a random loopback port
+const defaultAuthCodeListenAddr = "127.0.0.1:0"
+
+// authCodeLogin is the configuration of the authorization code grant
+type authCodeLogin struct {
+	listenAddr string
+	openURL    func(authURL string) error
+}
+
+// authCodeResult is the authorization code, or the error, the UAA redirected the browser back with
+type authCodeResult struct {
+	code string
+	err  error
+}
+
+// passcodeToken exchanges the one-time SSO passcode for a token, the UAA accepts a passcode in place of the
+// username and password of the password grant
+func (c *Config) passcodeToken(ctx context.Context) (*oauth2.Token, error) {
+	authConfig := &clientcredentials.Config{
+		ClientID:     c.clientID,
+		ClientSecret: c.clientSecret,
+		Scopes:       c.scopes,
+		TokenURL:     c.uaaEndpointURL + "/oauth/token",
+		AuthStyle:    oauth2.AuthStyleInHeader,
+		EndpointParams: url.Values{
+			"grant_type": {"password"},
+			"passcode":   {c.passcode},
+		},
+	}
+	token, err := authConfig.Token(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error exchanging the passcode for a token: %w", err)
+	}
+	return token, nil
+}
+
+// authorizationCodeToken signs the user in with the authorization code grant and PKCE. The user signs in at the
+// URL passed to openURL, then the UAA redirects the browser to a listener on the loopback address with the code.
+func (c *Config) authorizationCodeToken(ctx context.Context, authConfig *oauth2.Config) (*oauth2.Token, error) {
+	listener, err := net.Listen("tcp", c.authCodeLogin.listenAddr)
+	if err != nil {
+		return nil, fmt.Errorf("error listening for the authorization code redirect: %w", err)
+	}
+	host, _, _ := net.SplitHostPort(c.authCodeLogin.listenAddr)
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+	authConfig.RedirectURL = "http://" + net.JoinHostPort(host, port) + authCodeCallbackPath
+
+	state := oauth2.GenerateVerifier()
+	verifier := oauth2.GenerateVerifier()
+	opts := []oauth2.AuthCodeOption{oauth2.S256ChallengeOption(verifier)}
+	if c.origin != "" {
+		opts = append(opts, oauth2.SetAuthURLParam("login_hint", fmt.Sprintf(`{"origin":"%s"}`, c.origin)))
+	}
+
+	result := make(chan authCodeResult, 1)
+	server := &http.Server{
+		Handler:           authCodeCallback(state, result),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() { _ = server.Serve(listener) }()
+	defer func() { _ = server.Close() }()
+
+	if err = c.authCodeLogin.openURL(authConfig.AuthCodeURL(state, opts...)); err != nil {
+		return nil, fmt.Errorf("error opening the login URL: %w", err)
+	}
+
+	var r authCodeResult
+	select {
+	case r = <-result:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("error waiting for the user to sign in: %w", ctx.Err())
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	token, err := authConfig.Exchange(ctx, r.code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("error exchanging the authorization code for a token: %w", err)
+	}
+	return token, nil
+}
+
+// authCodeCallback handles the UAA's redirect with the authorization code. Requests without the expected state
+// are rejected, so another site can't sign the client in as a different user.
+func authCodeCallback(state string, result chan<- authCodeResult) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(authCodeCallbackPath, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("state") != state {
+			http.Error(w, "Invalid login state, please try signing in again.", http.StatusBadRequest)
+			return
+		}
+
+		var res authCodeResult
+		switch {
+		case q.Get("error") != "":
+			res.err = fmt.Errorf("the login failed: %s %s", q.Get("error"), q.Get("error_description"))
+			http.Error(w, "The login failed, you can close this window.", http.StatusUnauthorized)
+		case q.Get("code") == "":
+			res.err = errors.New("the login redirect doesn't have an authorization code")
+			http.Error(w, "The login failed, you can close this window.", http.StatusBadRequest)
+		default:
+			res.code = q.Get("code")
+			_, _ = w.Write([]byte("Login successful, you can close this window."))
+		}
+
+		// only the first redirect is used
+		select {
+		case result <- res:
+		default:
+		}
+	})
+	return mux
+}
+
+// isLoopbackHost returns true if the host only accepts connections from the local machine
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,196 @@
+package config
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+// fakeSSOUAA is a UAA that accepts the passcode and authorization code grants
+type fakeSSOUAA struct {
+	t *testing.T
+
+	mu     sync.Mutex
+	grants []string
+	forms  []url.Values
+}
+
+func (f *fakeSSOUAA) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	require.NoError(f.t, r.ParseForm())
+	f.mu.Lock()
+	f.grants = append(f.grants, r.PostForm.Get("grant_type"))
+	f.forms = append(f.forms, r.PostForm)
+	f.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case r.PostForm.Get("grant_type") == "password" && r.PostForm.Get("passcode") == "123456",
+		r.PostForm.Get("grant_type") == "authorization_code" && r.PostForm.Get("code") == "the-code",
+		r.PostForm.Get("grant_type") == "refresh_token":
+		_, _ = w.Write([]byte(`{"access_token":"access","refresh_token":"refresh","token_type":"bearer","expires_in":300}`))
+	default:
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+	}
+}
+
+func TestPasscode(t *testing.T) {
+	t.Run("with empty passcode", func(t *testing.T) {
+		_, err := New("https://api.example.com",
+			Passcode(" "),
+			AuthTokenURL("https://login.cf.example.com", "https://token.cf.example.com")) // skip service discovery
+		require.ErrorContains(t, err, "passcode is required")
+	})
+
+	t.Run("exchanges the passcode once", func(t *testing.T) {
+		uaa := &fakeSSOUAA{t: t}
+		server := httptest.NewServer(uaa)
+		defer server.Close()
+		c, err := New("https://api.example.com",
+			Passcode("123456"),
+			AuthTokenURL(server.URL, server.URL),
+			LazyInit())
+		require.NoError(t, err)
+		require.Equal(t, GrantTypePasscode, c.grantType)
+
+		ts, err := c.CreateOAuth2TokenSource(context.Background())
+		require.NoError(t, err)
+		token, err := ts.Token()
+		require.NoError(t, err)
+		require.Equal(t, "access", token.AccessToken)
+
+		// re-authenticating refreshes the token instead of using the used passcode
+		ts, err = c.CreateOAuth2TokenSource(context.Background())
+		require.NoError(t, err)
+		_, err = ts.Token()
+		require.NoError(t, err)
+		require.Equal(t, []string{"password", "refresh_token"}, uaa.grants)
+		require.Equal(t, "123456", uaa.forms[0].Get("passcode"))
+		require.Equal(t, "refresh", uaa.forms[1].Get("refresh_token"))
+	})
+
+	t.Run("signs in even with a stored token", func(t *testing.T) {
+		uaa := &fakeSSOUAA{t: t}
+		server := httptest.NewServer(uaa)
+		defer server.Close()
+		store := NewMemoryTokenStore()
+		require.NoError(t, store.Save(&oauth2.Token{AccessToken: "other-user", RefreshToken: "other-user-refresh"}))
+		c, err := New("https://api.example.com",
+			Passcode("123456"),
+			StoreTokens(store),
+			AuthTokenURL(server.URL, server.URL),
+			LazyInit())
+		require.NoError(t, err)
+
+		ts, err := c.CreateOAuth2TokenSource(context.Background())
+		require.NoError(t, err)
+		token, err := ts.Token()
+		require.NoError(t, err)
+		require.Equal(t, "access", token.AccessToken)
+		require.Equal(t, []string{"password"}, uaa.grants)
+		require.Equal(t, "refresh", c.currentOAuthToken().RefreshToken)
+	})
+
+	t.Run("with invalid passcode", func(t *testing.T) {
+		server := httptest.NewServer(&fakeSSOUAA{t: t})
+		defer server.Close()
+		_, err := New("https://api.example.com",
+			Passcode("654321"),
+			AuthTokenURL(server.URL, server.URL))
+		require.ErrorContains(t, err, "error exchanging the passcode for a token")
+	})
+}
+
+func TestAuthorizationCodePKCE(t *testing.T) {
+	// login simulates the user signing in and the UAA redirecting the browser back with the query params
+	login := func(t *testing.T, params url.Values) func(string) error {
+		return func(authURL string) error {
+			u, err := url.Parse(authURL)
+			require.NoError(t, err)
+			q := u.Query()
+			require.Equal(t, "/oauth/authorize", u.Path)
+			require.Equal(t, "code", q.Get("response_type"))
+			require.Equal(t, "S256", q.Get("code_challenge_method"))
+			require.NotEmpty(t, q.Get("code_challenge"))
+			if params.Get("state") == "" {
+				params.Set("state", q.Get("state"))
+			}
+
+			go func() {
+				resp, err := http.Get(q.Get("redirect_uri") + "?" + params.Encode())
+				if err == nil {
+					_ = resp.Body.Close()
+				}
+			}()
+			return nil
+		}
+	}
+
+	t.Run("with invalid options", func(t *testing.T) {
+		_, err := New("https://api.example.com",
+			AuthorizationCodePKCE("", nil),
+			AuthTokenURL("https://login.cf.example.com", "https://token.cf.example.com")) // skip service discovery
+		require.ErrorContains(t, err, "a func to open the login URL is required")
+
+		_, err = New("https://api.example.com",
+			AuthorizationCodePKCE("0.0.0.0:8080", func(string) error { return nil }),
+			AuthTokenURL("https://login.cf.example.com", "https://token.cf.example.com")) // skip service discovery
+		require.ErrorContains(t, err, "must be a loopback address")
+	})
+
+	t.Run("exchanges the authorization code", func(t *testing.T) {
+		uaa := &fakeSSOUAA{t: t}
+		server := httptest.NewServer(uaa)
+		defer server.Close()
+		c, err := New("https://api.example.com",
+			AuthorizationCodePKCE("", login(t, url.Values{"code": {"the-code"}})),
+			AuthTokenURL(server.URL, server.URL),
+			LazyInit())
+		require.NoError(t, err)
+		require.Equal(t, GrantTypeAuthorizationCode, c.grantType)
+
+		ts, err := c.CreateOAuth2TokenSource(context.Background())
+		require.NoError(t, err)
+		token, err := ts.Token()
+		require.NoError(t, err)
+		require.Equal(t, "access", token.AccessToken)
+		require.Equal(t, []string{"authorization_code"}, uaa.grants)
+		require.NotEmpty(t, uaa.forms[0].Get("code_verifier"))
+		require.Regexp(t, `^http://127\.0\.0\.1:\d+/callback$`, uaa.forms[0].Get("redirect_uri"))
+	})
+
+	t.Run("with login error", func(t *testing.T) {
+		server := httptest.NewServer(&fakeSSOUAA{t: t})
+		defer server.Close()
+		_, err := New("https://api.example.com",
+			AuthorizationCodePKCE("localhost:0", login(t, url.Values{"error": {"access_denied"}})),
+			AuthTokenURL(server.URL, server.URL))
+		require.ErrorContains(t, err, "the login failed: access_denied")
+	})
+
+	t.Run("ignores a redirect with the wrong state", func(t *testing.T) {
+		server := httptest.NewServer(&fakeSSOUAA{t: t})
+		defer server.Close()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		_, err := NewWithContext(ctx, "https://api.example.com",
+			AuthorizationCodePKCE("", func(authURL string) error {
+				defer cancel()
+				u, err := url.Parse(authURL)
+				require.NoError(t, err)
+				resp, err := http.Get(u.Query().Get("redirect_uri") + "?code=the-code&state=forged")
+				require.NoError(t, err)
+				_ = resp.Body.Close()
+				require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+				return nil
+			}),
+			AuthTokenURL(server.URL, server.URL))
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
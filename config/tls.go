@@ -0,0 +1,135 @@
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// clientCertificate is a client certificate and key presented to the CF API and UAA. A certificate loaded from
+// files is reloaded when either file changes, so a rotated certificate is used for new connections.
+type clientCertificate struct {
+	certFile string
+	keyFile  string
+
+	mu      sync.Mutex
+	cert    *tls.Certificate
+	modTime time.Time
+}
+
+// loadClientCertificate loads the client certificate and key from the PEM files
+func loadClientCertificate(certFile, keyFile string) (*clientCertificate, error) {
+	c := &clientCertificate{certFile: certFile, keyFile: keyFile}
+	modTime, err := c.filesModTime()
+	if err != nil {
+		return nil, err
+	}
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("error loading the client certificate %s: %w", certFile, err)
+	}
+	c.cert, c.modTime = &cert, modTime
+	return c, nil
+}
+
+// certificate returns the client certificate, reloading it first if the files changed. If the files can't be
+// loaded, i.e. because they're being rotated and the new key was written before the new certificate, the current
+// certificate is used and loading is retried by the next connection.
+func (c *clientCertificate) certificate() *tls.Certificate {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.certFile == "" {
+		return c.cert
+	}
+	modTime, err := c.filesModTime()
+	if err != nil || !modTime.After(c.modTime) {
+		return c.cert
+	}
+	if cert, err := tls.LoadX509KeyPair(c.certFile, c.keyFile); err == nil {
+		c.cert, c.modTime = &cert, modTime
+	}
+	return c.cert
+}
+
+// filesModTime returns when the certificate or key file last changed
+func (c *clientCertificate) filesModTime() (time.Time, error) {
+	var modTime time.Time
+	for _, file := range []string{c.certFile, c.keyFile} {
+		fi, err := os.Stat(file)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("error loading the client certificate: %w", err)
+		}
+		if fi.ModTime().After(modTime) {
+			modTime = fi.ModTime()
+		}
+	}
+	return modTime, nil
+}
+
+// getClientCertificate returns the first client certificate the server accepts, or the first certificate if
+// the server doesn't accept any of them so the server can report the error
+func getClientCertificate(certs []*clientCertificate) func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	return func(cri *tls.CertificateRequestInfo) (*tls.Certificate, error) {
+		var first *tls.Certificate
+		for _, c := range certs {
+			cert := c.certificate()
+			if first == nil {
+				first = cert
+			}
+			if cri.SupportsCertificate(cert) == nil {
+				return cert, nil
+			}
+		}
+		return first, nil
+	}
+}
+
+// parseCACertificates parses the PEM encoded CA certificates, failing if there are none
+func parseCACertificates(pemCerts []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, pemCerts = pem.Decode(pemCerts)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CA certificate: %w", err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("no PEM encoded CA certificates found")
+	}
+	return certs, nil
+}
+
+// configureTLS trusts the CA certificates in addition to the transport's or the system's root CAs, and presents
+// the client certificates when the server asks for one
+func configureTLS(c *Config, tlsConfig *tls.Config) {
+	tlsConfig.InsecureSkipVerify = c.skipTLSValidation
+	if len(c.caCerts) > 0 {
+		pool := tlsConfig.RootCAs
+		if pool != nil {
+			pool = pool.Clone()
+		} else if pool, _ = x509.SystemCertPool(); pool == nil {
+			pool = x509.NewCertPool()
+		}
+		for _, cert := range c.caCerts {
+			pool.AddCert(cert)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if len(c.clientCerts) > 0 {
+		tlsConfig.GetClientCertificate = getClientCertificate(c.clientCerts)
+	}
+}
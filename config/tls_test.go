@@ -0,0 +1,216 @@
+package config
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testCA issues the server and client certificates of a test
+type testCA struct {
+	t      *testing.T
+	cert   *x509.Certificate
+	key    *ecdsa.PrivateKey
+	pem    []byte
+	serial int64
+}
+
+func newTestCA(t *testing.T) *testCA {
+	ca := &testCA{t: t}
+	ca.cert, ca.key, ca.pem, _ = ca.issue(&x509.Certificate{
+		Subject:               pkix.Name{CommonName: "test CA"},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	})
+	return ca
+}
+
+// issue signs the certificate template with the CA, or self-signs it if the CA hasn't been created yet
+func (ca *testCA) issue(template *x509.Certificate) (*x509.Certificate, *ecdsa.PrivateKey, []byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(ca.t, err)
+	ca.serial++
+	template.SerialNumber = big.NewInt(ca.serial)
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+	parent, parentKey := template, key
+	if ca.cert != nil {
+		parent, parentKey = ca.cert, ca.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	require.NoError(ca.t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(ca.t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(ca.t, err)
+	return cert, key,
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func (ca *testCA) serverCert() tls.Certificate {
+	_, _, certPEM, keyPEM := ca.issue(&x509.Certificate{
+		Subject:     pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses: []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(ca.t, err)
+	return cert
+}
+
+func (ca *testCA) clientCert(name string) ([]byte, []byte) {
+	_, _, certPEM, keyPEM := ca.issue(&x509.Certificate{
+		Subject:     pkix.Name{CommonName: name},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	return certPEM, keyPEM
+}
+
+// newMTLSServer starts a server that requires a client certificate from the CA and responds with its common name
+func newMTLSServer(t *testing.T, ca *testCA) *httptest.Server {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+	}))
+	server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{ca.serverCert()},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    pool,
+	}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	return server
+}
+
+func getCommonName(t *testing.T, c *Config, url string) string {
+	resp, err := c.HTTPClient().Get(url)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	b, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(b)
+}
+
+func TestCACertificates(t *testing.T) {
+	ca := newTestCA(t)
+	server := newMTLSServer(t, ca)
+	certPEM, keyPEM := ca.clientCert("client")
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, ca.pem, 0o600))
+
+	t.Run("from file", func(t *testing.T) {
+		c, err := New(server.URL,
+			Token(accessToken, refreshToken),
+			CACertificates(caFile),
+			ClientCertificatePEM(certPEM, keyPEM),
+			AuthTokenURL("https://login.cf.example.com", "https://token.cf.example.com")) // skip service discovery
+		require.NoError(t, err)
+		require.Equal(t, "client", getCommonName(t, c, server.URL))
+	})
+
+	t.Run("without CA", func(t *testing.T) {
+		c, err := New(server.URL,
+			Token(accessToken, refreshToken),
+			ClientCertificatePEM(certPEM, keyPEM),
+			AuthTokenURL("https://login.cf.example.com", "https://token.cf.example.com")) // skip service discovery
+		require.NoError(t, err)
+		_, err = c.HTTPClient().Get(server.URL)
+		require.ErrorContains(t, err, "certificate signed by unknown authority")
+	})
+
+	t.Run("doesn't change the supplied transport", func(t *testing.T) {
+		tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+		transport := &http.Transport{TLSClientConfig: tlsConfig}
+		c, err := New(server.URL,
+			Token(accessToken, refreshToken),
+			HttpClient(&http.Client{Transport: transport}),
+			CACertificates(caFile),
+			ClientCertificatePEM(certPEM, keyPEM),
+			AuthTokenURL("https://login.cf.example.com", "https://token.cf.example.com")) // skip service discovery
+		require.NoError(t, err)
+		require.Equal(t, "client", getCommonName(t, c, server.URL))
+		require.Nil(t, tlsConfig.RootCAs)
+		require.Nil(t, tlsConfig.GetClientCertificate)
+	})
+
+	t.Run("with a transport that can't be configured", func(t *testing.T) {
+		_, err := New(server.URL,
+			Token(accessToken, refreshToken),
+			HttpClient(&http.Client{Transport: RoundTripperFunc(http.DefaultTransport.RoundTrip)}),
+			CACertificates(caFile),
+			AuthTokenURL("https://login.cf.example.com", "https://token.cf.example.com")) // skip service discovery
+		require.ErrorContains(t, err, "the CA and client certificate options require an *http.Transport")
+	})
+
+	t.Run("with invalid PEM", func(t *testing.T) {
+		_, err := New(server.URL,
+			Token(accessToken, refreshToken),
+			CACertificatesPEM([]byte("not a certificate")),
+			AuthTokenURL("https://login.cf.example.com", "https://token.cf.example.com")) // skip service discovery
+		require.ErrorContains(t, err, "no PEM encoded CA certificates found")
+
+		_, err = New(server.URL,
+			Token(accessToken, refreshToken),
+			CACertificates(filepath.Join(t.TempDir(), "missing.pem")),
+			AuthTokenURL("https://login.cf.example.com", "https://token.cf.example.com")) // skip service discovery
+		require.ErrorContains(t, err, "error reading the CA certificates")
+	})
+}
+
+func TestClientCertificate(t *testing.T) {
+	ca := newTestCA(t)
+	server := newMTLSServer(t, ca)
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	writeCert := func(name string, modTime time.Time) {
+		certPEM, keyPEM := ca.clientCert(name)
+		require.NoError(t, os.WriteFile(certFile, certPEM, 0o600))
+		require.NoError(t, os.WriteFile(keyFile, keyPEM, 0o600))
+		require.NoError(t, os.Chtimes(certFile, modTime, modTime))
+		require.NoError(t, os.Chtimes(keyFile, modTime, modTime))
+	}
+	writeCert("client-1", time.Now().Add(-time.Minute))
+
+	c, err := New(server.URL,
+		Token(accessToken, refreshToken),
+		CACertificatesPEM(ca.pem),
+		ClientCertificate(certFile, keyFile),
+		AuthTokenURL("https://login.cf.example.com", "https://token.cf.example.com")) // skip service discovery
+	require.NoError(t, err)
+	require.Equal(t, "client-1", getCommonName(t, c, server.URL))
+
+	// a rotated certificate is used for new connections
+	writeCert("client-2", time.Now())
+	c.HTTPClient().CloseIdleConnections()
+	require.Equal(t, "client-2", getCommonName(t, c, server.URL))
+
+	// a half written rotation keeps using the current certificate
+	require.NoError(t, os.WriteFile(keyFile, []byte("partial"), 0o600))
+	require.NoError(t, os.Chtimes(keyFile, time.Now().Add(time.Minute), time.Now().Add(time.Minute)))
+	c.HTTPClient().CloseIdleConnections()
+	require.Equal(t, "client-2", getCommonName(t, c, server.URL))
+
+	_, err = New(server.URL,
+		Token(accessToken, refreshToken),
+		ClientCertificate(filepath.Join(dir, "missing.pem"), keyFile),
+		AuthTokenURL("https://login.cf.example.com", "https://token.cf.example.com")) // skip service discovery
+	require.ErrorContains(t, err, "error loading the client certificate")
+}
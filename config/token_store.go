@@ -0,0 +1,223 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"golang.org/x/oauth2"
+
+	"github.com/cloudfoundry/go-cfclient/v3/internal/jwt"
+)
+
+// TokenStore persists the OAuth2 token so a refreshed token, which may include a rotated refresh token,
+// survives a restart
+type TokenStore interface {
+	// Load returns the stored token, or nil if there isn't one
+	Load() (*oauth2.Token, error)
+
+	// Save stores the token, replacing any stored token
+	Save(token *oauth2.Token) error
+}
+
+// TokenRefreshFunc is called with each new token the client obtains
+type TokenRefreshFunc func(token *oauth2.Token)
+
+// MemoryTokenStore keeps the token in memory, i.e. to share a token between configs
+type MemoryTokenStore struct {
+	mu    sync.Mutex
+	token *oauth2.Token
+}
+
+// NewMemoryTokenStore creates an empty in memory token store
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{}
+}
+
+// Load returns a copy of the stored token
+func (s *MemoryTokenStore) Load() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token == nil {
+		return nil, nil
+	}
+	token := *s.token
+	return &token, nil
+}
+
+// Save stores a copy of the token
+func (s *MemoryTokenStore) Save(token *oauth2.Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t := *token
+	s.token = &t
+	return nil
+}
+
+// FileTokenStore keeps the token in a JSON file that only the current user can read
+type FileTokenStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileTokenStore creates a token store backed by the file at the path, which is created on the first save
+func NewFileTokenStore(path string) *FileTokenStore {
+	return &FileTokenStore{path: path}
+}
+
+// Load reads the token from the file, returning nil if the file doesn't exist
+func (s *FileTokenStore) Load() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the token from %s: %w", s.path, err)
+	}
+	var token oauth2.Token
+	if err = json.Unmarshal(b, &token); err != nil {
+		return nil, fmt.Errorf("failed to decode the token in %s: %w", s.path, err)
+	}
+	return &token, nil
+}
+
+// Save atomically replaces the file with the token
+func (s *FileTokenStore) Save(token *oauth2.Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, err := json.Marshal(token)
+	if err != nil {
+		return fmt.Errorf("failed to encode the token: %w", err)
+	}
+	return writeFileAtomic(s.path, b)
+}
+
+// CFCLITokenStore reads and writes back the token in the CF CLI's config.json, so the CLI and the client keep
+// using the latest refresh token
+type CFCLITokenStore struct {
+	mu         sync.Mutex
+	configFile string
+}
+
+// NewCFCLITokenStore creates a token store backed by the CF CLI config in the CF home directory, use the same
+// directory as NewFromCFHomeDir
+func NewCFCLITokenStore(cfHomeDir string) *CFCLITokenStore {
+	return &CFCLITokenStore{configFile: filepath.Join(cfHomeDir, ".cf", "config.json")}
+}
+
+// Load returns the token in the CF CLI config, or nil if the CLI isn't logged in
+func (s *CFCLITokenStore) Load() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cf, err := loadCFCLIConfig(filepath.Dir(filepath.Dir(s.configFile)))
+	if err != nil {
+		return nil, err
+	}
+	if cf.AccessToken == "" && cf.RefreshToken == "" {
+		return nil, nil
+	}
+	return jwt.ToOAuth2Token(cf.AccessToken, cf.RefreshToken)
+}
+
+// Save updates the access and refresh tokens in the CF CLI config, leaving the rest of the config as is
+func (s *CFCLITokenStore) Save(token *oauth2.Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, err := os.ReadFile(s.configFile)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", s.configFile, err)
+	}
+	var cf map[string]any
+	if err = json.Unmarshal(b, &cf); err != nil {
+		return fmt.Errorf("error while unmarshalling CF CLI config: %w", err)
+	}
+	cf["AccessToken"] = "bearer " + token.AccessToken
+	if token.RefreshToken != "" {
+		cf["RefreshToken"] = token.RefreshToken
+	}
+	b, err = json.MarshalIndent(cf, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error while marshalling CF CLI config: %w", err)
+	}
+	return writeFileAtomic(s.configFile, b)
+}
+
+// writeFileAtomic replaces the file by renaming a temp file over it, so a crash never leaves a partial file.
+// The file is only readable by the current user.
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create the directory for %s: %w", path, err)
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+	if _, err = f.Write(data); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err = f.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err = os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// notifyingTokenSource saves each new token from the token source and passes it to the refresh callbacks
+type notifyingTokenSource struct {
+	config *Config
+	source oauth2.TokenSource
+
+	mu      sync.Mutex
+	last    *oauth2.Token
+	unsaved bool
+}
+
+func (s *notifyingTokenSource) Token() (*oauth2.Token, error) {
+	token, err := s.source.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	// the token store and callbacks are called without holding the lock, so a slow save or callback doesn't block
+	// concurrent requests that already have a valid token
+	s.mu.Lock()
+	isNew := !sameToken(s.last, token)
+	if isNew {
+		s.last = token
+		s.config.setOAuthToken(token)
+	}
+	// retry saving a token that failed to save, the request doesn't fail because the token is valid
+	save := (isNew || s.unsaved) && s.config.tokenStore != nil
+	s.unsaved = false
+	s.mu.Unlock()
+
+	if save {
+		err := s.config.tokenStore.Save(token)
+		s.mu.Lock()
+		// save the latest token again with the next request if this one failed to save or overwrote a newer one
+		if err != nil || !sameToken(s.last, token) {
+			s.unsaved = true
+		}
+		s.mu.Unlock()
+	}
+	if isNew {
+		for _, fn := range s.config.onTokenRefresh {
+			fn(token)
+		}
+	}
+	return token, nil
+}
+
+// sameToken returns true if both tokens have the same access and refresh token
+func sameToken(a, b *oauth2.Token) bool {
+	return a != nil && b != nil && a.AccessToken == b.AccessToken && a.RefreshToken == b.RefreshToken
+}
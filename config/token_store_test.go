@@ -0,0 +1,241 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"github.com/cloudfoundry/go-cfclient/v3/testutil"
+)
+
+type failingTokenStore struct {
+	MemoryTokenStore
+	failures int
+}
+
+func (s *failingTokenStore) Save(token *oauth2.Token) error {
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("disk full")
+	}
+	return s.MemoryTokenStore.Save(token)
+}
+
+func TestMemoryTokenStore(t *testing.T) {
+	store := NewMemoryTokenStore()
+	token, err := store.Load()
+	require.NoError(t, err)
+	require.Nil(t, token)
+
+	require.NoError(t, store.Save(&oauth2.Token{AccessToken: "access", RefreshToken: "refresh"}))
+	token, err = store.Load()
+	require.NoError(t, err)
+	require.Equal(t, "access", token.AccessToken)
+	require.Equal(t, "refresh", token.RefreshToken)
+}
+
+func TestFileTokenStore(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "tokens", "token.json")
+	store := NewFileTokenStore(tokenFile)
+	token, err := store.Load()
+	require.NoError(t, err)
+	require.Nil(t, token)
+
+	require.NoError(t, store.Save(&oauth2.Token{AccessToken: "access", RefreshToken: "refresh", TokenType: "bearer"}))
+	fi, err := os.Stat(tokenFile)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o600), fi.Mode().Perm())
+
+	token, err = NewFileTokenStore(tokenFile).Load()
+	require.NoError(t, err)
+	require.Equal(t, "access", token.AccessToken)
+	require.Equal(t, "refresh", token.RefreshToken)
+
+	require.NoError(t, os.WriteFile(tokenFile, []byte("{"), 0o600))
+	_, err = store.Load()
+	require.ErrorContains(t, err, "failed to decode the token")
+}
+
+func TestCFCLITokenStore(t *testing.T) {
+	cfHomeDir := writeTestCFCLIConfig(t)
+	defer func() { _ = os.RemoveAll(cfHomeDir) }()
+	store := NewCFCLITokenStore(cfHomeDir)
+
+	token, err := store.Load()
+	require.NoError(t, err)
+	require.Equal(t, accessToken, token.AccessToken)
+	require.Equal(t, refreshToken, token.RefreshToken)
+
+	require.NoError(t, store.Save(&oauth2.Token{AccessToken: "new-access", RefreshToken: "new-refresh"}))
+	b, err := os.ReadFile(filepath.Join(cfHomeDir, ".cf", "config.json"))
+	require.NoError(t, err)
+	var cf map[string]any
+	require.NoError(t, json.Unmarshal(b, &cf))
+	require.Equal(t, "bearer new-access", cf["AccessToken"])
+	require.Equal(t, "new-refresh", cf["RefreshToken"])
+	require.Equal(t, "https://api.sys.example.com", cf["Target"])
+	require.Equal(t, "ssh-proxy", cf["SSHOAuthClient"])
+
+	// the refresh token is kept when the UAA doesn't rotate it
+	require.NoError(t, store.Save(&oauth2.Token{AccessToken: "newer-access"}))
+	cfg, err := loadCFCLIConfig(cfHomeDir)
+	require.NoError(t, err)
+	require.Equal(t, "bearer newer-access", cfg.AccessToken)
+	require.Equal(t, "new-refresh", cfg.RefreshToken)
+}
+
+func TestStoreTokens(t *testing.T) {
+	t.Run("with nil store", func(t *testing.T) {
+		_, err := New("https://api.example.com",
+			Token(accessToken, refreshToken),
+			StoreTokens(nil),
+			AuthTokenURL("https://login.cf.example.com", "https://token.cf.example.com")) // skip service discovery
+		require.ErrorContains(t, err, "the token store can't be nil")
+	})
+
+	t.Run("stored token replaces the configured token", func(t *testing.T) {
+		store := NewMemoryTokenStore()
+		require.NoError(t, store.Save(&oauth2.Token{AccessToken: accessToken, RefreshToken: "stored-refresh-token"}))
+		c, err := New("https://api.example.com",
+			Token(accessToken, refreshToken),
+			StoreTokens(store),
+			AuthTokenURL("https://login.cf.example.com", "https://token.cf.example.com")) // skip service discovery
+		require.NoError(t, err)
+		require.Equal(t, "stored-refresh-token", c.oAuthToken.RefreshToken)
+	})
+
+	t.Run("re-authenticating refreshes the rejected token", func(t *testing.T) {
+		uaaURL := testutil.SetupFakeUAAServer(300)
+		defer testutil.Teardown()
+		store := NewMemoryTokenStore()
+		require.NoError(t, store.Save(&oauth2.Token{
+			AccessToken:  "rejected",
+			RefreshToken: "stored-refresh-token",
+			Expiry:       time.Now().Add(time.Hour),
+		}))
+		c, err := New("https://api.example.com",
+			Token(accessToken, refreshToken),
+			StoreTokens(store),
+			AuthTokenURL(uaaURL, uaaURL),
+			LazyInit())
+		require.NoError(t, err)
+
+		ts, err := c.CreateOAuth2TokenSource(context.Background())
+		require.NoError(t, err)
+		token, err := ts.Token()
+		require.NoError(t, err)
+		require.Equal(t, "rejected", token.AccessToken)
+
+		// the token source created after a 401 doesn't reuse the access token that hasn't expired yet
+		ts, err = c.CreateOAuth2TokenSource(context.Background())
+		require.NoError(t, err)
+		token, err = ts.Token()
+		require.NoError(t, err)
+		require.Equal(t, "foobar1", token.AccessToken)
+	})
+
+	t.Run("saves the refreshed token", func(t *testing.T) {
+		uaaURL := testutil.SetupFakeUAAServer(300)
+		defer testutil.Teardown()
+		store := NewMemoryTokenStore()
+		c, err := New("https://api.example.com",
+			Token(accessToken, refreshToken),
+			StoreTokens(store),
+			AuthTokenURL(uaaURL, uaaURL))
+		require.NoError(t, err)
+
+		// the configured access token is expired, so it's refreshed
+		ts, err := c.CreateOAuth2TokenSource(context.Background())
+		require.NoError(t, err)
+		token, err := ts.Token()
+		require.NoError(t, err)
+		stored, err := store.Load()
+		require.NoError(t, err)
+		require.Equal(t, token.AccessToken, stored.AccessToken)
+		require.Equal(t, "barfoo", stored.RefreshToken)
+		require.Equal(t, "barfoo", c.currentOAuthToken().RefreshToken)
+	})
+
+	t.Run("retries a failed save", func(t *testing.T) {
+		uaaURL := testutil.SetupFakeUAAServer(300)
+		defer testutil.Teardown()
+		store := &failingTokenStore{failures: 1}
+		c, err := New("https://api.example.com",
+			ClientCredentials("cf", "secret"),
+			StoreTokens(store),
+			AuthTokenURL(uaaURL, uaaURL))
+		require.NoError(t, err)
+
+		ts, err := c.CreateOAuth2TokenSource(context.Background())
+		require.NoError(t, err)
+		_, err = ts.Token()
+		require.NoError(t, err)
+		stored, err := store.Load()
+		require.NoError(t, err)
+		require.Nil(t, stored)
+
+		token, err := ts.Token()
+		require.NoError(t, err)
+		stored, err = store.Load()
+		require.NoError(t, err)
+		require.Equal(t, token.AccessToken, stored.AccessToken)
+	})
+}
+
+func TestOnTokenRefresh(t *testing.T) {
+	uaaURL := testutil.SetupFakeUAAServer(300)
+	defer testutil.Teardown()
+	var refreshed []string
+	c, err := New("https://api.example.com",
+		ClientCredentials("cf", "secret"),
+		OnTokenRefresh(func(token *oauth2.Token) {
+			refreshed = append(refreshed, token.AccessToken)
+		}),
+		AuthTokenURL(uaaURL, uaaURL))
+	require.NoError(t, err)
+
+	ts, err := c.CreateOAuth2TokenSource(context.Background())
+	require.NoError(t, err)
+	for range 3 {
+		token, err := ts.Token()
+		require.NoError(t, err)
+		require.Equal(t, "foobar1", token.AccessToken)
+	}
+	require.Equal(t, []string{"foobar1"}, refreshed)
+
+	t.Run("with nil func", func(t *testing.T) {
+		_, err := New("https://api.example.com",
+			ClientCredentials("cf", "secret"),
+			OnTokenRefresh(nil),
+			AuthTokenURL(uaaURL, uaaURL))
+		require.ErrorContains(t, err, "the token refresh func can't be nil")
+	})
+
+	t.Run("callback can use the token source", func(t *testing.T) {
+		var ts oauth2.TokenSource
+		var inner []string
+		c, err := New("https://api.example.com",
+			ClientCredentials("cf", "secret"),
+			OnTokenRefresh(func(*oauth2.Token) {
+				// the callback isn't called while holding the token source's lock
+				token, err := ts.Token()
+				require.NoError(t, err)
+				inner = append(inner, token.AccessToken)
+			}),
+			AuthTokenURL(uaaURL, uaaURL),
+			LazyInit())
+		require.NoError(t, err)
+		ts, err = c.CreateOAuth2TokenSource(context.Background())
+		require.NoError(t, err)
+		token, err := ts.Token()
+		require.NoError(t, err)
+		require.Equal(t, []string{token.AccessToken}, inner)
+	})
+}
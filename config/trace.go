@@ -0,0 +1,158 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	internal "github.com/cloudfoundry/go-cfclient/v3/internal/http"
+)
+
+// CFTraceEnvVar is the CF CLI env var that enables HTTP trace logging when using NewFromCFHome
+const CFTraceEnvVar = "CF_TRACE"
+
+// traceFiles are the CF_TRACE files opened by this process, which are shared by every config and kept open like
+// stderr so creating configs doesn't leak file handles
+var (
+	traceFilesMu sync.Mutex
+	traceFiles   = map[string]*os.File{}
+)
+
+// httpTrace logs every request sent to the CF API and UAA
+type httpTrace struct {
+	logger    *slog.Logger
+	logBodies bool
+}
+
+// middleware returns a Middleware that logs each request and response at debug level with any secrets
+// redacted
+func (t *httpTrace) middleware() Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			ctx := req.Context()
+			if !t.logger.Enabled(ctx, slog.LevelDebug) {
+				return next.RoundTrip(req)
+			}
+
+			attrs := []slog.Attr{
+				slog.String("method", req.Method),
+				slog.String("url", req.URL.Redacted()),
+			}
+			if t.logBodies {
+				attrs = append(attrs, slog.Any("request_headers", internal.RedactHeaders(req.Header)))
+				if body := t.requestBody(req); body != "" {
+					attrs = append(attrs, slog.String("request_body", body))
+				}
+			}
+
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+			attrs = append(attrs, slog.Duration("duration", time.Since(start)))
+			if err != nil {
+				attrs = append(attrs, slog.String("error", err.Error()))
+				t.logger.LogAttrs(ctx, slog.LevelDebug, "cf http request failed", attrs...)
+				return nil, err
+			}
+
+			attrs = append(attrs, slog.Int("status", resp.StatusCode))
+			if requestID := resp.Header.Get("X-Vcap-Request-Id"); requestID != "" {
+				attrs = append(attrs, slog.String("request_id", requestID))
+			}
+			if t.logBodies {
+				attrs = append(attrs, slog.Any("response_headers", internal.RedactHeaders(resp.Header)))
+				if body := t.responseBody(req, resp); body != "" {
+					attrs = append(attrs, slog.String("response_body", body))
+				}
+			}
+			t.logger.LogAttrs(ctx, slog.LevelDebug, "cf http request", attrs...)
+			return resp, nil
+		})
+	}
+}
+
+// requestBody returns the redacted request body without consuming it
+func (t *httpTrace) requestBody(req *http.Request) string {
+	contentType := req.Header.Get("Content-Type")
+	if req.Body == nil || req.GetBody == nil || !internal.IsLoggableBody(contentType) {
+		return ""
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return ""
+	}
+	return internal.RedactBody(contentType, req.URL.Path, b)
+}
+
+// responseBody returns the redacted response body, replacing the body so it can still be read by the caller
+func (t *httpTrace) responseBody(req *http.Request, resp *http.Response) string {
+	contentType := resp.Header.Get("Content-Type")
+	if resp.Body == nil || !internal.IsLoggableBody(contentType) {
+		return ""
+	}
+	b, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		// hand the read error to the caller after whatever was read
+		resp.Body = io.NopCloser(io.MultiReader(bytes.NewReader(b), &errReader{err: err}))
+		return ""
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(b))
+	return internal.RedactBody(contentType, req.URL.Path, b)
+}
+
+// errReader always returns the error
+type errReader struct {
+	err error
+}
+
+func (r *errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
+
+// newTraceWriterLogger creates a logger that writes debug level text records to the writer
+func newTraceWriterLogger(w io.Writer) *slog.Logger {
+	return slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{Level: slog.LevelDebug}))
+}
+
+// traceFromEnv configures HTTP trace logging from the CF_TRACE env var like the CF CLI, where true logs
+// to stderr and any value other than false is the path of a file to append to
+func traceFromEnv() (*httpTrace, error) {
+	value := strings.TrimSpace(os.Getenv(CFTraceEnvVar))
+	switch strings.ToLower(value) {
+	case "", "false":
+		return nil, nil
+	case "true":
+		return &httpTrace{logger: newTraceWriterLogger(os.Stderr), logBodies: true}, nil
+	}
+	f, err := openTraceFile(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the %s file %s: %w", CFTraceEnvVar, value, err)
+	}
+	return &httpTrace{logger: newTraceWriterLogger(f), logBodies: true}, nil
+}
+
+// openTraceFile opens the trace file for appending the first time it's used and returns the same file after that
+func openTraceFile(path string) (*os.File, error) {
+	traceFilesMu.Lock()
+	defer traceFilesMu.Unlock()
+	if f, ok := traceFiles[path]; ok {
+		return f, nil
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+	traceFiles[path] = f
+	return f, nil
+}
@@ -0,0 +1,120 @@
+package config
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloudfoundry/go-cfclient/v3/testutil"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPTrace(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Vcap-Request-Id", "req-123")
+		_, _ = w.Write([]byte(`{"guid":"binding-guid","credentials":{"password":"db-password"}}`))
+	}))
+	defer api.Close()
+	uaaURL := testutil.SetupFakeUAAServer(300)
+	defer testutil.Teardown()
+
+	t.Run("logs requests with secrets redacted", func(t *testing.T) {
+		var buf bytes.Buffer
+		c, err := New(api.URL,
+			ClientCredentials("clientID", "clientSecret"),
+			AuthTokenURL(uaaURL, uaaURL),
+			HTTPTraceWriter(&buf, true))
+		require.NoError(t, err)
+
+		resp, err := c.HTTPAuthClient().Get(api.URL + "/v3/service_credential_bindings/binding-guid/details")
+		require.NoError(t, err)
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.Contains(t, string(body), "db-password", "the caller must still get the full body")
+
+		log := buf.String()
+		require.Contains(t, log, "method=GET")
+		require.Contains(t, log, "/v3/service_credential_bindings/binding-guid/details")
+		require.Contains(t, log, "status=200")
+		require.Contains(t, log, "request_id=req-123")
+		require.Contains(t, log, "duration=")
+		require.Contains(t, log, "/oauth/token")
+		require.NotContains(t, log, "clientSecret")
+		require.NotContains(t, log, "db-password")
+		require.NotContains(t, log, "foobar", "the access token must not be logged")
+		require.Contains(t, log, "PRIVATE DATA HIDDEN")
+	})
+
+	t.Run("omits bodies when not requested", func(t *testing.T) {
+		var buf bytes.Buffer
+		c, err := New(api.URL,
+			ClientCredentials("clientID", "clientSecret"),
+			AuthTokenURL(uaaURL, uaaURL),
+			HTTPTraceWriter(&buf, false))
+		require.NoError(t, err)
+
+		_, err = c.HTTPAuthClient().Get(api.URL + "/v3/service_credential_bindings/binding-guid/details")
+		require.NoError(t, err)
+		require.Contains(t, buf.String(), "status=200")
+		require.NotContains(t, buf.String(), "response_body")
+		require.NotContains(t, buf.String(), "Authorization")
+	})
+}
+
+func TestCFTraceEnv(t *testing.T) {
+	cfHomeDir := writeTestCFCLIConfig(t)
+
+	t.Run("writes to the file", func(t *testing.T) {
+		traceFile := filepath.Join(t.TempDir(), "trace.log")
+		t.Setenv(CFTraceEnvVar, traceFile)
+
+		cfg, err := NewFromCFHomeDir(cfHomeDir)
+		require.NoError(t, err)
+		require.NotNil(t, cfg.trace)
+		require.True(t, cfg.trace.logBodies)
+	})
+
+	t.Run("opens the file once", func(t *testing.T) {
+		traceFile := filepath.Join(t.TempDir(), "trace.log")
+		t.Setenv(CFTraceEnvVar, traceFile)
+
+		_, err := NewFromCFHomeDir(cfHomeDir)
+		require.NoError(t, err)
+		f, err := openTraceFile(traceFile)
+		require.NoError(t, err)
+		_, err = NewFromCFHomeDir(cfHomeDir)
+		require.NoError(t, err)
+		f2, err := openTraceFile(traceFile)
+		require.NoError(t, err)
+		require.Same(t, f, f2)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Setenv(CFTraceEnvVar, "false")
+
+		cfg, err := NewFromCFHomeDir(cfHomeDir)
+		require.NoError(t, err)
+		require.Nil(t, cfg.trace)
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		t.Setenv(CFTraceEnvVar, filepath.Join(t.TempDir(), "missing", "trace.log"))
+
+		_, err := NewFromCFHomeDir(cfHomeDir)
+		require.ErrorContains(t, err, "CF_TRACE")
+	})
+
+	t.Run("options override the env var", func(t *testing.T) {
+		t.Setenv(CFTraceEnvVar, "true")
+
+		var buf bytes.Buffer
+		cfg, err := NewFromCFHomeDir(cfHomeDir, HTTPTraceWriter(&buf, false))
+		require.NoError(t, err)
+		require.False(t, cfg.trace.logBodies)
+	})
+}
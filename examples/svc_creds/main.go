@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 
@@ -42,8 +43,14 @@ func execute() error {
 		}
 		fmt.Printf("%s\n", details.Credentials)
 		params, err := cf.ServiceCredentialBindings.GetParameters(ctx, b.GUID)
-		if resource.IsServiceFetchBindingParametersNotSupportedError(err) {
-			fmt.Println(err.(resource.CloudFoundryError).Detail)
+		var cfErrs resource.CloudFoundryErrors
+		if resource.IsServiceFetchBindingParametersNotSupportedError(err) && errors.As(err, &cfErrs) {
+			// the CF API may return several errors, print the detail of the one that matched
+			for _, cfErr := range cfErrs.Errors {
+				if resource.IsServiceFetchBindingParametersNotSupportedError(cfErr) {
+					fmt.Println(cfErr.Detail)
+				}
+			}
 		} else if err != nil {
 			return err
 		} else {
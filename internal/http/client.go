@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sync"
 
 	"golang.org/x/oauth2"
 
@@ -20,10 +21,25 @@ type OAuthTokenSourceCreator interface {
 }
 
 // retryableAuthTransport wraps a http.RoundTripper and combines it with an OAuthTokenSourceCreator so
-// that any 401s cause a re-authentication and request retry
+// that any 401s cause a re-authentication and request retry. It's safe for concurrent use, requests that
+// get a 401 at the same time share a single re-authentication.
 type retryableAuthTransport struct {
-	transport          http.RoundTripper
+	base               http.RoundTripper
 	tokenSourceCreator OAuthTokenSourceCreator
+
+	// source is the current token source, which is replaced by each re-authentication, and id is its generation
+	mu     sync.Mutex
+	source oauth2.TokenSource
+	id     uint64
+	reauth *reauthCall
+}
+
+// reauthCall is an in-flight re-authentication that concurrent requests wait for
+type reauthCall struct {
+	done   chan struct{}
+	source oauth2.TokenSource
+	id     uint64
+	err    error
 }
 
 // NewAuthenticatedClient creates a new http.Client with a retryableAuthTransport that supports re-authentication
@@ -35,11 +51,9 @@ func NewAuthenticatedClient(ctx context.Context, baseClient *http.Client, tokenS
 	}
 
 	transport := &retryableAuthTransport{
+		base:               baseClient.Transport,
 		tokenSourceCreator: tokenSourceCreator,
-		transport: &oauth2.Transport{
-			Base:   baseClient.Transport,
-			Source: src,
-		},
+		source:             src,
 	}
 
 	// oauth2.NewClient only copies the transport, so explicitly create our own http.Client
@@ -59,7 +73,8 @@ func (t *retryableAuthTransport) RoundTrip(req *http.Request) (*http.Response, e
 	}
 
 	// Send the request
-	resp, err := t.transport.RoundTrip(req)
+	src, id := t.tokenSource()
+	resp, err := t.roundTrip(req, src)
 	if err != nil {
 		var oauthErr *oauth2.RetrieveError
 		if !errors.As(err, &oauthErr) {
@@ -74,11 +89,10 @@ func (t *retryableAuthTransport) RoundTrip(req *http.Request) (*http.Response, e
 		drainBody(resp)
 
 		// Recreate the token source
-		src, tsErr := t.tokenSourceCreator.CreateOAuth2TokenSource(context.Background())
+		src, tsErr := t.reauthenticate(req.Context(), id)
 		if tsErr != nil {
 			return nil, fmt.Errorf("error re-authenticating with the OAuth2 token source: %w", tsErr)
 		}
-		t.transport.(*oauth2.Transport).Source = src
 
 		// Clone the request body again
 		if req.GetBody != nil {
@@ -88,13 +102,66 @@ func (t *retryableAuthTransport) RoundTrip(req *http.Request) (*http.Response, e
 		}
 
 		// Retry the request
-		resp, err = t.transport.RoundTrip(req)
+		resp, err = t.roundTrip(req, src)
 	}
 
 	// Return the response
 	return resp, err
 }
 
+// roundTrip sends the request authenticated with a token from the token source
+func (t *retryableAuthTransport) roundTrip(req *http.Request, src oauth2.TokenSource) (*http.Response, error) {
+	transport := &oauth2.Transport{
+		Base:   t.base,
+		Source: src,
+	}
+	return transport.RoundTrip(req)
+}
+
+// tokenSource returns the current token source and its generation
+func (t *retryableAuthTransport) tokenSource() (oauth2.TokenSource, uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.source, t.id
+}
+
+// reauthenticate replaces the token source of the given generation, which got a 401. If the token source was
+// already replaced since the request was sent, the new one is returned without re-authenticating again, and
+// concurrent callers wait for the same re-authentication.
+func (t *retryableAuthTransport) reauthenticate(ctx context.Context, id uint64) (oauth2.TokenSource, error) {
+	t.mu.Lock()
+	if t.id != id {
+		src := t.source
+		t.mu.Unlock()
+		return src, nil
+	}
+	call := t.reauth
+	if call == nil {
+		call = &reauthCall{done: make(chan struct{}), id: id + 1}
+		t.reauth = call
+		t.mu.Unlock()
+
+		// the re-authentication is shared, so it isn't canceled with the request that started it
+		call.source, call.err = t.tokenSourceCreator.CreateOAuth2TokenSource(context.Background())
+		t.mu.Lock()
+		if call.err == nil {
+			t.source, t.id = call.source, call.id
+		}
+		t.reauth = nil
+		t.mu.Unlock()
+		close(call.done)
+	} else {
+		t.mu.Unlock()
+	}
+
+	select {
+	case <-call.done:
+		return call.source, call.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
 func backupRequestBody(req *http.Request) error {
 	if req.Body != nil && req.GetBody == nil {
 		bodyBytes, err := io.ReadAll(req.Body)
@@ -3,14 +3,19 @@ package http_test
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"golang.org/x/oauth2"
 	gohttp "net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/cloudfoundry/go-cfclient/v3/internal/http"
 	"github.com/cloudfoundry/go-cfclient/v3/testutil"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -79,3 +84,50 @@ func TestOAuthSessionManager(t *testing.T) {
 
 	tokenSrcCreator.AssertNumberOfCalls(t, "CreateOAuth2TokenSource", 3)
 }
+
+type countingTokenSourceCreator struct {
+	calls atomic.Int32
+}
+
+func (tsc *countingTokenSourceCreator) CreateOAuth2TokenSource(context.Context) (oauth2.TokenSource, error) {
+	n := tsc.calls.Add(1)
+	// give the other requests time to get a 401 while the re-authentication is in flight
+	time.Sleep(10 * time.Millisecond)
+	return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: fmt.Sprintf("token-%d", n)}), nil
+}
+
+func TestOAuthSessionManagerConcurrentReauth(t *testing.T) {
+	var unauthorized atomic.Int32
+	server := httptest.NewServer(gohttp.HandlerFunc(func(w gohttp.ResponseWriter, r *gohttp.Request) {
+		if r.Header.Get("Authorization") == "Bearer token-1" {
+			unauthorized.Add(1)
+			w.WriteHeader(gohttp.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(gohttp.StatusOK)
+	}))
+	defer server.Close()
+
+	tokenSrcCreator := &countingTokenSourceCreator{}
+	client, err := http.NewAuthenticatedClient(context.Background(), gohttp.DefaultClient, tokenSrcCreator)
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Post(server.URL, "application/json", bytes.NewBufferString(`{}`))
+			if !assert.NoError(t, err) {
+				return
+			}
+			_ = resp.Body.Close()
+			assert.Equal(t, gohttp.StatusOK, resp.StatusCode)
+		}()
+	}
+	wg.Wait()
+
+	// all the 401s share the one re-authentication
+	require.Positive(t, unauthorized.Load())
+	require.Equal(t, int32(2), tokenSrcCreator.calls.Load())
+}
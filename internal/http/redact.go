@@ -0,0 +1,137 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// RedactedValue replaces any secret in logged requests and responses
+const RedactedValue = "[PRIVATE DATA HIDDEN]"
+
+// sensitiveHeaders are never logged in clear text
+var sensitiveHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+	// the SSH code redirect carries the one-time code in its query string
+	"Location",
+}
+
+// sensitiveKeys are JSON object keys and form fields whose values are redacted wherever they appear,
+// credentials covers service credential bindings, user-provided service instances and VCAP_SERVICES
+var sensitiveKeys = map[string]bool{
+	"access_token":     true,
+	"assertion":        true,
+	"client_assertion": true,
+	"client_secret":    true,
+	"code_verifier":    true,
+	"credentials":      true,
+	"id_token":         true,
+	"passcode":         true,
+	"password":         true,
+	"refresh_token":    true,
+	"token":            true,
+}
+
+// sensitiveFormKeys are only redacted in form encoded UAA requests, code is the authorization code grant's
+// one-time code but in CC JSON error payloads it's the error code, which is needed for debugging
+var sensitiveFormKeys = map[string]bool{
+	"code": true,
+}
+
+// sensitiveTextRegex matches the sensitive keys followed by their value, i.e. password=p or "token": "t", and
+// bearer tokens in plain text bodies
+var sensitiveTextRegex = regexp.MustCompile(
+	`(?i)(\b(?:access_token|assertion|client_assertion|client_secret|code_verifier|credentials|id_token|passcode|password|refresh_token|token)\b["']?\s*[:=]\s*["']?)[^"'&\s,;}]+` +
+		`|(\bbearer\s+)[A-Za-z0-9\-._~+/]+=*`)
+
+// credentialsPathRegex matches endpoints whose entire response body is a set of credentials
+var credentialsPathRegex = regexp.MustCompile(`/v3/service_instances/[^/]+/credentials$`)
+
+// RedactHeaders returns a copy of the headers with any credentials replaced
+func RedactHeaders(h http.Header) http.Header {
+	redacted := h.Clone()
+	for _, name := range sensitiveHeaders {
+		if redacted.Get(name) != "" {
+			redacted.Set(name, RedactedValue)
+		}
+	}
+	return redacted
+}
+
+// RedactBody returns a loggable version of a request or response body with any secrets replaced.
+//
+// JSON and form encoded bodies have the values of sensitive keys redacted at any depth, and text bodies have any
+// sensitive key=value pairs and bearer tokens redacted. Other content types aren't logged since they're typically
+// binary uploads or downloads.
+func RedactBody(contentType, urlPath string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case mediaType == "application/json":
+		if credentialsPathRegex.MatchString(urlPath) {
+			return RedactedValue
+		}
+		var v any
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.UseNumber()
+		if err := decoder.Decode(&v); err != nil {
+			return RedactedValue
+		}
+		b, err := json.Marshal(redactJSON(v))
+		if err != nil {
+			return RedactedValue
+		}
+		return string(b)
+	case mediaType == "application/x-www-form-urlencoded":
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return RedactedValue
+		}
+		for key := range values {
+			if sensitiveKeys[strings.ToLower(key)] || sensitiveFormKeys[strings.ToLower(key)] {
+				values.Set(key, RedactedValue)
+			}
+		}
+		return values.Encode()
+	case strings.HasPrefix(mediaType, "text/"):
+		return sensitiveTextRegex.ReplaceAllString(string(body), "${1}${2}"+RedactedValue)
+	default:
+		return "[" + mediaType + " body omitted]"
+	}
+}
+
+// IsLoggableBody returns true if the content type is one RedactBody can log, other bodies shouldn't be
+// buffered since they can be very large
+func IsLoggableBody(contentType string) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	return mediaType == "application/json" ||
+		mediaType == "application/x-www-form-urlencoded" ||
+		strings.HasPrefix(mediaType, "text/")
+}
+
+func redactJSON(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for key, value := range t {
+			if sensitiveKeys[strings.ToLower(key)] {
+				t[key] = RedactedValue
+			} else {
+				t[key] = redactJSON(value)
+			}
+		}
+	case []any:
+		for i, value := range t {
+			t[i] = redactJSON(value)
+		}
+	}
+	return v
+}
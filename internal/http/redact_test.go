@@ -0,0 +1,74 @@
+package http
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactHeaders(t *testing.T) {
+	h := http.Header{}
+	h.Set("Authorization", "bearer secret-token")
+	h.Set("Content-Type", "application/json")
+	h.Set("Location", "https://uaa.example.com/login?code=one-time")
+
+	redacted := RedactHeaders(h)
+	require.Equal(t, RedactedValue, redacted.Get("Authorization"))
+	require.Equal(t, RedactedValue, redacted.Get("Location"))
+	require.Equal(t, "application/json", redacted.Get("Content-Type"))
+	require.Equal(t, "bearer secret-token", h.Get("Authorization"))
+}
+
+func TestRedactBody(t *testing.T) {
+	t.Run("token response", func(t *testing.T) {
+		body := `{"access_token":"a","refresh_token":"r","token_type":"bearer","expires_in":599}`
+		require.JSONEq(t,
+			`{"access_token":"[PRIVATE DATA HIDDEN]","refresh_token":"[PRIVATE DATA HIDDEN]","token_type":"bearer","expires_in":599}`,
+			RedactBody("application/json;charset=utf-8", "/oauth/token", []byte(body)))
+	})
+
+	t.Run("token request", func(t *testing.T) {
+		body := "client_id=cf&client_secret=shh&grant_type=refresh_token&refresh_token=r"
+		require.Equal(t,
+			"client_id=cf&client_secret=%5BPRIVATE+DATA+HIDDEN%5D&grant_type=refresh_token&refresh_token=%5BPRIVATE+DATA+HIDDEN%5D",
+			RedactBody("application/x-www-form-urlencoded", "/oauth/token", []byte(body)))
+	})
+
+	t.Run("authorization code request", func(t *testing.T) {
+		body := "code=c&code_verifier=v&grant_type=authorization_code"
+		require.Equal(t,
+			"code=%5BPRIVATE+DATA+HIDDEN%5D&code_verifier=%5BPRIVATE+DATA+HIDDEN%5D&grant_type=authorization_code",
+			RedactBody("application/x-www-form-urlencoded", "/oauth/token", []byte(body)))
+	})
+
+	t.Run("CC error code", func(t *testing.T) {
+		body := `{"errors":[{"code":10008,"title":"CF-UnprocessableEntity","detail":"invalid"}]}`
+		require.JSONEq(t, body, RedactBody("application/json", "/v3/apps", []byte(body)))
+	})
+
+	t.Run("text body", func(t *testing.T) {
+		body := "login failed for password=p access_token: \"a\" Authorization: Bearer eyJ.abc-1"
+		require.Equal(t,
+			"login failed for password=[PRIVATE DATA HIDDEN] access_token: \"[PRIVATE DATA HIDDEN]\" Authorization: Bearer [PRIVATE DATA HIDDEN]",
+			RedactBody("text/plain", "/oauth/token", []byte(body)))
+	})
+
+	t.Run("nested credentials", func(t *testing.T) {
+		body := `{"name":"upsi","credentials":{"password":"p"},"system_env_json":{"VCAP_SERVICES":{"db":[{"credentials":{"uri":"u"}}]}}}`
+		require.JSONEq(t,
+			`{"name":"upsi","credentials":"[PRIVATE DATA HIDDEN]","system_env_json":{"VCAP_SERVICES":{"db":[{"credentials":"[PRIVATE DATA HIDDEN]"}]}}}`,
+			RedactBody("application/json", "/v3/apps/guid/env", []byte(body)))
+	})
+
+	t.Run("user-provided credentials endpoint", func(t *testing.T) {
+		require.Equal(t, RedactedValue,
+			RedactBody("application/json", "/v3/service_instances/guid/credentials", []byte(`{"uri":"u"}`)))
+	})
+
+	t.Run("binary bodies are omitted", func(t *testing.T) {
+		require.Equal(t, "[application/zip body omitted]", RedactBody("application/zip", "/v3/packages/guid/download", []byte("PK")))
+		require.False(t, IsLoggableBody("application/zip"))
+		require.True(t, IsLoggableBody("application/json; charset=utf-8"))
+	})
+}
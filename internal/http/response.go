@@ -64,6 +64,9 @@ func DecodeBody(resp *http.Response, result any) error {
 	return nil
 }
 
+// DecodeError returns all the CF API errors in the response body as resource.CloudFoundryErrors, or a
+// resource.CloudFoundryHTTPError if the body doesn't contain any. Either includes the details needed to
+// correlate the failed request with the CC logs.
 func DecodeError(resp *http.Response) error {
 	if resp == nil || resp.Body == nil {
 		return errors.New("response has empty or invalid body")
@@ -71,16 +74,32 @@ func DecodeError(resp *http.Response) error {
 
 	defer ios.Close(resp.Body)
 
+	var method, reqURL string
+	if resp.Request != nil {
+		method = resp.Request.Method
+		if resp.Request.URL != nil {
+			reqURL = resp.Request.URL.Redacted()
+		}
+	}
+	requestID := resp.Header.Get("X-Vcap-Request-Id")
+
 	body, err := io.ReadAll(resp.Body)
 	if err == nil {
 		var cfErrs resource.CloudFoundryErrors
 		if err = json.Unmarshal(body, &cfErrs); err == nil && len(cfErrs.Errors) > 0 {
-			return cfErrs.Errors[0]
+			cfErrs.StatusCode = resp.StatusCode
+			cfErrs.RequestMethod = method
+			cfErrs.RequestURL = reqURL
+			cfErrs.RequestID = requestID
+			return cfErrs
 		}
 	}
 	return resource.CloudFoundryHTTPError{
-		StatusCode: resp.StatusCode,
-		Status:     resp.Status,
-		Body:       body,
+		StatusCode:    resp.StatusCode,
+		Status:        resp.Status,
+		Body:          body,
+		RequestMethod: method,
+		RequestURL:    reqURL,
+		RequestID:     requestID,
 	}
 }
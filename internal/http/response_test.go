@@ -87,12 +87,59 @@ func TestResponse(t *testing.T) {
 
 		resp := &http.Response{Body: io.NopCloser(strings.NewReader(`{"errors":[{"detail":"Unknown request","title":"CF-NotFound","code":10000}]}`))}
 		err = DecodeError(resp)
-		require.IsType(t, resource.CloudFoundryError{}, err)
+		require.IsType(t, resource.CloudFoundryErrors{}, err)
 		require.EqualError(t, err, "cfclient error (CF-NotFound|10000): Unknown request")
+		var cfErr resource.CloudFoundryError
+		require.ErrorAs(t, err, &cfErr)
+		require.Equal(t, 10000, cfErr.Code)
 
 		resp = &http.Response{Body: io.NopCloser(strings.NewReader(`invalid request`)), StatusCode: 404, Status: "Not Found"}
 		err = DecodeError(resp)
 		require.IsType(t, resource.CloudFoundryHTTPError{}, err)
 		require.EqualError(t, err, "cfclient: HTTP error (404): Not Found")
 	})
+
+	t.Run("Test DecodeError with multiple errors", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPost, "https://api.example.org/v3/apps", nil)
+		resp := &http.Response{
+			StatusCode: http.StatusUnprocessableEntity,
+			Header:     http.Header{"X-Vcap-Request-Id": []string{"b8c2e5c1-request-id"}},
+			Request:    req,
+			Body: io.NopCloser(strings.NewReader(`{"errors":[
+				{"detail":"Name must be unique","title":"CF-UnprocessableEntity","code":10008},
+				{"detail":"Space not found","title":"CF-SpaceNotFound","code":40004}]}`)),
+		}
+		err := DecodeError(resp)
+		require.EqualError(t, err, "cfclient error (CF-UnprocessableEntity|10008): Name must be unique, "+
+			"cfclient error (CF-SpaceNotFound|40004): Space not found (request id: b8c2e5c1-request-id)")
+		require.True(t, resource.IsUnprocessableEntityError(err))
+		require.True(t, resource.IsSpaceNotFoundError(err))
+		require.False(t, resource.IsAppNotFoundError(err))
+		require.ErrorIs(t, err, resource.NewSpaceNotFoundError())
+
+		var cfErrs resource.CloudFoundryErrors
+		require.ErrorAs(t, err, &cfErrs)
+		require.Len(t, cfErrs.Errors, 2)
+		require.Equal(t, http.StatusUnprocessableEntity, cfErrs.StatusCode)
+		require.Equal(t, http.MethodPost, cfErrs.RequestMethod)
+		require.Equal(t, "https://api.example.org/v3/apps", cfErrs.RequestURL)
+		require.Equal(t, "b8c2e5c1-request-id", cfErrs.RequestID)
+	})
+
+	t.Run("Test DecodeError with non CF error body", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "https://api.example.org/v3/apps", nil)
+		resp := &http.Response{
+			StatusCode: http.StatusBadGateway,
+			Status:     "502 Bad Gateway",
+			Header:     http.Header{"X-Vcap-Request-Id": []string{"b8c2e5c1-request-id"}},
+			Request:    req,
+			Body:       io.NopCloser(strings.NewReader(`<html>bad gateway</html>`)),
+		}
+		err := DecodeError(resp)
+		require.EqualError(t, err, "cfclient: HTTP error (502): 502 Bad Gateway (request id: b8c2e5c1-request-id)")
+		var httpErr resource.CloudFoundryHTTPError
+		require.ErrorAs(t, err, &httpErr)
+		require.Equal(t, http.MethodGet, httpErr.RequestMethod)
+		require.Equal(t, "https://api.example.org/v3/apps", httpErr.RequestURL)
+	})
 }
@@ -0,0 +1,93 @@
+package http
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// RetryAfter returns the delay requested by the server via the Retry-After response header.
+//
+// The header may contain either a number of seconds or an HTTP date. Zero is returned if the header is
+// missing, invalid, or the date is in the past.
+func RetryAfter(resp *http.Response, now time.Time) time.Duration {
+	if resp == nil {
+		return 0
+	}
+	v := strings.TrimSpace(resp.Header.Get("Retry-After"))
+	if v == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(v); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+	return 0
+}
+
+// CanRewindBody returns true if the request has no body or the body can be recreated for another attempt.
+func CanRewindBody(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// RewindBody resets the request body so the request can be sent again.
+func RewindBody(req *http.Request) error {
+	if req.Body == nil || req.Body == http.NoBody || req.GetBody == nil {
+		return nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return err
+	}
+	req.Body = body
+	return nil
+}
+
+// DrainBody consumes and closes the response body so the underlying connection can be reused.
+func DrainBody(resp *http.Response) {
+	if resp != nil {
+		drainBody(resp)
+	}
+}
+
+// IsTransientError returns true if a request failed with a network error that may succeed when it's sent again,
+// i.e. a timeout, a refused or reset connection or a temporary DNS failure. TLS certificate errors, invalid URLs
+// and unknown hosts fail the same way every time.
+func IsTransientError(err error) bool {
+	var certErr *tls.CertificateVerificationError
+	var recordErr tls.RecordHeaderError
+	var unknownAuthorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var certInvalidErr x509.CertificateInvalidError
+	if errors.As(err, &certErr) || errors.As(err, &recordErr) || errors.As(err, &unknownAuthorityErr) ||
+		errors.As(err, &hostnameErr) || errors.As(err, &certInvalidErr) {
+		return false
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTimeout || dnsErr.IsTemporary
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	if errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr)
+}
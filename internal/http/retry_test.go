@@ -0,0 +1,84 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	require.Equal(t, time.Duration(0), RetryAfter(nil, now))
+	require.Equal(t, time.Duration(0), RetryAfter(&http.Response{Header: http.Header{}}, now))
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"5"}}}
+	require.Equal(t, 5*time.Second, RetryAfter(resp, now))
+
+	resp.Header.Set("Retry-After", now.Add(10*time.Second).Format(http.TimeFormat))
+	require.Equal(t, 10*time.Second, RetryAfter(resp, now))
+
+	resp.Header.Set("Retry-After", now.Add(-10*time.Second).Format(http.TimeFormat))
+	require.Equal(t, time.Duration(0), RetryAfter(resp, now))
+
+	resp.Header.Set("Retry-After", "soon")
+	require.Equal(t, time.Duration(0), RetryAfter(resp, now))
+}
+
+func TestIsTransientError(t *testing.T) {
+	urlErr := func(err error) error {
+		return &url.Error{Op: "Get", URL: "https://api.example.com/v3/apps", Err: err}
+	}
+	opErr := func(err error) error {
+		return &net.OpError{Op: "dial", Net: "tcp", Err: err}
+	}
+
+	transient := []error{
+		urlErr(opErr(os.NewSyscallError("connect", syscall.ECONNREFUSED))),
+		urlErr(opErr(os.NewSyscallError("read", syscall.ECONNRESET))),
+		urlErr(opErr(os.ErrDeadlineExceeded)),
+		urlErr(io.EOF),
+		urlErr(io.ErrUnexpectedEOF),
+		urlErr(&net.DNSError{Err: "server misbehaving", Name: "api.example.com", IsTemporary: true}),
+	}
+	for _, err := range transient {
+		require.True(t, IsTransientError(err), err.Error())
+	}
+
+	permanent := []error{
+		urlErr(x509.UnknownAuthorityError{}),
+		urlErr(x509.HostnameError{Certificate: &x509.Certificate{}, Host: "api.example.com"}),
+		urlErr(opErr(&net.DNSError{Err: "no such host", Name: "api.example.com", IsNotFound: true})),
+		urlErr(errors.New(`unsupported protocol scheme ""`)),
+	}
+	for _, err := range permanent {
+		require.False(t, IsTransientError(err), err.Error())
+	}
+}
+
+func TestRewindBody(t *testing.T) {
+	req, _ := http.NewRequestWithContext(context.Background(), "GET", "/v3/apps", nil)
+	require.True(t, CanRewindBody(req))
+	require.NoError(t, RewindBody(req))
+
+	req, _ = http.NewRequestWithContext(context.Background(), "PUT", "/v3/apps", bytes.NewBufferString("body"))
+	require.True(t, CanRewindBody(req))
+	_, _ = io.ReadAll(req.Body)
+	require.NoError(t, RewindBody(req))
+	b, _ := io.ReadAll(req.Body)
+	require.Equal(t, "body", string(b))
+
+	req, _ = http.NewRequestWithContext(context.Background(), "PUT", "/v3/apps", io.NopCloser(bytes.NewBufferString("body")))
+	require.False(t, CanRewindBody(req))
+}
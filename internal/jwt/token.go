@@ -167,9 +167,22 @@ func (s *JWTAssertionTokenSource) Token() (*oauth2.Token, error) {
 	if err != nil {
 		return nil, fmt.Errorf("token unmarshal error: %w", err)
 	}
+	token.Expiry = tokenExpiry(&token)
 	return &token, nil
 }
 
+// tokenExpiry returns when the token expires from the expires_in field, falling back to the access token's exp
+// claim, or the zero time if neither is set so the token is used until the API rejects it
+func tokenExpiry(token *oauth2.Token) time.Time {
+	if token.ExpiresIn > 0 {
+		return time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+	if exp, err := AccessTokenExpiration(token.AccessToken); err == nil && exp.Unix() > 0 {
+		return exp
+	}
+	return time.Time{}
+}
+
 // validateJWTTokenFormat checks if the provided JWT token has a valid format.
 func validateJWTTokenFormat(token string) error {
 	parts := strings.Split(token, ".")
@@ -5,6 +5,7 @@ import (
 	"log"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -73,6 +74,32 @@ func TestTokenSource(t *testing.T) {
 		token, err := src.Token()
 		require.NoError(t, err)
 		require.NotNil(t, token)
+		require.WithinDuration(t, time.Now().Add(time.Hour), token.Expiry, time.Minute)
+	})
+	t.Run("Test JWTAssertionTokenSource expiry from access token", func(t *testing.T) {
+		token := strings.TrimPrefix(accessToken, "bearer ")
+		ts := mockServer(200, `{"access_token":"`+token+`","token_type":"Bearer"}`)
+		defer ts.Close()
+		src := &JWTAssertionTokenSource{
+			Assertion: validAssertionToken,
+			TokenURL:  ts.URL,
+		}
+
+		tok, err := src.Token()
+		require.NoError(t, err)
+		require.Equal(t, time.Unix(1698096468, 0), tok.Expiry)
+	})
+	t.Run("Test JWTAssertionTokenSource without expiry", func(t *testing.T) {
+		ts := mockServer(200, `{"access_token":"`+validToken+`","token_type":"Bearer"}`)
+		defer ts.Close()
+		src := &JWTAssertionTokenSource{
+			Assertion: validAssertionToken,
+			TokenURL:  ts.URL,
+		}
+
+		tok, err := src.Token()
+		require.NoError(t, err)
+		require.True(t, tok.Expiry.IsZero())
 	})
 	t.Run("Test JWTAssertionTokenSource minimal", func(t *testing.T) {
 		ts := mockServer(200, `{"access_token":"`+validToken+`","token_type":"Bearer","expires_in":3600}`)
@@ -0,0 +1,93 @@
+package telemetry
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName is the name of the tracer and meter used by the client
+const InstrumentationName = "github.com/cloudfoundry/go-cfclient/v3"
+
+// Attribute keys recorded on spans and metrics
+const (
+	AttrResourceType   = attribute.Key("cf.resource.type")
+	AttrResourceGUID   = attribute.Key("cf.resource.guid")
+	AttrJobGUID        = attribute.Key("cf.job.guid")
+	AttrOrgName        = attribute.Key("cf.org.name")
+	AttrSpaceName      = attribute.Key("cf.space.name")
+	AttrAppName        = attribute.Key("cf.app.name")
+	AttrPackageFiles   = attribute.Key("cf.package.files")
+	AttrPackageCached  = attribute.Key("cf.package.cached_files")
+	AttrDockerImage    = attribute.Key("cf.docker.image")
+	AttrRequestID      = attribute.Key("cf.request_id")
+	AttrHTTPMethod     = attribute.Key("http.request.method")
+	AttrHTTPStatusCode = attribute.Key("http.response.status_code")
+	AttrURLTemplate    = attribute.Key("url.template")
+	AttrServerAddress  = attribute.Key("server.address")
+	AttrErrorType      = attribute.Key("error.type")
+)
+
+// GUIDPlaceholder replaces GUIDs in endpoint templates
+const GUIDPlaceholder = ":guid"
+
+var guidRegex = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// Endpoint describes the CF API endpoint a request path targets
+type Endpoint struct {
+	// Template is the request path with GUIDs replaced, i.e. /v3/apps/:guid/env
+	Template string
+
+	// ResourceType is the top level resource collection, i.e. apps
+	ResourceType string
+
+	// GUID is the last GUID in the path, if any
+	GUID string
+}
+
+// ParseEndpoint converts a request URL path into a low cardinality endpoint template
+func ParseEndpoint(urlPath string) Endpoint {
+	var e Endpoint
+	segments := strings.Split(urlPath, "/")
+	for i, s := range segments {
+		if guidRegex.MatchString(s) {
+			e.GUID = s
+			segments[i] = GUIDPlaceholder
+		}
+	}
+	e.Template = strings.Join(segments, "/")
+
+	// skip the API version, i.e. /v3
+	resources := strings.Split(strings.TrimPrefix(urlPath, "/"), "/")
+	if len(resources[0]) > 1 && resources[0][0] == 'v' {
+		resources = resources[1:]
+	}
+	if len(resources) > 0 {
+		e.ResourceType = resources[0]
+	}
+	return e
+}
+
+// SpanName returns the name of a client span sending a request with the method to the endpoint, i.e.
+// GET /v3/apps/:guid
+func (e Endpoint) SpanName(method string) string {
+	return method + " " + e.Template
+}
+
+// Start creates an internal span with the specified name and attributes
+func Start(ctx context.Context, tracer trace.Tracer, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records the error, if any, on the span and then ends it
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
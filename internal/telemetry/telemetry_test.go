@@ -0,0 +1,31 @@
+package telemetry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEndpoint(t *testing.T) {
+	guid := "9c4bc2c4-d7d4-4ee3-a8e2-a4ca38d5a1d6"
+	guid2 := "D3A0A2C1-8B8E-4F8A-9C1B-1E2F3A4B5C6D"
+
+	tests := []struct {
+		path     string
+		expected Endpoint
+	}{
+		{"/v3/apps", Endpoint{Template: "/v3/apps", ResourceType: "apps"}},
+		{"/v3/apps/" + guid, Endpoint{Template: "/v3/apps/:guid", ResourceType: "apps", GUID: guid}},
+		{"/v3/apps/" + guid + "/env", Endpoint{Template: "/v3/apps/:guid/env", ResourceType: "apps", GUID: guid}},
+		{"/v3/apps/" + guid + "/processes/web", Endpoint{Template: "/v3/apps/:guid/processes/web", ResourceType: "apps", GUID: guid}},
+		{"/v3/service_instances/" + guid + "/relationships/shared_spaces/" + guid2,
+			Endpoint{Template: "/v3/service_instances/:guid/relationships/shared_spaces/:guid", ResourceType: "service_instances", GUID: guid2}},
+		{"/v3/feature_flags/diego_docker", Endpoint{Template: "/v3/feature_flags/diego_docker", ResourceType: "feature_flags"}},
+		{"/v3", Endpoint{Template: "/v3"}},
+		{"/", Endpoint{Template: "/"}},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.expected, ParseEndpoint(tt.path), tt.path)
+	}
+	require.Equal(t, "GET /v3/apps/:guid", ParseEndpoint("/v3/apps/"+guid).SpanName("GET"))
+}
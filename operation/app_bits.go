@@ -0,0 +1,224 @@
+package operation
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/cloudfoundry/go-cfclient/v3/client"
+	"github.com/cloudfoundry/go-cfclient/v3/internal/telemetry"
+	"github.com/cloudfoundry/go-cfclient/v3/resource"
+)
+
+// resourceMatchBatchSize is the number of resources sent in each request to the resource matches endpoint
+const resourceMatchBatchSize = 1000
+
+// appBits is the source of the application files uploaded to a package during a push
+type appBits interface {
+	upload(ctx context.Context, c *client.Client, pkgGUID string) error
+}
+
+// zipBits uploads an already zipped application
+type zipBits struct {
+	zipFile io.Reader
+}
+
+func (b zipBits) upload(ctx context.Context, c *client.Client, pkgGUID string) error {
+	_, err := c.Packages.Upload(ctx, pkgGUID, b.zipFile)
+	return err
+}
+
+// dirBits uploads the files in an application directory that the CF API doesn't already have cached
+type dirBits struct {
+	dir string
+}
+
+// appFile is a file in the application directory to upload
+type appFile struct {
+	resource.ResourceMatch
+	fullPath string
+}
+
+func (b dirBits) upload(ctx context.Context, c *client.Client, pkgGUID string) error {
+	files, err := collectAppFiles(b.dir)
+	if err != nil {
+		return err
+	}
+
+	cached, err := matchResources(ctx, c, files)
+	if err != nil {
+		return err
+	}
+	var uncached []appFile
+	var cachedResources []resource.ResourceMatch
+	for _, f := range files {
+		if cached[resourceKey(f.ResourceMatch)] {
+			cachedResources = append(cachedResources, f.ResourceMatch)
+		} else {
+			uncached = append(uncached, f)
+		}
+	}
+	trace.SpanFromContext(ctx).SetAttributes(
+		telemetry.AttrPackageFiles.Int(len(files)),
+		telemetry.AttrPackageCached.Int(len(cachedResources)))
+
+	var zipFile io.Reader
+	if len(uncached) > 0 {
+		zipFile, err = zipAppFiles(uncached)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = c.Packages.UploadWithResources(ctx, pkgGUID, zipFile, cachedResources)
+	return err
+}
+
+// collectAppFiles walks the application directory and returns the checksum, size and mode of every regular
+// file that isn't excluded by the .cfignore file
+func collectAppFiles(dir string) ([]appFile, error) {
+	ignore, err := loadCFIgnore(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []appFile
+	err = filepath.WalkDir(dir, func(fullPath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(dir, fullPath)
+		if err != nil {
+			return err
+		}
+		if relPath == "." {
+			return nil
+		}
+		relPath = filepath.ToSlash(relPath)
+		if ignore.ignored(relPath, d.IsDir()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		// follow symlinks to files, anything else that isn't a regular file is skipped
+		info, err := os.Stat(fullPath)
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		checksum, err := sha1File(fullPath)
+		if err != nil {
+			return err
+		}
+		files = append(files, appFile{
+			ResourceMatch: resource.ResourceMatch{
+				Checksum:    resource.ResourceMatchChecksum{Value: checksum},
+				SizeInBytes: int(info.Size()),
+				Path:        relPath,
+				Mode:        fmt.Sprintf("%o", info.Mode().Perm()),
+			},
+			fullPath: fullPath,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reading the application directory %s: %w", dir, err)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("the application directory %s doesn't contain any files to push", dir)
+	}
+	return files, nil
+}
+
+// matchResources asks the CF API which of the files it already has cached and returns their keys
+func matchResources(ctx context.Context, c *client.Client, files []appFile) (map[string]bool, error) {
+	cached := make(map[string]bool)
+	for start := 0; start < len(files); start += resourceMatchBatchSize {
+		batch := &resource.ResourceMatches{}
+		for _, f := range files[start:min(start+resourceMatchBatchSize, len(files))] {
+			batch.Resources = append(batch.Resources, f.ResourceMatch)
+		}
+		matched, err := c.ResourceMatches.Create(ctx, batch)
+		if err != nil {
+			return nil, fmt.Errorf("error matching the application files with the cached resources: %w", err)
+		}
+		for _, r := range matched.Resources {
+			cached[resourceKey(r)] = true
+		}
+	}
+	return cached, nil
+}
+
+// zipAppFiles creates a zip archive of the files preserving their relative paths and modes
+func zipAppFiles(files []appFile) (io.Reader, error) {
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	for _, f := range files {
+		if err := addZipFile(zw, f); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("error creating the application zip: %w", err)
+	}
+	return buf, nil
+}
+
+func addZipFile(zw *zip.Writer, f appFile) error {
+	info, err := os.Stat(f.fullPath)
+	if err != nil {
+		return err
+	}
+	header, err := zip.FileInfoHeader(info)
+	if err != nil {
+		return err
+	}
+	header.Name = f.Path
+	header.Method = zip.Deflate
+	w, err := zw.CreateHeader(header)
+	if err != nil {
+		return fmt.Errorf("error adding %s to the application zip: %w", f.Path, err)
+	}
+	src, err := os.Open(f.fullPath)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	if _, err = io.Copy(w, src); err != nil {
+		return fmt.Errorf("error adding %s to the application zip: %w", f.Path, err)
+	}
+	return nil
+}
+
+func sha1File(name string) (string, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha1.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// resourceKey identifies a resource by its path and checksum
+func resourceKey(r resource.ResourceMatch) string {
+	return r.Path + "\x00" + r.Checksum.Value
+}
@@ -0,0 +1,126 @@
+package operation
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudfoundry/go-cfclient/v3/client"
+	"github.com/cloudfoundry/go-cfclient/v3/config"
+	"github.com/cloudfoundry/go-cfclient/v3/resource"
+	"github.com/cloudfoundry/go-cfclient/v3/testutil"
+)
+
+func writeTestAppDir(t *testing.T) string {
+	dir := t.TempDir()
+	files := map[string]string{
+		".cfignore":          "*.log\n",
+		"manifest.yml":       "applications: []\n",
+		"app.jar":            "large unchanged dependency",
+		"lib/dependency.jar": "another unchanged dependency",
+		"src/main.go":        "package main",
+		"debug.log":          "ignored",
+	}
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0755))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0644))
+	}
+	require.NoError(t, os.Chmod(filepath.Join(dir, "src/main.go"), 0755))
+	return dir
+}
+
+func sha1Hex(s string) string {
+	h := sha1.Sum([]byte(s))
+	return hex.EncodeToString(h[:])
+}
+
+func TestCollectAppFiles(t *testing.T) {
+	files, err := collectAppFiles(writeTestAppDir(t))
+	require.NoError(t, err)
+
+	var paths []string
+	for _, f := range files {
+		paths = append(paths, f.Path)
+	}
+	require.ElementsMatch(t, []string{"app.jar", "lib/dependency.jar", "src/main.go"}, paths)
+	for _, f := range files {
+		if f.Path == "src/main.go" {
+			require.Equal(t, sha1Hex("package main"), f.Checksum.Value)
+			require.Equal(t, len("package main"), f.SizeInBytes)
+			require.Equal(t, "755", f.Mode)
+		}
+	}
+
+	_, err = collectAppFiles(t.TempDir())
+	require.ErrorContains(t, err, "doesn't contain any files")
+}
+
+func TestDirBitsUpload(t *testing.T) {
+	var matchRequest resource.ResourceMatches
+	var uploadedResources []resource.ResourceMatch
+	var uploadedFiles []string
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v3/resource_matches":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&matchRequest))
+			// the jars are already cached
+			var matched resource.ResourceMatches
+			for _, res := range matchRequest.Resources {
+				if filepath.Ext(res.Path) == ".jar" {
+					matched.Resources = append(matched.Resources, res)
+				}
+			}
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(matched)
+		case "/v3/packages/pkg-guid/upload":
+			require.NoError(t, r.ParseMultipartForm(1<<20))
+			require.NoError(t, json.Unmarshal([]byte(r.FormValue("resources")), &uploadedResources))
+			f, _, err := r.FormFile("bits")
+			require.NoError(t, err)
+			b, err := io.ReadAll(f)
+			require.NoError(t, err)
+			zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+			require.NoError(t, err)
+			for _, zf := range zr.File {
+				uploadedFiles = append(uploadedFiles, zf.Name)
+				require.Equal(t, os.FileMode(0755), zf.Mode().Perm())
+			}
+			_, _ = w.Write([]byte(`{"guid":"pkg-guid","type":"bits","state":"PROCESSING_UPLOAD","data":{}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer api.Close()
+	uaaURL := testutil.SetupFakeUAAServer(300)
+	defer testutil.Teardown()
+
+	cfg, err := config.New(api.URL, config.ClientCredentials("cf", "secret"), config.AuthTokenURL(uaaURL, uaaURL))
+	require.NoError(t, err)
+	cf, err := client.New(cfg)
+	require.NoError(t, err)
+
+	err = dirBits{dir: writeTestAppDir(t)}.upload(context.Background(), cf, "pkg-guid")
+	require.NoError(t, err)
+
+	require.Len(t, matchRequest.Resources, 3)
+	require.Equal(t, []string{"src/main.go"}, uploadedFiles)
+	require.Len(t, uploadedResources, 2)
+	for _, res := range uploadedResources {
+		require.Contains(t, []string{"app.jar", "lib/dependency.jar"}, res.Path)
+		require.Equal(t, "644", res.Mode)
+	}
+}
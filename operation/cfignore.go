@@ -0,0 +1,139 @@
+package operation
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// CFIgnoreFile is the name of the file listing the paths that are never uploaded when pushing a directory
+const CFIgnoreFile = ".cfignore"
+
+// defaultCFIgnorePatterns are always ignored, the same as the CF CLI
+var defaultCFIgnorePatterns = []string{
+	".cfignore",
+	"/manifest.yml",
+	".gitignore",
+	".git",
+	".hg",
+	".svn",
+	"_darcs",
+	".DS_Store",
+}
+
+// cfIgnore matches paths relative to the app directory against the patterns of a .cfignore file, which use the
+// .gitignore syntax. The last matching pattern wins, so a later !pattern can include a previously ignored path.
+type cfIgnore struct {
+	patterns []ignorePattern
+}
+
+type ignorePattern struct {
+	re      *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+// loadCFIgnore reads the .cfignore file from the app directory, if there is one, along with the default patterns
+func loadCFIgnore(dir string) (*cfIgnore, error) {
+	content, err := os.ReadFile(filepath.Join(dir, CFIgnoreFile))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("error reading %s: %w", CFIgnoreFile, err)
+	}
+	return newCFIgnore(strings.Join(defaultCFIgnorePatterns, "\n") + "\n" + string(content))
+}
+
+// newCFIgnore parses the .cfignore patterns, one per line
+func newCFIgnore(content string) (*cfIgnore, error) {
+	c := &cfIgnore{}
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		var p ignorePattern
+		if strings.HasPrefix(line, "!") {
+			p.negate = true
+			line = line[1:]
+		}
+		if strings.HasSuffix(line, "/") {
+			p.dirOnly = true
+			line = strings.TrimRight(line, "/")
+		}
+
+		// patterns without a slash match the name at any depth, otherwise they're relative to the app directory
+		expr := "(?:^|/)" + globToRegex(line) + "$"
+		if strings.Contains(line, "/") {
+			expr = "^" + globToRegex(strings.TrimPrefix(line, "/")) + "$"
+		}
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s pattern %q: %w", CFIgnoreFile, line, err)
+		}
+		p.re = re
+		c.patterns = append(c.patterns, p)
+	}
+	return c, nil
+}
+
+// ignored returns true if the slash separated path relative to the app directory shouldn't be uploaded
+func (c *cfIgnore) ignored(relPath string, isDir bool) bool {
+	ignored := false
+	for _, p := range c.patterns {
+		if p.dirOnly && !isDir {
+			continue
+		}
+		if p.re.MatchString(relPath) {
+			ignored = !p.negate
+		}
+	}
+	return ignored
+}
+
+// globToRegex converts a .gitignore style glob into a regular expression where * and ? don't match a
+// slash and ** matches any number of directories
+func globToRegex(glob string) string {
+	var sb strings.Builder
+	for i := 0; i < len(glob); i++ {
+		switch ch := glob[i]; ch {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				i++
+				if i+1 < len(glob) && glob[i+1] == '/' {
+					i++
+					sb.WriteString("(?:.*/)?")
+				} else {
+					sb.WriteString(".*")
+				}
+			} else {
+				sb.WriteString("[^/]*")
+			}
+		case '?':
+			sb.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				sb.WriteString(`\[`)
+				continue
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			sb.WriteString("[" + class + "]")
+			i += end + 1
+		case '\\':
+			if i+1 < len(glob) {
+				i++
+				sb.WriteString(regexp.QuoteMeta(string(glob[i])))
+			}
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(ch)))
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,54 @@
+package operation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCFIgnore(t *testing.T) {
+	ignore, err := newCFIgnore(`
+# comments and blank lines are skipped
+
+*.log
+/tmp
+build/
+docs/**/*.md
+!important.log
+src/*/generated
+`)
+	require.NoError(t, err)
+
+	tests := []struct {
+		path    string
+		isDir   bool
+		ignored bool
+	}{
+		{"app.log", false, true},
+		{"logs/app.log", false, true},
+		{"important.log", false, false},
+		{"tmp", true, true},
+		{"src/tmp", true, false},
+		{"build", true, true},
+		{"build", false, false},
+		{"src/build", true, true},
+		{"docs/guide.md", false, true},
+		{"docs/a/b/guide.md", false, true},
+		{"docs/guide.txt", false, false},
+		{"src/main/generated", true, true},
+		{"src/main/java/generated", true, false},
+		{"main.go", false, false},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.ignored, ignore.ignored(tt.path, tt.isDir), tt.path)
+	}
+}
+
+func TestCFIgnoreDefaults(t *testing.T) {
+	ignore, err := loadCFIgnore(t.TempDir())
+	require.NoError(t, err)
+	require.True(t, ignore.ignored(".git", true))
+	require.True(t, ignore.ignored("manifest.yml", false))
+	require.False(t, ignore.ignored("config/manifest.yml", false))
+	require.True(t, ignore.ignored("src/.DS_Store", false))
+}
@@ -82,6 +82,10 @@ type AppManifestProcess struct {
 type AppManifestDocker struct {
 	Image    string `yaml:"image,omitempty"`
 	Username string `yaml:"username,omitempty"`
+
+	// Password is the registry password, it's never written to the manifest YAML. When empty the password passed
+	// to AppPushOperation.WithDockerCredentials or the CF_DOCKER_PASSWORD env var is used.
+	Password string `yaml:"-"`
 }
 
 type AppManifestServices []AppManifestService
@@ -0,0 +1,435 @@
+package operation
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ManifestOpType is the type of change a ManifestOp makes to a manifest
+type ManifestOpType string
+
+const (
+	ManifestOpReplace ManifestOpType = "replace"
+	ManifestOpRemove  ManifestOpType = "remove"
+)
+
+// ManifestOp is a BOSH style ops file operation that replaces or removes the value at a path in the manifest.
+//
+// The path is a list of tokens separated by "/", where each token is a map key, an array index, "-" to append
+// to an array, or "key=value" to find the element of an array of maps that has that value, i.e.
+// "/applications/name=api/instances". A token ending with "?" makes it and every token after it optional, so
+// missing maps and array elements are created when replacing and ignored when removing.
+type ManifestOp struct {
+	Type  ManifestOpType `yaml:"type"`
+	Path  string         `yaml:"path"`
+	Value any            `yaml:"value,omitempty"`
+}
+
+// UnresolvedVariablesError is returned when a manifest has variables that don't have a value
+type UnresolvedVariablesError struct {
+	Names []string
+}
+
+func (e *UnresolvedVariablesError) Error() string {
+	return fmt.Sprintf("the manifest has unresolved variables: %s", strings.Join(e.Names, ", "))
+}
+
+// ManifestLoader reads a manifest, applies any ops files to it and then interpolates the ((var)) placeholders
+// the same way the CF CLI does
+type ManifestLoader struct {
+	vars      map[string]any
+	varsFiles []string
+	overrides []manifestOverride
+	validate  bool
+}
+
+// manifestOverride is either an ops file or a list of ops, applied in the order they were added
+type manifestOverride struct {
+	path string
+	ops  []ManifestOp
+}
+
+// varPattern matches a ((var)) placeholder, the name can be a dotted path into a variable's value
+var varPattern = regexp.MustCompile(`\(\(([-\w.:/]+)\)\)`)
+
+// NewManifestLoader creates a new ManifestLoader without any variables or ops
+func NewManifestLoader() *ManifestLoader {
+	return &ManifestLoader{
+		vars: make(map[string]any),
+	}
+}
+
+// WithVars adds variables that take precedence over the values in any vars files, like the --var flag
+func (l *ManifestLoader) WithVars(vars map[string]any) {
+	for name, value := range vars {
+		l.vars[name] = value
+	}
+}
+
+// WithVarsFile adds a YAML file of variables, like the --vars-file flag. Files added later take precedence.
+func (l *ManifestLoader) WithVarsFile(path string) {
+	l.varsFiles = append(l.varsFiles, path)
+}
+
+// WithOpsFile adds a YAML file containing a list of ManifestOps to apply to the manifest
+func (l *ManifestLoader) WithOpsFile(path string) {
+	l.overrides = append(l.overrides, manifestOverride{path: path})
+}
+
+// WithOps adds ManifestOps to apply to the manifest after any ops added before them
+func (l *ManifestLoader) WithOps(ops ...ManifestOp) {
+	l.overrides = append(l.overrides, manifestOverride{ops: ops})
+}
+
+// WithManifestValidation validates the fields of the loaded manifest, see Manifest.Validate. Without it, only the
+// manifest's applications and their names are checked.
+func (l *ManifestLoader) WithManifestValidation(validate bool) {
+	l.validate = validate
+}
+
+// Load reads the manifest file and returns the manifest. An error is returned if the manifest has no
+// applications, an application without a name or the same application more than once.
+//
+// The paths of the applications in the manifest are returned as is, use ManifestPathResolver with the
+// manifest's directory to resolve them like the CF CLI does.
+func (l *ManifestLoader) Load(path string) (*Manifest, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading manifest %s: %w", path, err)
+	}
+	return l.LoadBytes(b)
+}
+
+// LoadBytes parses the YAML manifest and returns the manifest, checking it like Load
+func (l *ManifestLoader) LoadBytes(b []byte) (*Manifest, error) {
+	var doc any
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return nil, fmt.Errorf("error parsing manifest: %w", err)
+	}
+	if doc == nil {
+		return nil, errors.New("the manifest is empty")
+	}
+
+	var err error
+	for _, override := range l.overrides {
+		ops := override.ops
+		if override.path != "" {
+			if ops, err = readOpsFile(override.path); err != nil {
+				return nil, err
+			}
+		}
+		for _, op := range ops {
+			if doc, err = op.apply(doc); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	vars, err := l.loadVars()
+	if err != nil {
+		return nil, err
+	}
+	in := &interpolator{vars: vars}
+	if doc, err = in.interpolate(doc); err != nil {
+		return nil, err
+	}
+	if len(in.missing) > 0 {
+		slices.Sort(in.missing)
+		return nil, &UnresolvedVariablesError{Names: slices.Compact(in.missing)}
+	}
+
+	b, err = yaml.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling interpolated manifest: %w", err)
+	}
+	var manifest Manifest
+	if err = yaml.Unmarshal(b, &manifest); err != nil {
+		return nil, fmt.Errorf("error parsing manifest: %w", err)
+	}
+	if err = manifest.validateStructure(); err != nil {
+		return nil, err
+	}
+	if l.validate {
+		if err = manifest.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return &manifest, nil
+}
+
+// loadVars merges the vars files in order and then the vars set directly
+func (l *ManifestLoader) loadVars() (map[string]any, error) {
+	vars := make(map[string]any)
+	for _, path := range l.varsFiles {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading vars file %s: %w", path, err)
+		}
+		var fileVars map[string]any
+		if err = yaml.Unmarshal(b, &fileVars); err != nil {
+			return nil, fmt.Errorf("error parsing vars file %s: %w", path, err)
+		}
+		for name, value := range fileVars {
+			vars[name] = value
+		}
+	}
+	for name, value := range l.vars {
+		vars[name] = value
+	}
+	return vars, nil
+}
+
+func readOpsFile(path string) ([]ManifestOp, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading ops file %s: %w", path, err)
+	}
+	var ops []ManifestOp
+	if err = yaml.Unmarshal(b, &ops); err != nil {
+		return nil, fmt.Errorf("error parsing ops file %s: %w", path, err)
+	}
+	return ops, nil
+}
+
+// interpolator replaces the ((var)) placeholders in a parsed YAML document and records the missing variables
+type interpolator struct {
+	vars    map[string]any
+	missing []string
+}
+
+func (in *interpolator) interpolate(node any) (any, error) {
+	switch v := node.(type) {
+	case map[string]any:
+		for key, value := range v {
+			interpolated, err := in.interpolate(value)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = interpolated
+		}
+	case []any:
+		for i, value := range v {
+			interpolated, err := in.interpolate(value)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = interpolated
+		}
+	case string:
+		return in.interpolateString(v)
+	}
+	return node, nil
+}
+
+// interpolateString replaces a value that's only a placeholder with the variable as is, so it can be a number,
+// map or list, otherwise each placeholder in the string is replaced by the variable's string value
+func (in *interpolator) interpolateString(s string) (any, error) {
+	if m := varPattern.FindStringSubmatch(s); m != nil && m[0] == s {
+		value, ok := in.lookup(m[1])
+		if !ok {
+			in.missing = append(in.missing, m[1])
+			return s, nil
+		}
+		return value, nil
+	}
+
+	var err error
+	result := varPattern.ReplaceAllStringFunc(s, func(placeholder string) string {
+		name := varPattern.FindStringSubmatch(placeholder)[1]
+		value, ok := in.lookup(name)
+		if !ok {
+			in.missing = append(in.missing, name)
+			return placeholder
+		}
+		switch value.(type) {
+		case map[string]any, []any:
+			err = fmt.Errorf("the variable %s can't be used within a string because it isn't a single value", name)
+			return placeholder
+		}
+		return fmt.Sprint(value)
+	})
+	return result, err
+}
+
+// lookup returns the variable with the name, or if there isn't one follows the dotted path into a variable
+func (in *interpolator) lookup(name string) (any, bool) {
+	if value, ok := in.vars[name]; ok {
+		return value, true
+	}
+	keys := strings.Split(name, ".")
+	value, ok := in.vars[keys[0]]
+	for _, key := range keys[1:] {
+		if !ok {
+			break
+		}
+		m, isMap := value.(map[string]any)
+		if !isMap {
+			return nil, false
+		}
+		value, ok = m[key]
+	}
+	return value, ok
+}
+
+// opToken is a token of a ManifestOp path
+type opToken struct {
+	key        string
+	index      int
+	isIndex    bool
+	isAppend   bool
+	matchKey   string
+	matchValue string
+	optional   bool
+}
+
+func parseOpPath(path string) ([]opToken, error) {
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("the ops path %q must start with /", path)
+	}
+	var tokens []opToken
+	optional := false
+	for _, raw := range strings.Split(path[1:], "/") {
+		raw = strings.NewReplacer("~1", "/", "~0", "~").Replace(raw)
+		if strings.HasSuffix(raw, "?") {
+			raw = strings.TrimSuffix(raw, "?")
+			optional = true
+		}
+		if raw == "" {
+			return nil, fmt.Errorf("the ops path %q has an empty token", path)
+		}
+		t := opToken{key: raw, optional: optional}
+		if raw == "-" {
+			t.isAppend = true
+		} else if i, err := strconv.Atoi(raw); err == nil {
+			t.index, t.isIndex = i, true
+		} else if k, v, ok := strings.Cut(raw, "="); ok {
+			t.matchKey, t.matchValue = k, v
+		}
+		tokens = append(tokens, t)
+	}
+	return tokens, nil
+}
+
+// apply returns the document with the op applied, the document is modified in place where possible
+func (op ManifestOp) apply(doc any) (any, error) {
+	if op.Type != ManifestOpReplace && op.Type != ManifestOpRemove {
+		return nil, fmt.Errorf("unknown ops type %q for path %s", op.Type, op.Path)
+	}
+	tokens, err := parseOpPath(op.Path)
+	if err != nil {
+		return nil, err
+	}
+	doc, err = op.applyTokens(doc, tokens)
+	if err != nil {
+		return nil, fmt.Errorf("error applying ops %s %s: %w", op.Type, op.Path, err)
+	}
+	return doc, nil
+}
+
+func (op ManifestOp) applyTokens(node any, tokens []opToken) (any, error) {
+	t, rest := tokens[0], tokens[1:]
+	last := len(rest) == 0
+	remove := op.Type == ManifestOpRemove
+
+	switch v := node.(type) {
+	case map[string]any:
+		if t.isIndex || t.isAppend || t.matchKey != "" {
+			return nil, fmt.Errorf("expected a map key but found %q", t.key)
+		}
+		child, ok := v[t.key]
+		if last {
+			if !remove {
+				v[t.key] = op.Value
+			} else if ok {
+				delete(v, t.key)
+			} else if !t.optional {
+				return nil, fmt.Errorf("the key %s doesn't exist", t.key)
+			}
+			return v, nil
+		}
+		if !ok {
+			if !t.optional {
+				return nil, fmt.Errorf("the key %s doesn't exist", t.key)
+			}
+			if remove {
+				return v, nil
+			}
+			child = newOpContainer(rest[0])
+		}
+		child, err := op.applyTokens(child, rest)
+		if err != nil {
+			return nil, err
+		}
+		v[t.key] = child
+		return v, nil
+
+	case []any:
+		var i int
+		switch {
+		case t.isAppend:
+			if !last || remove {
+				return nil, errors.New("the - token can only be used to append to an array")
+			}
+			return append(v, op.Value), nil
+		case t.isIndex:
+			i = t.index
+			if i < 0 {
+				i += len(v)
+			}
+			if i < 0 || i >= len(v) {
+				return nil, fmt.Errorf("the index %d is out of range", t.index)
+			}
+		case t.matchKey != "":
+			i = slices.IndexFunc(v, func(elem any) bool {
+				m, ok := elem.(map[string]any)
+				return ok && fmt.Sprint(m[t.matchKey]) == t.matchValue
+			})
+			if i < 0 {
+				if !t.optional {
+					return nil, fmt.Errorf("no element has %s=%s", t.matchKey, t.matchValue)
+				}
+				if remove {
+					return v, nil
+				}
+				if last {
+					return append(v, op.Value), nil
+				}
+				v = append(v, map[string]any{t.matchKey: t.matchValue})
+				i = len(v) - 1
+			}
+		default:
+			return nil, fmt.Errorf("expected an array index but found %q", t.key)
+		}
+		if last {
+			if remove {
+				return slices.Delete(v, i, i+1), nil
+			}
+			v[i] = op.Value
+			return v, nil
+		}
+		child, err := op.applyTokens(v[i], rest)
+		if err != nil {
+			return nil, err
+		}
+		v[i] = child
+		return v, nil
+
+	default:
+		return nil, fmt.Errorf("can't find %q in a value that isn't a map or array", t.key)
+	}
+}
+
+// newOpContainer creates the missing map or array that the next token looks up a value in
+func newOpContainer(next opToken) any {
+	if next.isIndex || next.isAppend || next.matchKey != "" {
+		return []any{}
+	}
+	return map[string]any{}
+}
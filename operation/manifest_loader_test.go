@@ -0,0 +1,120 @@
+package operation
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const templatedManifestYaml = `applications:
+- name: ((app_name))
+  instances: ((instances))
+  memory: ((memory))
+  env:
+    DATABASE_URL: postgres://((db.host)):((db.port))/app
+  routes:
+  - route: ((app_name)).((domain))
+  services: ((services))
+- name: worker
+  no-route: true
+`
+
+func TestManifestLoader(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		p := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(p, []byte(content), 0644))
+		return p
+	}
+	manifestPath := write("manifest.yml", templatedManifestYaml)
+	write("vars.yml", `
+app_name: api
+instances: 2
+memory: 512M
+domain: apps.dev.example.org
+db:
+  host: db.dev
+  port: 5432
+services:
+- postgres
+- name: redis
+  binding_name: cache
+`)
+	write("prod-vars.yml", "domain: apps.example.org\n")
+	write("ops.yml", `
+- type: replace
+  path: /applications/name=worker/instances
+  value: 3
+- type: replace
+  path: /applications/name=scheduler?/command
+  value: ./scheduler
+- type: remove
+  path: /applications/name=worker/no-route
+`)
+
+	loader := NewManifestLoader()
+	loader.WithVarsFile(filepath.Join(dir, "vars.yml"))
+	loader.WithVarsFile(filepath.Join(dir, "prod-vars.yml"))
+	loader.WithVars(map[string]any{"memory": "1G"})
+	loader.WithOpsFile(filepath.Join(dir, "ops.yml"))
+	loader.WithOps(ManifestOp{Type: ManifestOpReplace, Path: "/applications/0/stack", Value: "cflinuxfs4"})
+	m, err := loader.Load(manifestPath)
+	require.NoError(t, err)
+
+	require.Len(t, m.Applications, 3)
+	api := m.Applications[0]
+	require.Equal(t, "api", api.Name)
+	require.Equal(t, uint(2), *api.Instances)
+	require.Equal(t, "1G", api.Memory)
+	require.Equal(t, "cflinuxfs4", api.Stack)
+	require.Equal(t, "postgres://db.dev:5432/app", api.Env["DATABASE_URL"])
+	require.Equal(t, "api.apps.example.org", (*api.Routes)[0].Route)
+	require.Equal(t, "postgres", (*api.Services)[0].Name)
+	require.Equal(t, "cache", (*api.Services)[1].BindingName)
+
+	worker := m.Applications[1]
+	require.Equal(t, uint(3), *worker.Instances)
+	require.False(t, worker.NoRoute)
+	require.Equal(t, "scheduler", m.Applications[2].Name)
+	require.Equal(t, "./scheduler", m.Applications[2].Command)
+}
+
+func TestManifestLoaderErrors(t *testing.T) {
+	_, err := NewManifestLoader().LoadBytes([]byte(templatedManifestYaml))
+	var unresolved *UnresolvedVariablesError
+	require.ErrorAs(t, err, &unresolved)
+	require.Equal(t, []string{"app_name", "db.host", "db.port", "domain", "instances", "memory", "services"}, unresolved.Names)
+
+	loader := NewManifestLoader()
+	loader.WithVars(map[string]any{"name": []any{"a"}})
+	_, err = loader.LoadBytes([]byte("applications:\n- name: app-((name))\n"))
+	require.ErrorContains(t, err, "variable name can't be used within a string")
+
+	_, err = NewManifestLoader().LoadBytes([]byte("applications:\n- name: app\n- name: app\n"))
+	require.ErrorContains(t, err, "more than once")
+
+	_, err = NewManifestLoader().LoadBytes([]byte("applications: []\n"))
+	require.ErrorContains(t, err, "at least one application")
+
+	// the fields are only validated when asked to, the CC accepts some values the validation doesn't
+	invalidMemory := []byte("applications:\n- name: app\n  memory: lots\n")
+	manifest, err := NewManifestLoader().LoadBytes(invalidMemory)
+	require.NoError(t, err)
+	require.Equal(t, "lots", manifest.Applications[0].Memory)
+	loader = NewManifestLoader()
+	loader.WithManifestValidation(true)
+	_, err = loader.LoadBytes(invalidMemory)
+	require.ErrorContains(t, err, "memory")
+
+	loader = NewManifestLoader()
+	loader.WithOps(ManifestOp{Type: ManifestOpReplace, Path: "/applications/name=missing/memory", Value: "1G"})
+	_, err = loader.LoadBytes([]byte("applications:\n- name: app\n"))
+	require.ErrorContains(t, err, "no element has name=missing")
+
+	loader = NewManifestLoader()
+	loader.WithOps(ManifestOp{Type: "move", Path: "/applications"})
+	_, err = loader.LoadBytes([]byte("applications:\n- name: app\n"))
+	require.ErrorContains(t, err, `unknown ops type "move"`)
+}
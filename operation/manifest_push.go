@@ -0,0 +1,258 @@
+package operation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/cloudfoundry/go-cfclient/v3/client"
+	"github.com/cloudfoundry/go-cfclient/v3/internal/telemetry"
+	"github.com/cloudfoundry/go-cfclient/v3/resource"
+)
+
+// DefaultManifestPushConcurrency is the default number of apps staged at once
+const DefaultManifestPushConcurrency = 4
+
+// AppPathResolver returns the location of an app's files, either a directory or a zip file
+type AppPathResolver func(app *AppManifest) (string, error)
+
+// ManifestPathResolver returns an AppPathResolver that resolves each app's manifest path relative to the
+// base directory, typically the directory containing the manifest. Apps without a path use the base directory.
+func ManifestPathResolver(baseDir string) AppPathResolver {
+	return func(app *AppManifest) (string, error) {
+		if app.Path == "" {
+			return baseDir, nil
+		}
+		if filepath.IsAbs(app.Path) {
+			return app.Path, nil
+		}
+		return filepath.Join(baseDir, app.Path), nil
+	}
+}
+
+// ManifestPushOperation can be used to push every application in a multi-app manifest
+type ManifestPushOperation struct {
+	pusher      *AppPushOperation
+	concurrency int
+	resolvePath AppPathResolver
+}
+
+// AppPushResult is the outcome of pushing one of the applications in a manifest
+type AppPushResult struct {
+	Name     string
+	App      *resource.App
+	Err      error
+	Duration time.Duration
+}
+
+// ManifestPushReport has the result of each application pushed from a manifest, in manifest order
+type ManifestPushReport struct {
+	Apps []AppPushResult
+}
+
+// NewManifestPushOperation creates a new ManifestPushOperation that resolves app paths relative to the
+// current working directory
+func NewManifestPushOperation(client *client.Client, orgName, spaceName string) *ManifestPushOperation {
+	return &ManifestPushOperation{
+		pusher:      NewAppPushOperation(client, orgName, spaceName),
+		concurrency: DefaultManifestPushConcurrency,
+		resolvePath: ManifestPathResolver("."),
+	}
+}
+
+// WithConcurrency sets the maximum number of apps that are uploaded and staged at once
+func (m *ManifestPushOperation) WithConcurrency(concurrency int) {
+	m.concurrency = max(concurrency, 1)
+}
+
+// WithPathResolver sets how the location of each app's files is found
+func (m *ManifestPushOperation) WithPathResolver(resolver AppPathResolver) {
+	m.resolvePath = resolver
+}
+
+// WithNoStart stages the apps without starting them, any started apps are stopped
+func (m *ManifestPushOperation) WithNoStart(stopped bool) {
+	m.pusher.WithNoStart(stopped)
+}
+
+// WithManifestValidation validates the manifest client-side before applying it, see
+// AppPushOperation.WithManifestValidation
+func (m *ManifestPushOperation) WithManifestValidation(validate bool) {
+	m.pusher.WithManifestValidation(validate)
+}
+
+// WithDockerCredentials sets the registry credentials of the docker apps whose manifest doesn't include them
+func (m *ManifestPushOperation) WithDockerCredentials(username, password string) {
+	m.pusher.WithDockerCredentials(username, password)
+}
+
+// WithDockerPreflight calls preflight before each docker image is staged, since the apps are staged concurrently
+// it can be called concurrently
+func (m *ManifestPushOperation) WithDockerPreflight(preflight DockerPreflight) {
+	m.pusher.WithDockerPreflight(preflight)
+}
+
+// Push creates or updates every application in the manifest.
+//
+// The manifest is applied to the space once, so all the apps, routes and service bindings exist before any app
+// is staged. The apps are then uploaded and staged concurrently, but started in the order they're listed in the
+// manifest, so the backing apps listed first are started before the apps that depend on them.
+//
+// An error is returned without a report if the manifest has no applications, an application without a name or
+// the same application more than once, or if it can't be applied. Otherwise, the report contains the
+// result of every app and the returned error joins the errors of the apps that failed.
+func (m *ManifestPushOperation) Push(ctx context.Context, manifest *Manifest) (report *ManifestPushReport, err error) {
+	if err = manifest.validateStructure(); err != nil {
+		return nil, err
+	}
+	if m.pusher.validate {
+		if err = manifest.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	p := m.pusher
+	ctx, span := telemetry.Start(ctx, p.client.Tracer(), "operation.ManifestPushOperation.Push",
+		telemetry.AttrOrgName.String(p.orgName),
+		telemetry.AttrSpaceName.String(p.spaceName))
+	defer func() { telemetry.End(span, err) }()
+
+	org, err := p.findOrg(ctx)
+	if err != nil {
+		return nil, err
+	}
+	space, err := p.findSpace(ctx, org.GUID)
+	if err != nil {
+		return nil, err
+	}
+	if err = p.applyManifest(ctx, space, manifest); err != nil {
+		return nil, err
+	}
+
+	report = &ManifestPushReport{Apps: make([]AppPushResult, len(manifest.Applications))}
+
+	// each app waits for the previous app to be started before starting, a semaphore bounds the staging
+	started := make([]chan struct{}, len(manifest.Applications))
+	for i := range started {
+		started[i] = make(chan struct{})
+	}
+	sem := make(chan struct{}, m.concurrency)
+	var wg sync.WaitGroup
+	for i, appManifest := range manifest.Applications {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			var previous <-chan struct{}
+			if i > 0 {
+				previous = started[i-1]
+			}
+			// an app that fails still waits for the apps before it, so the later apps keep the manifest order
+			defer func() {
+				if previous != nil {
+					<-previous
+				}
+				close(started[i])
+			}()
+			start := time.Now()
+			app, err := m.pushApp(ctx, space, appManifest, sem, previous)
+			report.Apps[i] = AppPushResult{
+				Name:     appManifest.Name,
+				App:      app,
+				Err:      err,
+				Duration: time.Since(start),
+			}
+		}()
+	}
+	wg.Wait()
+	return report, report.Err()
+}
+
+// pushApp stages the app once a staging slot is free and then starts it after the previous app
+func (m *ManifestPushOperation) pushApp(ctx context.Context, space *resource.Space, appManifest *AppManifest, sem chan struct{}, previous <-chan struct{}) (app *resource.App, err error) {
+	p := m.pusher
+	ctx, span := telemetry.Start(ctx, p.client.Tracer(), "operation.ManifestPushOperation.pushApp",
+		telemetry.AttrAppName.String(appManifest.Name))
+	defer func() { telemetry.End(span, err) }()
+
+	select {
+	case sem <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	app, appWasStarted, err := m.stageApp(ctx, space, appManifest)
+	<-sem
+	if err != nil {
+		return nil, err
+	}
+
+	if previous != nil {
+		select {
+		case <-previous:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return p.startApp(ctx, app, appWasStarted)
+}
+
+// stageApp resolves the app's files and stages them
+func (m *ManifestPushOperation) stageApp(ctx context.Context, space *resource.Space, appManifest *AppManifest) (*resource.App, bool, error) {
+	var bits appBits
+	if appManifest.Docker == nil || appManifest.Lifecycle == Buildpack || appManifest.Lifecycle == CNB {
+		appPath, err := m.resolvePath(appManifest)
+		if err != nil {
+			return nil, false, fmt.Errorf("error resolving the path of app %s: %w", appManifest.Name, err)
+		}
+		info, err := os.Stat(appPath)
+		if err != nil {
+			return nil, false, fmt.Errorf("error reading the path of app %s: %w", appManifest.Name, err)
+		}
+		if info.IsDir() {
+			bits = dirBits{dir: appPath}
+		} else {
+			zipFile, err := os.Open(appPath)
+			if err != nil {
+				return nil, false, fmt.Errorf("error opening the zip file of app %s: %w", appManifest.Name, err)
+			}
+			defer zipFile.Close()
+			bits = zipBits{zipFile: zipFile}
+		}
+	}
+	return m.pusher.stageApp(ctx, space, appManifest, bits)
+}
+
+// Succeeded returns the results of the apps that were pushed successfully
+func (r *ManifestPushReport) Succeeded() []AppPushResult {
+	var results []AppPushResult
+	for _, app := range r.Apps {
+		if app.Err == nil {
+			results = append(results, app)
+		}
+	}
+	return results
+}
+
+// Failed returns the results of the apps that failed to push
+func (r *ManifestPushReport) Failed() []AppPushResult {
+	var results []AppPushResult
+	for _, app := range r.Apps {
+		if app.Err != nil {
+			results = append(results, app)
+		}
+	}
+	return results
+}
+
+// Err returns the errors of every app that failed to push joined together, or nil if they all succeeded
+func (r *ManifestPushReport) Err() error {
+	var errs []error
+	for _, app := range r.Failed() {
+		errs = append(errs, fmt.Errorf("failed to push app %s: %w", app.Name, app.Err))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,221 @@
+package operation
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudfoundry/go-cfclient/v3/client"
+	"github.com/cloudfoundry/go-cfclient/v3/config"
+	"github.com/cloudfoundry/go-cfclient/v3/testutil"
+)
+
+// fakeManifestPushAPI is a minimal CF API that stages every app it's asked to, except for the failing app
+type fakeManifestPushAPI struct {
+	failingApp string
+
+	mu       sync.Mutex
+	applied  int
+	uploaded []string
+	started  []string
+}
+
+func (f *fakeManifestPushAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	paged := func(resources ...string) string {
+		return fmt.Sprintf(`{"pagination":{"total_results":%d,"total_pages":1},"resources":[%s]}`,
+			len(resources), strings.Join(resources, ","))
+	}
+	app := func(name, state string) string {
+		return fmt.Sprintf(`{"guid":"%s","name":"%s","state":"%s","lifecycle":{"type":"buildpack","data":{}}}`, name, name, state)
+	}
+	pkg := func(guid string) string {
+		return fmt.Sprintf(`{"guid":"%s","type":"bits","state":"READY","data":{}}`, guid)
+	}
+	segments := strings.Split(strings.TrimPrefix(r.URL.Path, "/v3/"), "/")
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case r.URL.Path == "/v3/organizations":
+		_, _ = w.Write([]byte(paged(`{"guid":"org-guid","name":"org"}`)))
+	case r.URL.Path == "/v3/spaces":
+		_, _ = w.Write([]byte(paged(`{"guid":"space-guid","name":"space"}`)))
+	case r.URL.Path == "/v3/spaces/space-guid/actions/apply_manifest":
+		f.applied++
+		w.Header().Set("Location", "http://"+r.Host+"/v3/jobs/job-guid")
+		w.WriteHeader(http.StatusAccepted)
+	case r.URL.Path == "/v3/jobs/job-guid":
+		_, _ = w.Write([]byte(`{"guid":"job-guid","state":"COMPLETE"}`))
+	case r.URL.Path == "/v3/apps" && r.Method == http.MethodGet:
+		_, _ = w.Write([]byte(paged(app(r.URL.Query().Get("names"), "STOPPED"))))
+	case r.URL.Path == "/v3/resource_matches":
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"resources":[]}`))
+	case r.URL.Path == "/v3/packages" && r.Method == http.MethodPost:
+		var create struct {
+			Relationships struct {
+				App struct {
+					Data struct{ GUID string } `json:"data"`
+				} `json:"app"`
+			} `json:"relationships"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&create)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(pkg(create.Relationships.App.Data.GUID)))
+	case segments[0] == "packages" && len(segments) == 3 && segments[2] == "upload":
+		f.uploaded = append(f.uploaded, segments[1])
+		_, _ = w.Write([]byte(pkg(segments[1])))
+	case segments[0] == "packages" && len(segments) == 2:
+		_, _ = w.Write([]byte(pkg(segments[1])))
+	case segments[0] == "packages" && len(segments) == 3 && segments[2] == "droplets":
+		_, _ = w.Write([]byte(paged(fmt.Sprintf(`{"guid":"%s","state":"STAGED"}`, segments[1]))))
+	case r.URL.Path == "/v3/builds":
+		var create struct {
+			Package struct{ GUID string } `json:"package"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&create)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(fmt.Sprintf(`{"guid":"%s","state":"STAGING"}`, create.Package.GUID)))
+	case segments[0] == "builds":
+		state := "STAGED"
+		if segments[1] == f.failingApp {
+			state = "FAILED"
+		}
+		_, _ = w.Write([]byte(fmt.Sprintf(`{"guid":"%s","state":"%s","error":"staging failed","relationships":{"app":{"data":{"guid":"%s"}}}}`,
+			segments[1], state, segments[1])))
+	case segments[0] == "apps" && len(segments) == 4 && segments[3] == "current_droplet":
+		_, _ = w.Write([]byte(`{"data":{"guid":"droplet-guid"}}`))
+	case segments[0] == "apps" && len(segments) == 4 && segments[3] == "start":
+		f.started = append(f.started, segments[1])
+		_, _ = w.Write([]byte(app(segments[1], "STARTED")))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"errors":[{"code":10000,"title":"CF-NotFound","detail":"` + r.Method + " " + r.URL.Path + `"}]}`))
+	}
+}
+
+func TestManifestPush(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"db-migrator", "api", "broken"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, name), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name, "main.go"), []byte("package "+name), 0644))
+	}
+	zipFile, err := os.Create(filepath.Join(dir, "ui.zip"))
+	require.NoError(t, err)
+	zw := zip.NewWriter(zipFile)
+	_, err = zw.Create("index.html")
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	require.NoError(t, zipFile.Close())
+
+	fake := &fakeManifestPushAPI{failingApp: "broken"}
+	api := httptest.NewServer(fake)
+	defer api.Close()
+	uaaURL := testutil.SetupFakeUAAServer(300)
+	defer testutil.Teardown()
+
+	cfg, err := config.New(api.URL, config.ClientCredentials("cf", "secret"), config.AuthTokenURL(uaaURL, uaaURL))
+	require.NoError(t, err)
+	cf, err := client.New(cfg)
+	require.NoError(t, err)
+
+	manifest := &Manifest{
+		Applications: []*AppManifest{
+			{Name: "db-migrator", Path: "db-migrator"},
+			{Name: "api", Path: "api"},
+			{Name: "broken", Path: "broken"},
+			{Name: "ui", Path: "ui.zip"},
+		},
+	}
+	pusher := NewManifestPushOperation(cf, "org", "space")
+	pusher.WithConcurrency(2)
+	pusher.WithPathResolver(ManifestPathResolver(dir))
+	report, err := pusher.Push(context.Background(), manifest)
+	require.ErrorContains(t, err, "failed to push app broken")
+
+	require.Equal(t, 1, fake.applied)
+	require.ElementsMatch(t, []string{"db-migrator", "api", "broken", "ui"}, fake.uploaded)
+	require.Equal(t, []string{"db-migrator", "api", "ui"}, fake.started)
+
+	require.Len(t, report.Apps, 4)
+	for i, app := range manifest.Applications {
+		require.Equal(t, app.Name, report.Apps[i].Name)
+	}
+	require.Len(t, report.Succeeded(), 3)
+	require.Equal(t, "STARTED", report.Apps[0].App.State)
+	failed := report.Failed()
+	require.Len(t, failed, 1)
+	require.Equal(t, "broken", failed[0].Name)
+	require.ErrorContains(t, failed[0].Err, "staging failed")
+}
+
+func TestManifestPushDockerAppWithBuildpackLifecycle(t *testing.T) {
+	fake := &fakeManifestPushAPI{}
+	api := httptest.NewServer(fake)
+	defer api.Close()
+	uaaURL := testutil.SetupFakeUAAServer(300)
+	defer testutil.Teardown()
+
+	cfg, err := config.New(api.URL, config.ClientCredentials("cf", "secret"), config.AuthTokenURL(uaaURL, uaaURL))
+	require.NoError(t, err)
+	cf, err := client.New(cfg)
+	require.NoError(t, err)
+
+	// the existing app uses the buildpack lifecycle, so there are no files to stage the docker image with
+	manifest := &Manifest{
+		Applications: []*AppManifest{
+			{Name: "web", Docker: &AppManifestDocker{Image: "nginx:latest"}},
+		},
+	}
+	report, err := NewManifestPushOperation(cf, "org", "space").Push(context.Background(), manifest)
+	require.ErrorContains(t, err, "failed to push app web")
+	require.Len(t, report.Failed(), 1)
+	require.ErrorContains(t, report.Failed()[0].Err, "app web has no files to upload")
+	require.Empty(t, fake.uploaded)
+}
+
+func TestManifestPushValidation(t *testing.T) {
+	pusher := NewManifestPushOperation(nil, "org", "space")
+	pusher.WithManifestValidation(true)
+	_, err := pusher.Push(context.Background(), &Manifest{})
+	require.ErrorContains(t, err, "at least one application")
+
+	_, err = pusher.Push(context.Background(), &Manifest{
+		Applications: []*AppManifest{{Name: "api"}, {Name: "api"}},
+	})
+	require.ErrorContains(t, err, "more than once")
+
+	_, err = pusher.Push(context.Background(), &Manifest{
+		Applications: []*AppManifest{{Name: "api", AppManifestProcess: AppManifestProcess{Memory: "lots"}}},
+	})
+	require.ErrorContains(t, err, "memory")
+
+	t.Run("without validation", func(t *testing.T) {
+		// the manifest's structure is always checked, so the apps can be pushed concurrently by name
+		pusher := NewManifestPushOperation(nil, "org", "space")
+		_, err := pusher.Push(context.Background(), nil)
+		require.ErrorContains(t, err, "at least one application")
+
+		_, err = pusher.Push(context.Background(), &Manifest{Applications: []*AppManifest{{Name: "api"}, nil}})
+		require.ErrorContains(t, err, "applications[1]: the application is empty")
+
+		_, err = pusher.Push(context.Background(), &Manifest{
+			Applications: []*AppManifest{{Name: "api"}, {Name: "api"}},
+		})
+		require.ErrorContains(t, err, "more than once")
+
+		_, err = pusher.Push(context.Background(), &Manifest{Applications: []*AppManifest{{Path: "api"}}})
+		require.ErrorContains(t, err, "the application must have a name")
+	})
+}
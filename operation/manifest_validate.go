@@ -0,0 +1,318 @@
+package operation
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ManifestFieldError is a problem with a field of a manifest
+type ManifestFieldError struct {
+	// App is the name of the application with the problem, empty for problems with the manifest itself
+	App string
+
+	// Field is the YAML path of the field within the application, or within the manifest when App is empty,
+	// i.e. processes[1].memory
+	Field string
+
+	Message string
+}
+
+func (e *ManifestFieldError) Error() string {
+	if e.App == "" {
+		return fmt.Sprintf("%s: %s", e.Field, e.Message)
+	}
+	return fmt.Sprintf("application %s: %s: %s", e.App, e.Field, e.Message)
+}
+
+// ManifestValidationError has every problem found validating a manifest
+type ManifestValidationError struct {
+	Errors []*ManifestFieldError
+}
+
+func (e *ManifestValidationError) Error() string {
+	if len(e.Errors) == 1 {
+		return "invalid manifest: " + e.Errors[0].Error()
+	}
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("invalid manifest, %d errors:\n%s", len(e.Errors), strings.Join(msgs, "\n"))
+}
+
+// Unwrap returns each of the field errors
+func (e *ManifestValidationError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+	return errs
+}
+
+var (
+	// sizePattern matches the memory and disk sizes accepted by the CF CLI, i.e. 512M or 1GB
+	sizePattern = regexp.MustCompile(`(?i)^[0-9]+(B|K|KB|M|MB|G|GB|T|TB)$`)
+
+	// logRatePattern matches a log rate limit, which can be -1 for unlimited or a size, i.e. 16K
+	logRatePattern = regexp.MustCompile(`(?i)^(-1|0|[0-9]+(B|K|KB|M|MB|G|GB|T|TB))$`)
+)
+
+// manifestValidator collects the field errors of a manifest
+type manifestValidator struct {
+	app    string
+	errors []*ManifestFieldError
+}
+
+func (v *manifestValidator) addf(field, format string, args ...any) {
+	v.errors = append(v.errors, &ManifestFieldError{
+		App:     v.app,
+		Field:   field,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *manifestValidator) err() error {
+	if len(v.errors) == 0 {
+		return nil
+	}
+	return &ManifestValidationError{Errors: v.errors}
+}
+
+// Validate checks the manifest has at least one application, that every application has a unique name and
+// that each application is valid. A *ManifestValidationError with every problem found is returned.
+func (m *Manifest) Validate() error {
+	return m.validate(true)
+}
+
+// validateStructure checks the manifest has at least one application and that every application has a unique
+// name, without validating the fields of the applications. These checks are always made before a manifest is
+// loaded or pushed, since the apps are pushed concurrently by name.
+func (m *Manifest) validateStructure() error {
+	return m.validate(false)
+}
+
+func (m *Manifest) validate(validateApps bool) error {
+	v := &manifestValidator{}
+	if m == nil || len(m.Applications) == 0 {
+		v.addf("applications", "the manifest must contain at least one application")
+		return v.err()
+	}
+	names := make(map[string]bool)
+	for i, app := range m.Applications {
+		if app == nil {
+			v.addf(fmt.Sprintf("applications[%d]", i), "the application is empty")
+			continue
+		}
+		if app.Name != "" && names[app.Name] {
+			v.addf(fmt.Sprintf("applications[%d].name", i), "the manifest contains the application %s more than once", app.Name)
+		}
+		names[app.Name] = true
+		av := &manifestValidator{app: app.Name}
+		if app.Name == "" {
+			av.app = fmt.Sprintf("at index %d", i)
+		}
+		if validateApps {
+			av.validateApp(app)
+		} else if app.Name == "" {
+			av.addf("name", "the application must have a name")
+		}
+		v.errors = append(v.errors, av.errors...)
+	}
+	return v.err()
+}
+
+// Validate checks the application's sizes, health checks, routes and sidecars are valid and that it doesn't
+// combine mutually exclusive fields. A *ManifestValidationError with every problem found is returned.
+func (a *AppManifest) Validate() error {
+	v := &manifestValidator{app: a.Name}
+	v.validateApp(a)
+	return v.err()
+}
+
+func (v *manifestValidator) validateApp(a *AppManifest) {
+	if a.Name == "" {
+		v.addf("name", "the application must have a name")
+	}
+
+	switch a.Lifecycle {
+	case "", Buildpack, CNB, Docker:
+	default:
+		v.addf("lifecycle", "must be one of %s, %s or %s", Buildpack, CNB, Docker)
+	}
+	if a.Docker != nil {
+		if a.Docker.Image == "" {
+			v.addf("docker.image", "a docker image is required")
+		} else if _, err := ParseDockerImage(a.Docker.Image); err != nil {
+			v.addf("docker.image", "invalid image reference %q, must be [registry/]repository[:tag][@digest]", a.Docker.Image)
+		}
+		if len(a.Buildpacks) > 0 {
+			v.addf("docker", "can't be used together with buildpacks")
+		}
+		if a.Path != "" {
+			v.addf("docker", "can't be used together with path")
+		}
+		if a.Lifecycle == Buildpack || a.Lifecycle == CNB {
+			v.addf("docker", "can't be used with the %s lifecycle", a.Lifecycle)
+		}
+	} else if a.Lifecycle == Docker {
+		v.addf("docker", "the docker lifecycle requires a docker image")
+	}
+
+	if a.NoRoute {
+		if a.Routes != nil && len(*a.Routes) > 0 {
+			v.addf("no-route", "can't be used together with routes")
+		}
+		if a.RandomRoute {
+			v.addf("no-route", "can't be used together with random-route")
+		}
+		if a.DefaultRoute {
+			v.addf("no-route", "can't be used together with default-route")
+		}
+	}
+	if a.RandomRoute && a.Routes != nil && len(*a.Routes) > 0 {
+		v.addf("random-route", "can't be used together with routes")
+	}
+	if a.Routes != nil {
+		for i, route := range *a.Routes {
+			v.validateRoute(fmt.Sprintf("routes[%d]", i), route)
+		}
+	}
+
+	if a.Services != nil {
+		for i, svc := range *a.Services {
+			if svc.Name == "" {
+				v.addf(fmt.Sprintf("services[%d].name", i), "the service instance must have a name")
+			}
+		}
+	}
+
+	v.validateProcess("", &a.AppManifestProcess)
+	processTypes := map[AppProcessType]bool{}
+	if a.Processes != nil {
+		for i, proc := range *a.Processes {
+			field := fmt.Sprintf("processes[%d]", i)
+			if proc.Type == "" {
+				v.addf(field+".type", "the process must have a type")
+			} else if processTypes[proc.Type] {
+				v.addf(field+".type", "the process type %s is used more than once", proc.Type)
+			}
+			processTypes[proc.Type] = true
+			v.validateProcess(field+".", &proc)
+		}
+	}
+
+	if a.Sidecars != nil {
+		sidecarNames := map[string]bool{}
+		for i, sidecar := range *a.Sidecars {
+			field := fmt.Sprintf("sidecars[%d]", i)
+			if sidecar.Name == "" {
+				v.addf(field+".name", "the sidecar must have a name")
+			} else if sidecarNames[sidecar.Name] {
+				v.addf(field+".name", "the sidecar %s is used more than once", sidecar.Name)
+			}
+			sidecarNames[sidecar.Name] = true
+			if sidecar.Command == "" {
+				v.addf(field+".command", "the sidecar must have a command")
+			}
+			if len(sidecar.ProcessTypes) == 0 {
+				v.addf(field+".process_types", "the sidecar must have at least one process type")
+			}
+			seen := map[string]bool{}
+			for j, processType := range sidecar.ProcessTypes {
+				if processType == "" {
+					v.addf(fmt.Sprintf("%s.process_types[%d]", field, j), "the process type can't be empty")
+				} else if seen[processType] {
+					v.addf(fmt.Sprintf("%s.process_types[%d]", field, j), "the process type %s is listed more than once", processType)
+				}
+				seen[processType] = true
+			}
+			if sidecar.Memory != "" && !sizePattern.MatchString(sidecar.Memory) {
+				v.addf(field+".memory", "invalid size %q, must be a number with a unit of B, K, M, G or T, i.e. 256M", sidecar.Memory)
+			}
+		}
+	}
+}
+
+// validateProcess checks the fields shared by an application and its processes, the prefix is prepended to
+// each field path
+func (v *manifestValidator) validateProcess(prefix string, p *AppManifestProcess) {
+	if p.Memory != "" && !sizePattern.MatchString(p.Memory) {
+		v.addf(prefix+"memory", "invalid size %q, must be a number with a unit of B, K, M, G or T, i.e. 256M", p.Memory)
+	}
+	if p.DiskQuota != "" && !sizePattern.MatchString(p.DiskQuota) {
+		v.addf(prefix+"disk_quota", "invalid size %q, must be a number with a unit of B, K, M, G or T, i.e. 1G", p.DiskQuota)
+	}
+	if p.LogRateLimitPerSecond != "" && !logRatePattern.MatchString(p.LogRateLimitPerSecond) {
+		v.addf(prefix+"log-rate-limit-per-second", "invalid log rate %q, must be -1 for unlimited or a number with a unit of B, K, M, G or T, i.e. 16K", p.LogRateLimitPerSecond)
+	}
+
+	switch p.HealthCheckType {
+	// the CC still accepts none, the deprecated name of process
+	case "", Http, Port, Process, "none":
+	default:
+		v.addf(prefix+"health-check-type", "must be one of %s, %s or %s", Http, Port, Process)
+	}
+	if p.HealthCheckHTTPEndpoint != "" && !strings.HasPrefix(p.HealthCheckHTTPEndpoint, "/") {
+		v.addf(prefix+"health-check-http-endpoint", "must be a path starting with /")
+	}
+	switch AppHealthCheckType(p.ReadinessHealthCheckType) {
+	case "", Http, Port, Process:
+	default:
+		v.addf(prefix+"readiness-health-check-type", "must be one of %s, %s or %s", Http, Port, Process)
+	}
+	if p.ReadinessHealthCheckHttpEndpoint != "" && !strings.HasPrefix(p.ReadinessHealthCheckHttpEndpoint, "/") {
+		v.addf(prefix+"readiness-health-check-http-endpoint", "must be a path starting with /")
+	}
+}
+
+// validateRoute checks the route is a host and domain with an optional port and path. Like the CC, the route can
+// have an http or https scheme, or a tcp scheme for a tcp route.
+func (v *manifestValidator) validateRoute(field string, route AppManifestRoute) {
+	switch route.Protocol {
+	case "", HTTP1, HTTP2, TCP:
+	default:
+		v.addf(field+".protocol", "must be one of %s, %s or %s", HTTP1, HTTP2, TCP)
+	}
+
+	if route.Route == "" {
+		v.addf(field+".route", "the route can't be empty")
+		return
+	}
+	raw, tcp := route.Route, route.Protocol == TCP
+	if scheme, rest, ok := strings.Cut(raw, "://"); ok {
+		switch strings.ToLower(scheme) {
+		case "http", "https":
+		case "tcp":
+			tcp = true
+		default:
+			v.addf(field+".route", "invalid route %q, the scheme must be http, https or tcp", route.Route)
+			return
+		}
+		raw = rest
+	}
+	u, err := url.Parse("//" + raw)
+	if err != nil || u.User != nil || u.RawQuery != "" || u.Fragment != "" {
+		v.addf(field+".route", "invalid route %q, must be a host and domain with an optional port and path", route.Route)
+		return
+	}
+	host := u.Hostname()
+	if host == "" || !strings.Contains(host, ".") || strings.HasPrefix(host, ".") || strings.HasSuffix(host, ".") {
+		v.addf(field+".route", "invalid route %q, must include a domain", route.Route)
+	}
+	if portStr := u.Port(); portStr != "" || strings.HasSuffix(u.Host, ":") {
+		if port, err := strconv.Atoi(portStr); err != nil || port < 1 || port > 65535 {
+			v.addf(field+".route", "invalid route %q, the port must be between 1 and 65535", route.Route)
+		}
+	}
+	if tcp {
+		if u.Port() == "" {
+			v.addf(field+".route", "invalid route %q, a tcp route must have a port", route.Route)
+		}
+		if u.Path != "" {
+			v.addf(field+".route", "invalid route %q, a tcp route can't have a path", route.Route)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package operation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppManifestValidate(t *testing.T) {
+	var instances uint = 2
+	valid := NewAppManifest("api")
+	valid.Routes = &AppManifestRoutes{
+		{Route: "api.apps.example.org/v1"},
+		{Route: "https://api.example.org"},
+		{Route: "tcp.example.org:1024", Protocol: TCP},
+	}
+	valid.Processes = &AppManifestProcesses{
+		{Type: Web, Instances: &instances, DiskQuota: "1GB", LogRateLimitPerSecond: "-1"},
+		{Type: Worker, HealthCheckType: Process, Memory: "512m"},
+		{Type: "clock", HealthCheckType: "none"},
+	}
+	valid.Sidecars = &AppManifestSideCars{
+		{Name: "envoy", ProcessTypes: []string{"web"}, Command: "envoy", Memory: "64M"},
+	}
+	require.NoError(t, valid.Validate())
+
+	tests := []struct {
+		name   string
+		modify func(a *AppManifest)
+		field  string
+		msg    string
+	}{
+		{"memory unit", func(a *AppManifest) { a.Memory = "256" }, "memory", `invalid size "256"`},
+		{"disk quota unit", func(a *AppManifest) { a.DiskQuota = "1 gig" }, "disk_quota", "invalid size"},
+		{"log rate", func(a *AppManifest) { a.LogRateLimitPerSecond = "fast" }, "log-rate-limit-per-second", "invalid log rate"},
+		{"health check type", func(a *AppManifest) { a.HealthCheckType = "tcp" }, "health-check-type", "must be one of http, port or process"},
+		{"health check endpoint", func(a *AppManifest) { a.HealthCheckHTTPEndpoint = "health" }, "health-check-http-endpoint", "must be a path"},
+		{"readiness type", func(a *AppManifest) { a.ReadinessHealthCheckType = "none" }, "readiness-health-check-type", "must be one of"},
+		{"lifecycle", func(a *AppManifest) { a.Lifecycle = "kpack" }, "lifecycle", "must be one of buildpack, cnb or docker"},
+		{"no-route with routes", func(a *AppManifest) {
+			a.NoRoute = true
+			a.Routes = &AppManifestRoutes{{Route: "api.example.org"}}
+		}, "no-route", "can't be used together with routes"},
+		{"no-route with random-route", func(a *AppManifest) {
+			a.NoRoute = true
+			a.RandomRoute = true
+		}, "no-route", "can't be used together with random-route"},
+		{"docker with buildpacks", func(a *AppManifest) {
+			a.Docker = &AppManifestDocker{Image: "nginx"}
+			a.Buildpacks = []string{"go_buildpack"}
+		}, "docker", "can't be used together with buildpacks"},
+		{"docker without image", func(a *AppManifest) { a.Docker = &AppManifestDocker{} }, "docker.image", "a docker image is required"},
+		{"docker lifecycle without docker", func(a *AppManifest) { a.Lifecycle = Docker }, "docker", "requires a docker image"},
+		{"route scheme", func(a *AppManifest) {
+			a.Routes = &AppManifestRoutes{{Route: "ftp://api.example.org"}}
+		}, "routes[0].route", "the scheme must be http, https or tcp"},
+		{"route domain", func(a *AppManifest) {
+			a.Routes = &AppManifestRoutes{{Route: "localhost"}}
+		}, "routes[0].route", "must include a domain"},
+		{"route port", func(a *AppManifest) {
+			a.Routes = &AppManifestRoutes{{Route: "api.example.org:99999"}}
+		}, "routes[0].route", "the port must be between 1 and 65535"},
+		{"tcp route without port", func(a *AppManifest) {
+			a.Routes = &AppManifestRoutes{{Route: "api.example.org"}, {Route: "tcp://tcp.example.org"}}
+		}, "routes[1].route", "a tcp route must have a port"},
+		{"route protocol", func(a *AppManifest) {
+			a.Routes = &AppManifestRoutes{{Route: "api.example.org", Protocol: "http3"}}
+		}, "routes[0].protocol", "must be one of http1, http2 or tcp"},
+		{"process memory", func(a *AppManifest) {
+			a.Processes = &AppManifestProcesses{{Type: Web}, {Type: Worker, Memory: "lots"}}
+		}, "processes[1].memory", "invalid size"},
+		{"duplicate process", func(a *AppManifest) {
+			a.Processes = &AppManifestProcesses{{Type: Web}, {Type: Web}}
+		}, "processes[1].type", "used more than once"},
+		{"sidecar process types", func(a *AppManifest) {
+			a.Sidecars = &AppManifestSideCars{{Name: "envoy", Command: "envoy"}}
+		}, "sidecars[0].process_types", "at least one process type"},
+		{"sidecar empty process type", func(a *AppManifest) {
+			a.Sidecars = &AppManifestSideCars{{Name: "envoy", Command: "envoy", ProcessTypes: []string{"web", ""}}}
+		}, "sidecars[0].process_types[1]", "can't be empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewAppManifest("api")
+			tt.modify(a)
+			err := a.Validate()
+			var validationErr *ManifestValidationError
+			require.ErrorAs(t, err, &validationErr)
+			require.Len(t, validationErr.Errors, 1, err.Error())
+			fieldErr := validationErr.Errors[0]
+			require.Equal(t, "api", fieldErr.App)
+			require.Equal(t, tt.field, fieldErr.Field)
+			require.Contains(t, fieldErr.Message, tt.msg)
+			require.ErrorContains(t, err, "application api: "+tt.field+": ")
+		})
+	}
+}
+
+func TestManifestValidate(t *testing.T) {
+	require.NoError(t, NewManifest(NewAppManifest("api"), NewAppManifest("worker")).Validate())
+
+	err := (&Manifest{}).Validate()
+	require.EqualError(t, err, "invalid manifest: applications: the manifest must contain at least one application")
+
+	bad := NewAppManifest("worker")
+	bad.Memory = "1"
+	bad.NoRoute = true
+	bad.RandomRoute = true
+	err = NewManifest(NewAppManifest("api"), NewAppManifest(""), bad, NewAppManifest("api")).Validate()
+	var validationErr *ManifestValidationError
+	require.ErrorAs(t, err, &validationErr)
+	require.Len(t, validationErr.Errors, 4)
+	require.Equal(t, "application at index 1: name: the application must have a name", validationErr.Errors[0].Error())
+	require.Equal(t, "worker", validationErr.Errors[1].App)
+	require.Equal(t, "no-route", validationErr.Errors[1].Field)
+	require.Equal(t, "memory", validationErr.Errors[2].Field)
+	require.Equal(t, "applications[3].name", validationErr.Errors[3].Field)
+	require.Contains(t, err.Error(), "invalid manifest, 4 errors:\n")
+
+	var fieldErr *ManifestFieldError
+	require.ErrorAs(t, err, &fieldErr)
+}
@@ -5,12 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"os"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"gopkg.in/yaml.v3"
 
 	"github.com/cloudfoundry/go-cfclient/v3/client"
+	"github.com/cloudfoundry/go-cfclient/v3/internal/telemetry"
 	"github.com/cloudfoundry/go-cfclient/v3/resource"
 )
 
@@ -20,6 +22,7 @@ const (
 	StrategyNone StrategyMode = iota
 	StrategyBlueGreen
 	StrategyRolling
+	StrategyCanary
 )
 
 const (
@@ -27,6 +30,10 @@ const (
 	AppDeployedRunningTimeoutMinutesDefault       = 5
 )
 
+// CanaryGate is called each time a canary deployment pauses after deploying a step's instances. Returning nil
+// continues the deployment to the next step, returning an error cancels the deployment.
+type CanaryGate func(ctx context.Context, deployment *resource.Deployment) error
+
 // AppPushOperation can be used to push buildpack apps
 type AppPushOperation struct {
 	orgName       string
@@ -36,6 +43,15 @@ type AppPushOperation struct {
 	stopped       bool
 	timeout       uint
 	checkInterval uint
+	canarySteps   []resource.CanaryStep
+	canaryGate    CanaryGate
+	rollback      bool
+	stagingLogs   client.StagingLogFunc
+	validate      bool
+
+	dockerUsername    string
+	dockerPassword    string
+	dockerPreflightFn DockerPreflight
 }
 
 // NewAppPushOperation creates a new AppPushOperation
@@ -51,7 +67,7 @@ func NewAppPushOperation(client *client.Client, orgName, spaceName string) *AppP
 }
 func (p *AppPushOperation) WithStrategy(s StrategyMode) {
 	switch s {
-	case StrategyBlueGreen, StrategyRolling:
+	case StrategyBlueGreen, StrategyRolling, StrategyCanary:
 		p.strategy = s
 	default:
 		p.strategy = StrategyNone
@@ -64,12 +80,90 @@ func (p *AppPushOperation) WithBlueGreenStrategy(timeout uint, checkInterval uin
 	p.checkInterval = checkInterval
 }
 
+// WithCanaryStrategy pushes a new version of a running app using a canary deployment. The deployment pauses after
+// each step's instance weight is deployed, and the gate decides whether to continue or cancel the deployment. The
+// CC deploys a single canary instance when no steps are specified, and every pause is continued when the gate is nil.
+func (p *AppPushOperation) WithCanaryStrategy(steps []resource.CanaryStep, gate CanaryGate) {
+	p.strategy = StrategyCanary
+	p.canarySteps = steps
+	p.canaryGate = gate
+}
+
+// WithRollback snapshots the app's droplet, revision, environment variables, routes and generated manifest before
+// pushing and restores them if the push fails. An app created by the failed push is deleted. It's ignored by the
+// blue-green strategy, which replaces the app instead of updating it.
+func (p *AppPushOperation) WithRollback(rollback bool) {
+	p.rollback = rollback
+}
+
+// WithManifestValidation validates the manifest client-side before pushing, so a push with an invalid manifest
+// fails with a *ManifestValidationError listing every problem before the app is changed. The validation is
+// stricter than the CC for some fields, so it's off by default.
+func (p *AppPushOperation) WithManifestValidation(validate bool) {
+	p.validate = validate
+}
+
+// WithStagingLogs streams the staging logs of each build to logFn, use client.StagingLogWriter to write them to
+// an io.Writer. When staging fails the error includes the last lines of the staging log.
+func (p *AppPushOperation) WithStagingLogs(logFn client.StagingLogFunc) {
+	p.stagingLogs = logFn
+}
+
+// WithDockerCredentials sets the registry credentials of docker images for apps whose manifest doesn't include
+// them, instead of reading the password from the CF_DOCKER_PASSWORD env var
+func (p *AppPushOperation) WithDockerCredentials(username, password string) {
+	p.dockerUsername = username
+	p.dockerPassword = password
+}
+
+// WithDockerPreflight calls preflight with the parsed image reference before each docker image is staged, which
+// can stop the push by returning an error
+func (p *AppPushOperation) WithDockerPreflight(preflight DockerPreflight) {
+	p.dockerPreflightFn = preflight
+}
+
 func (p *AppPushOperation) WithNoStart(stopped bool) {
 	p.stopped = stopped
 }
 
 // Push creates or updates an application using the specified manifest and zipped source files
 func (p *AppPushOperation) Push(ctx context.Context, appManifest *AppManifest, zipFile io.Reader) (*resource.App, error) {
+	return p.push(ctx, "Push", appManifest, zipBits{zipFile: zipFile})
+}
+
+// PushDir creates or updates an application using the specified manifest and the files in the application
+// directory, excluding any paths listed in its .cfignore file.
+//
+// Only the files the CF API doesn't already have cached are zipped and uploaded, so pushing an app that has
+// mostly unchanged files, like the dependencies of a Java app, uploads far less than Push.
+func (p *AppPushOperation) PushDir(ctx context.Context, appManifest *AppManifest, dir string) (*resource.App, error) {
+	return p.push(ctx, "PushDir", appManifest, dirBits{dir: dir})
+}
+
+func (p *AppPushOperation) push(ctx context.Context, operation string, appManifest *AppManifest, bits appBits) (app *resource.App, err error) {
+	if p.validate {
+		if err = appManifest.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	if appManifest.Docker != nil {
+		// fail before changing the app if the credentials are incomplete
+		if _, _, err = p.dockerCredentials(appManifest.Docker); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx, span := p.startSpan(ctx, operation,
+		telemetry.AttrOrgName.String(p.orgName),
+		telemetry.AttrSpaceName.String(p.spaceName),
+		telemetry.AttrAppName.String(appManifest.Name))
+	defer func() {
+		if app != nil {
+			span.SetAttributes(telemetry.AttrResourceGUID.String(app.GUID))
+		}
+		telemetry.End(span, err)
+	}()
+
 	org, err := p.findOrg(ctx)
 	if err != nil {
 		return nil, err
@@ -78,26 +172,40 @@ func (p *AppPushOperation) Push(ctx context.Context, appManifest *AppManifest, z
 	if err != nil {
 		return nil, err
 	}
-	return p.pushWithStrategyApp(ctx, space, appManifest, zipFile)
+	if !p.rollback || p.strategy == StrategyBlueGreen {
+		return p.pushWithStrategyApp(ctx, space, appManifest, bits)
+	}
+
+	snapshot, err := p.snapshotApp(ctx, space, appManifest.Name)
+	if err != nil {
+		return nil, err
+	}
+	app, err = p.pushWithStrategyApp(ctx, space, appManifest, bits)
+	if err != nil {
+		// restore even if the push was cancelled, so the app isn't left half updated
+		rollbackErr := p.restoreSnapshot(context.WithoutCancel(ctx), space, snapshot, err)
+		return nil, &PushRollbackError{App: appManifest.Name, Err: err, RollbackErr: rollbackErr}
+	}
+	return app, nil
 }
-func (p *AppPushOperation) pushWithStrategyApp(ctx context.Context, space *resource.Space, manifest *AppManifest, zipFile io.Reader) (*resource.App, error) {
+func (p *AppPushOperation) pushWithStrategyApp(ctx context.Context, space *resource.Space, manifest *AppManifest, bits appBits) (*resource.App, error) {
 	switch p.strategy {
 	case StrategyBlueGreen:
-		return p.pushBlueGreenApp(ctx, space, manifest, zipFile)
-	case StrategyRolling:
-		return p.pushRollingApp(ctx, space, manifest, zipFile)
+		return p.pushBlueGreenApp(ctx, space, manifest, bits)
+	case StrategyRolling, StrategyCanary:
+		return p.pushRollingApp(ctx, space, manifest, bits)
 	default:
-		return p.pushApp(ctx, space, manifest, zipFile)
+		return p.pushApp(ctx, space, manifest, bits)
 	}
 }
 
-func (p *AppPushOperation) pushBlueGreenApp(ctx context.Context, space *resource.Space, manifest *AppManifest, zipFile io.Reader) (*resource.App, error) {
+func (p *AppPushOperation) pushBlueGreenApp(ctx context.Context, space *resource.Space, manifest *AppManifest, bits appBits) (*resource.App, error) {
 	originalApp, err := p.findApp(ctx, manifest.Name, space)
 	if err != nil && !errors.Is(err, client.ErrExactlyOneResultNotReturned) {
 		return nil, err
 	}
 	if errors.Is(err, client.ErrExactlyOneResultNotReturned) || originalApp.State != "STARTED" {
-		return p.pushApp(ctx, space, manifest, zipFile)
+		return p.pushApp(ctx, space, manifest, bits)
 	}
 
 	if p.stopped {
@@ -129,7 +237,7 @@ func (p *AppPushOperation) pushBlueGreenApp(ctx context.Context, space *resource
 		return nil, fmt.Errorf("failed to update: %s, failed with: %w", tempAppName, updateErr)
 	}
 
-	newApp, pushError := p.pushApp(ctx, space, manifest, zipFile)
+	newApp, pushError := p.pushApp(ctx, space, manifest, bits)
 	if pushError != nil {
 		// If push fails delete new application and change back original app name
 		invalidNewApp, findAppErr := p.findApp(ctx, originalAppName, space)
@@ -198,13 +306,13 @@ func (p *AppPushOperation) getAppDeployedRunningPollingOptions() *client.Polling
 	return pollOptions
 }
 
-func (p *AppPushOperation) pushRollingApp(ctx context.Context, space *resource.Space, manifest *AppManifest, zipFile io.Reader) (*resource.App, error) {
+func (p *AppPushOperation) pushRollingApp(ctx context.Context, space *resource.Space, manifest *AppManifest, bits appBits) (*resource.App, error) {
 	originalApp, err := p.findApp(ctx, manifest.Name, space)
 	if err != nil && !errors.Is(err, client.ErrExactlyOneResultNotReturned) {
 		return nil, err
 	}
 	if errors.Is(err, client.ErrExactlyOneResultNotReturned) || originalApp.State != "STARTED" {
-		return p.pushApp(ctx, space, manifest, zipFile)
+		return p.pushApp(ctx, space, manifest, bits)
 	}
 
 	if p.stopped {
@@ -231,17 +339,17 @@ func (p *AppPushOperation) pushRollingApp(ctx context.Context, space *resource.S
 		case Docker:
 			pkg, err = p.uploadDockerPackage(ctx, originalApp, manifest.Docker)
 		case Buildpack, CNB:
-			pkg, err = p.uploadBitsPackage(ctx, originalApp, zipFile)
+			pkg, err = p.uploadBitsPackage(ctx, originalApp, bits)
 		default:
 			// Default to buildpack for unknown lifecycle types
-			pkg, err = p.uploadBitsPackage(ctx, originalApp, zipFile)
+			pkg, err = p.uploadBitsPackage(ctx, originalApp, bits)
 		}
 	} else {
 		// Fall back to old logic when lifecycle is not set
 		if manifest.Docker != nil {
 			pkg, err = p.uploadDockerPackage(ctx, originalApp, manifest.Docker)
 		} else {
-			pkg, err = p.uploadBitsPackage(ctx, originalApp, zipFile)
+			pkg, err = p.uploadBitsPackage(ctx, originalApp, bits)
 		}
 	}
 	if err != nil {
@@ -257,6 +365,9 @@ func (p *AppPushOperation) pushRollingApp(ctx context.Context, space *resource.S
 	if err != nil {
 		return nil, fmt.Errorf("failed to deploy with: %w", err)
 	}
+	if p.strategy == StrategyCanary {
+		return p.finishCanaryDeployment(ctx, space, manifest, deployment)
+	}
 	// In case application crashed due to new deployment, deployment will be stuck with value "ACTIVE" and reason "DEPLOYING"
 	// This will be considered as deployment failed after timeout
 	depPollErr := p.waitForDeployment(ctx, deployment.GUID, *manifest.Instances)
@@ -275,34 +386,34 @@ func (p *AppPushOperation) pushRollingApp(ctx context.Context, space *resource.S
 		if depRollPollErr != nil {
 			return nil, fmt.Errorf("failed to deploy with: %w \nfailed to confirm roll back to last deployment with: %w", depPollErr, depRollPollErr)
 		}
-		return nil, fmt.Errorf("failed to deploy with: %w \nrolled back to last deployment", depPollErr)
+		return nil, &deploymentRolledBackError{err: fmt.Errorf("failed to deploy with: %w \nrolled back to last deployment", depPollErr)}
 	}
 
 	return originalApp, nil
 }
 
-// Poll for deployment status and wait for the deployment to be in the final state
+// Poll for deployment status and wait for the deployment to be deployed
 // Timeout is calculated based on the number of instances
-func (p *AppPushOperation) waitForDeployment(ctx context.Context, deploymentGUID string, instances uint) error {
-	// If instances is not set default to 1
-	if instances == 0 {
-		instances = 1
-	}
-	pollOptions := client.NewPollingOptions()
-	pollOptions.Timeout = time.Duration(instances) * time.Minute
+func (p *AppPushOperation) waitForDeployment(ctx context.Context, deploymentGUID string, instances uint) (err error) {
+	ctx, span := p.startSpan(ctx, "waitForDeployment", telemetry.AttrResourceGUID.String(deploymentGUID))
+	defer func() { telemetry.End(span, err) }()
 
-	depPollErr := client.PollForStateOrTimeout(func() (string, string, error) {
-		deployment, err := p.client.Deployments.Get(ctx, deploymentGUID)
+	for event, err := range p.client.Deployments.Watch(ctx, deploymentGUID, deploymentPollingOptions(instances)) {
 		if err != nil {
-			return "", "", err
+			return err
 		}
-		return deployment.Status.Value, deployment.Status.Reason, nil
-	}, "FINALIZED", pollOptions)
-	return depPollErr
+		if event.Type == client.DeploymentEventDeployed {
+			return nil
+		}
+	}
+	return nil
 }
 
-func (p *AppPushOperation) createNewDeployment(ctx context.Context, originalApp *resource.App, droplet *resource.Droplet) (*resource.Deployment, error) {
-	return p.client.Deployments.Create(ctx, &resource.DeploymentCreate{
+func (p *AppPushOperation) createNewDeployment(ctx context.Context, originalApp *resource.App, droplet *resource.Droplet) (_ *resource.Deployment, err error) {
+	ctx, span := p.startSpan(ctx, "createDeployment", telemetry.AttrResourceGUID.String(originalApp.GUID))
+	defer func() { telemetry.End(span, err) }()
+
+	deploymentCreate := &resource.DeploymentCreate{
 		Relationships: resource.AppRelationship{
 			App: resource.ToOneRelationship{
 				Data: &resource.Relationship{
@@ -313,10 +424,126 @@ func (p *AppPushOperation) createNewDeployment(ctx context.Context, originalApp
 		Droplet: &resource.Relationship{
 			GUID: droplet.GUID,
 		},
-	})
+		Strategy: "rolling",
+	}
+	if p.strategy == StrategyCanary {
+		deploymentCreate.Strategy = "canary"
+		if len(p.canarySteps) > 0 {
+			deploymentCreate.Options = &resource.DeploymentOptions{
+				Canary: &resource.DeploymentCanaryOptions{
+					Steps: p.canarySteps,
+				},
+			}
+		}
+	}
+	return p.client.Deployments.Create(ctx, deploymentCreate)
 }
 
-func (p *AppPushOperation) rollBackDeployment(ctx context.Context, originalApp *resource.App, fallbackRevision *resource.Revision) (*resource.Deployment, error) {
+// finishCanaryDeployment waits for the canary deployment, calling the gate at each pause, and cancels it if the
+// gate rejects it, it fails or the app isn't started afterwards
+func (p *AppPushOperation) finishCanaryDeployment(ctx context.Context, space *resource.Space, manifest *AppManifest, deployment *resource.Deployment) (*resource.App, error) {
+	var instances uint
+	if manifest.Instances != nil {
+		instances = *manifest.Instances
+	}
+	depErr := p.waitForCanaryDeployment(ctx, deployment.GUID, instances)
+	if depErr == nil {
+		app, err := p.findApp(ctx, manifest.Name, space)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify application status with: %w", err)
+		}
+		if app.State == "STARTED" {
+			return app, nil
+		}
+		depErr = fmt.Errorf("application %s is %s after the deployment", app.Name, app.State)
+	}
+
+	// cancel even if the push was cancelled, so the app is left running the previous droplet
+	if cancelErr := p.cancelDeployment(context.WithoutCancel(ctx), deployment.GUID, instances); cancelErr != nil {
+		return nil, fmt.Errorf("canary deployment failed with: %w \nfailed to cancel the deployment with: %w", depErr, cancelErr)
+	}
+	return nil, &deploymentRolledBackError{err: fmt.Errorf("canary deployment failed with: %w \ncancelled the deployment", depErr)}
+}
+
+// waitForCanaryDeployment waits for the deployment to be deployed, calling the gate and continuing the deployment
+// each time it pauses at a new step. The timeout applies to each step, so it doesn't include the time in the gate.
+func (p *AppPushOperation) waitForCanaryDeployment(ctx context.Context, deploymentGUID string, instances uint) (err error) {
+	ctx, span := p.startSpan(ctx, "waitForCanaryDeployment", telemetry.AttrResourceGUID.String(deploymentGUID))
+	defer func() { telemetry.End(span, err) }()
+
+	// the deployment can still report the pause it was continued from, so each step is only gated once
+	gatedStep := -1
+	for {
+		var paused *client.DeploymentEvent
+		for event, err := range p.client.Deployments.Watch(ctx, deploymentGUID, deploymentPollingOptions(instances)) {
+			if err != nil {
+				return err
+			}
+			if event.Type == client.DeploymentEventDeployed {
+				return nil
+			}
+			if event.Type == client.DeploymentEventPaused && event.CanaryStep != gatedStep {
+				paused = event
+				break
+			}
+		}
+		if paused == nil {
+			return nil
+		}
+
+		gatedStep = paused.CanaryStep
+		if p.canaryGate != nil {
+			if err = p.canaryGate(ctx, paused.Deployment); err != nil {
+				return fmt.Errorf("canary deployment rejected at step %d: %w", gatedStep, err)
+			}
+		}
+		if err = p.client.Deployments.Continue(ctx, deploymentGUID); err != nil {
+			return fmt.Errorf("failed to continue the canary deployment with: %w", err)
+		}
+	}
+}
+
+// cancelDeployment cancels the deployment and waits for the app to be rolled back to its previous droplet. A
+// deployment that already finished can't be cancelled, so it returns an error unless the deployment was cancelled.
+func (p *AppPushOperation) cancelDeployment(ctx context.Context, deploymentGUID string, instances uint) (err error) {
+	ctx, span := p.startSpan(ctx, "cancelDeployment", telemetry.AttrResourceGUID.String(deploymentGUID))
+	defer func() { telemetry.End(span, err) }()
+
+	deployment, err := p.client.Deployments.Get(ctx, deploymentGUID)
+	if err != nil {
+		return err
+	}
+	if deployment.Status.Value != resource.DeploymentStatusValueFinalized {
+		if err = p.client.Deployments.Cancel(ctx, deploymentGUID); err != nil {
+			return err
+		}
+	}
+	for event, err := range p.client.Deployments.Watch(ctx, deploymentGUID, deploymentPollingOptions(instances)) {
+		if event != nil && event.Type == client.DeploymentEventCanceled {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return errors.New("the deployment finished without being cancelled")
+}
+
+// deploymentPollingOptions returns the options to wait for a deployment, the timeout is a minute per instance
+func deploymentPollingOptions(instances uint) *client.PollingOptions {
+	// If instances is not set default to 1
+	if instances == 0 {
+		instances = 1
+	}
+	pollOptions := client.NewPollingOptions()
+	pollOptions.Timeout = time.Duration(instances) * time.Minute
+	return pollOptions
+}
+
+func (p *AppPushOperation) rollBackDeployment(ctx context.Context, originalApp *resource.App, fallbackRevision *resource.Revision) (_ *resource.Deployment, err error) {
+	ctx, span := p.startSpan(ctx, "rollBackDeployment", telemetry.AttrResourceGUID.String(originalApp.GUID))
+	defer func() { telemetry.End(span, err) }()
+
 	return p.client.Deployments.Create(ctx, &resource.DeploymentCreate{
 		Relationships: resource.AppRelationship{
 			App: resource.ToOneRelationship{
@@ -359,15 +586,24 @@ func (p *AppPushOperation) gracefulDeletion(ctx context.Context, app *resource.A
 // an application to be deployed or tasks to be run. The current droplet must be assigned to an application before
 // it may be started. When tasks are created, they either use a specific droplet guid, or use the current droplet
 // assigned to an application.
-func (p *AppPushOperation) pushApp(ctx context.Context, space *resource.Space, manifest *AppManifest, zipFile io.Reader) (*resource.App, error) {
+func (p *AppPushOperation) pushApp(ctx context.Context, space *resource.Space, manifest *AppManifest, bits appBits) (*resource.App, error) {
 	err := p.applySpaceManifest(ctx, space, manifest)
 	if err != nil {
 		return nil, err
 	}
+	app, appWasStarted, err := p.stageApp(ctx, space, manifest, bits)
+	if err != nil {
+		return nil, err
+	}
+	return p.startApp(ctx, app, appWasStarted)
+}
 
+// stageApp uploads and stages a package for an app that already exists from applying the manifest, then sets
+// the resulting droplet as the app's current droplet. It also returns whether the app was started before.
+func (p *AppPushOperation) stageApp(ctx context.Context, space *resource.Space, manifest *AppManifest, bits appBits) (*resource.App, bool, error) {
 	app, err := p.findApp(ctx, manifest.Name, space)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 	appWasStarted := app.State == "STARTED"
 
@@ -378,33 +614,37 @@ func (p *AppPushOperation) pushApp(ctx context.Context, space *resource.Space, m
 		case Docker:
 			pkg, err = p.uploadDockerPackage(ctx, app, manifest.Docker)
 		case Buildpack, CNB:
-			pkg, err = p.uploadBitsPackage(ctx, app, zipFile)
+			pkg, err = p.uploadBitsPackage(ctx, app, bits)
 		default:
 			// Default to buildpack for unknown lifecycle types
-			pkg, err = p.uploadBitsPackage(ctx, app, zipFile)
+			pkg, err = p.uploadBitsPackage(ctx, app, bits)
 		}
 	} else {
 		// Fall back to old logic when lifecycle is not set
 		if app.Lifecycle.Type == resource.LifecycleDocker.String() {
 			pkg, err = p.uploadDockerPackage(ctx, app, manifest.Docker)
 		} else {
-			pkg, err = p.uploadBitsPackage(ctx, app, zipFile)
+			pkg, err = p.uploadBitsPackage(ctx, app, bits)
 		}
 	}
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	droplet, err := p.buildDroplet(ctx, pkg, manifest)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	_, err = p.client.Droplets.SetCurrentAssociationForApp(ctx, app.GUID, droplet.GUID)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
+	return app, appWasStarted, nil
+}
 
+// startApp starts or restarts the app with its new droplet, or stops it if the no start option is set
+func (p *AppPushOperation) startApp(ctx context.Context, app *resource.App, appWasStarted bool) (*resource.App, error) {
 	if p.stopped {
 		return p.client.Applications.Stop(ctx, app.GUID)
 	}
@@ -416,18 +656,30 @@ func (p *AppPushOperation) pushApp(ctx context.Context, space *resource.Space, m
 
 func (p *AppPushOperation) applySpaceManifest(ctx context.Context, space *resource.Space, manifest *AppManifest) error {
 	// wrap it in a manifest that has an applications array as required by the API
-	multiAppsManifest := &Manifest{
+	return p.applyManifest(ctx, space, &Manifest{
 		Applications: []*AppManifest{manifest},
-	}
-	manifestBytes, err := yaml.Marshal(&multiAppsManifest)
+	})
+}
+
+// applyManifest applies the manifest to the space, creating or updating every app it contains
+func (p *AppPushOperation) applyManifest(ctx context.Context, space *resource.Space, manifest *Manifest) error {
+	manifestBytes, err := yaml.Marshal(manifest)
 	if err != nil {
 		return fmt.Errorf("error marshalling application manifest: %w", err)
 	}
+	return p.applyManifestYAML(ctx, space, string(manifestBytes))
+}
+
+// applyManifestYAML applies the YAML manifest to the space and waits for the job to complete
+func (p *AppPushOperation) applyManifestYAML(ctx context.Context, space *resource.Space, manifest string) (err error) {
+	ctx, span := p.startSpan(ctx, "applySpaceManifest", telemetry.AttrResourceGUID.String(space.GUID))
+	defer func() { telemetry.End(span, err) }()
 
-	jobGUID, err := p.client.Manifests.ApplyManifest(ctx, space.GUID, string(manifestBytes))
+	jobGUID, err := p.client.Manifests.ApplyManifest(ctx, space.GUID, manifest)
 	if err != nil {
 		return fmt.Errorf("error applying application manifest to space %s: %w", space.Name, err)
 	}
+	span.SetAttributes(telemetry.AttrJobGUID.String(jobGUID))
 	err = p.client.Jobs.PollComplete(ctx, jobGUID, nil)
 	if err != nil {
 		return fmt.Errorf("error waiting for application manifest to finish applying to space %s: %w", space.Name, err)
@@ -446,22 +698,46 @@ func (p *AppPushOperation) findApp(ctx context.Context, appName string, space *r
 	return app, nil
 }
 
-func (p *AppPushOperation) uploadDockerPackage(ctx context.Context, app *resource.App, docker *AppManifestDocker) (*resource.Package, error) {
-	newPkg := resource.NewDockerPackageCreate(app.GUID, docker.Image, docker.Username, os.Getenv("CF_DOCKER_PASSWORD"))
+func (p *AppPushOperation) uploadDockerPackage(ctx context.Context, app *resource.App, docker *AppManifestDocker) (_ *resource.Package, err error) {
+	ctx, span := p.startSpan(ctx, "uploadPackage", telemetry.AttrResourceGUID.String(app.GUID))
+	defer func() { telemetry.End(span, err) }()
+
+	if docker == nil {
+		return nil, errDockerImageRequired
+	}
+	image, err := p.dockerPreflight(ctx, app.Name, docker)
+	if err != nil {
+		return nil, err
+	}
+	span.SetAttributes(telemetry.AttrDockerImage.String(image.Reference))
+	username, password, err := p.dockerCredentials(docker)
+	if err != nil {
+		return nil, err
+	}
+
+	newPkg := resource.NewDockerPackageCreate(app.GUID, docker.Image, username, password)
 	pkg, err := p.client.Packages.Create(ctx, newPkg)
 	if err != nil {
-		return nil, fmt.Errorf("error creating docker package for app %s: %w", app.Name, err)
+		return nil, redactError(fmt.Errorf("error creating docker package for app %s: %w", app.Name, err), password)
 	}
 	return pkg, nil
 }
 
-func (p *AppPushOperation) uploadBitsPackage(ctx context.Context, app *resource.App, zipFile io.Reader) (*resource.Package, error) {
+func (p *AppPushOperation) uploadBitsPackage(ctx context.Context, app *resource.App, bits appBits) (_ *resource.Package, err error) {
+	ctx, span := p.startSpan(ctx, "uploadPackage", telemetry.AttrResourceGUID.String(app.GUID))
+	defer func() { telemetry.End(span, err) }()
+
+	// a docker app has no files, i.e. when its manifest doesn't set the docker lifecycle and the existing app
+	// was created with the buildpack lifecycle
+	if bits == nil {
+		return nil, fmt.Errorf("app %s has no files to upload, set the manifest's lifecycle to docker to stage its docker image", app.Name)
+	}
 	newPkg := resource.NewPackageCreate(app.GUID)
 	pkg, err := p.client.Packages.Create(ctx, newPkg)
 	if err != nil {
 		return nil, fmt.Errorf("error creating package bits for app %s: %w", app.Name, err)
 	}
-	_, err = p.client.Packages.Upload(ctx, pkg.GUID, zipFile)
+	err = bits.upload(ctx, p.client, pkg.GUID)
 	if err != nil {
 		return nil, fmt.Errorf("error uploading package bits for app %s: %w", app.Name, err)
 	}
@@ -472,7 +748,10 @@ func (p *AppPushOperation) uploadBitsPackage(ctx context.Context, app *resource.
 	return pkg, nil
 }
 
-func (p *AppPushOperation) buildDroplet(ctx context.Context, pkg *resource.Package, manifest *AppManifest) (*resource.Droplet, error) {
+func (p *AppPushOperation) buildDroplet(ctx context.Context, pkg *resource.Package, manifest *AppManifest) (_ *resource.Droplet, err error) {
+	ctx, span := p.startSpan(ctx, "buildDroplet", telemetry.AttrResourceGUID.String(pkg.GUID))
+	defer func() { telemetry.End(span, err) }()
+
 	newBuild := resource.NewBuildCreate(pkg.GUID)
 
 	// Check if lifecycle is explicitly set in manifest first
@@ -524,7 +803,11 @@ func (p *AppPushOperation) buildDroplet(ctx context.Context, pkg *resource.Packa
 	if err != nil {
 		return nil, fmt.Errorf("error creating build from package for app %s: %w", manifest.Name, err)
 	}
-	err = p.client.Builds.PollStaged(ctx, build.GUID, nil)
+	if p.stagingLogs != nil {
+		err = p.client.Builds.PollStagedWithLogs(ctx, build.GUID, nil, p.stagingLogs)
+	} else {
+		err = p.client.Builds.PollStaged(ctx, build.GUID, nil)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("error while waiting for app %s package to build: %w", manifest.Name, err)
 	}
@@ -559,8 +842,11 @@ func (p *AppPushOperation) findSpace(ctx context.Context, orgGUID string) (*reso
 	return space, nil
 }
 
-func (p *AppPushOperation) waitForAppHealthy(ctx context.Context, app *resource.App, pollOptions *client.PollingOptions) error {
-	appPollErr := client.PollForStateOrTimeout(func() (string, string, error) {
+func (p *AppPushOperation) waitForAppHealthy(ctx context.Context, app *resource.App, pollOptions *client.PollingOptions) (err error) {
+	ctx, span := p.startSpan(ctx, "waitForAppHealthy", telemetry.AttrResourceGUID.String(app.GUID))
+	defer func() { telemetry.End(span, err) }()
+
+	appPollErr := client.PollForState(ctx, func(ctx context.Context) (string, string, error) {
 		procData, err := p.client.Processes.GetStatsForApp(ctx, app.GUID, "web")
 		if err != nil {
 			return "FAILED", "Failed to get processes stats for application", err
@@ -574,3 +860,8 @@ func (p *AppPushOperation) waitForAppHealthy(ctx context.Context, app *resource.
 	}, "RUNNING", pollOptions)
 	return appPollErr
 }
+
+// startSpan starts a child span for a stage of the push operation
+func (p *AppPushOperation) startSpan(ctx context.Context, stage string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return telemetry.Start(ctx, p.client.Tracer(), "operation.AppPushOperation."+stage, attrs...)
+}
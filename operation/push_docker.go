@@ -0,0 +1,161 @@
+package operation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	internal "github.com/cloudfoundry/go-cfclient/v3/internal/http"
+)
+
+// DockerPasswordEnvVar is the environment variable the docker registry password is read from when it isn't
+// passed explicitly, like the CF CLI
+const DockerPasswordEnvVar = "CF_DOCKER_PASSWORD"
+
+// defaultDockerRegistry is the registry of images without a registry host, i.e. nginx:latest
+const defaultDockerRegistry = "docker.io"
+
+var errDockerImageRequired = errors.New("the app's manifest doesn't have a docker image")
+
+// DockerImage is a docker image that's about to be staged
+type DockerImage struct {
+	// Reference is the image as given in the manifest, i.e. registry.example.org/team/api:1.2
+	Reference string
+
+	// Registry is the host of the image's registry, docker.io when the reference doesn't include one
+	Registry string
+
+	// Repository is the image name within the registry, i.e. team/api or library/nginx
+	Repository string
+
+	// Tag is the image tag, latest when the reference has neither a tag nor a digest
+	Tag string
+
+	// Digest is the image digest, i.e. sha256:0123..., if the reference includes one
+	Digest string
+
+	// Username is the registry username, empty for a public image
+	Username string
+}
+
+// DockerPreflight is called before an app's docker image is staged. Returning an error stops the push, i.e. to
+// only allow images from trusted registries.
+type DockerPreflight func(ctx context.Context, appName string, image *DockerImage) error
+
+// dockerReferencePattern matches [registry/]repository[:tag][@digest]
+var dockerReferencePattern = regexp.MustCompile(
+	`^(?:([a-zA-Z0-9.-]+(?::[0-9]+)?)/)?([a-z0-9]+(?:[._-]+[a-z0-9]+)*(?:/[a-z0-9]+(?:[._-]+[a-z0-9]+)*)*)(?::([\w][\w.-]{0,127}))?(?:@([a-z0-9]+:[a-fA-F0-9]{32,}))?$`)
+
+// ParseDockerImage splits a docker image reference into its registry, repository, tag and digest
+func ParseDockerImage(reference string) (*DockerImage, error) {
+	m := dockerReferencePattern.FindStringSubmatch(reference)
+	if m == nil {
+		return nil, fmt.Errorf("invalid docker image reference %q", reference)
+	}
+	image := &DockerImage{
+		Reference:  reference,
+		Registry:   m[1],
+		Repository: m[2],
+		Tag:        m[3],
+		Digest:     m[4],
+	}
+
+	// like docker, the first component is only a registry if it looks like a host
+	if image.Registry != "" && !strings.ContainsAny(image.Registry, ".:") && image.Registry != "localhost" {
+		if strings.ToLower(image.Registry) != image.Registry {
+			return nil, fmt.Errorf("invalid docker image reference %q", reference)
+		}
+		image.Repository = image.Registry + "/" + image.Repository
+		image.Registry = ""
+	}
+	if image.Registry == "" {
+		image.Registry = defaultDockerRegistry
+		if !strings.Contains(image.Repository, "/") {
+			image.Repository = "library/" + image.Repository
+		}
+	}
+	if image.Tag == "" && image.Digest == "" {
+		image.Tag = "latest"
+	}
+	return image, nil
+}
+
+// dockerCredentials returns the registry username and password for the app's image. The manifest's credentials
+// take precedence over WithDockerCredentials, and the password falls back to the CF_DOCKER_PASSWORD env var.
+func (p *AppPushOperation) dockerCredentials(docker *AppManifestDocker) (username, password string, err error) {
+	username, password = docker.Username, docker.Password
+	if username == "" {
+		username = p.dockerUsername
+	}
+	if password == "" {
+		password = p.dockerPassword
+	}
+	if password == "" && username != "" {
+		password = os.Getenv(DockerPasswordEnvVar)
+	}
+	switch {
+	case username != "" && password == "":
+		return "", "", fmt.Errorf("the docker image %s has a username but no password, pass it explicitly or set %s",
+			docker.Image, DockerPasswordEnvVar)
+	case username == "" && password != "":
+		return "", "", fmt.Errorf("the docker image %s has a password but no username", docker.Image)
+	}
+	return username, password, nil
+}
+
+// dockerPreflight checks the app's docker image and credentials before the image is staged, calling the
+// DockerPreflight if one was set
+func (p *AppPushOperation) dockerPreflight(ctx context.Context, appName string, docker *AppManifestDocker) (*DockerImage, error) {
+	image, err := ParseDockerImage(docker.Image)
+	if err != nil {
+		return nil, err
+	}
+	if image.Username, _, err = p.dockerCredentials(docker); err != nil {
+		return nil, err
+	}
+	if p.dockerPreflightFn != nil {
+		if err = p.dockerPreflightFn(ctx, appName, image); err != nil {
+			return nil, fmt.Errorf("docker image %s for app %s was rejected: %w", image.Reference, appName, err)
+		}
+	}
+	return image, nil
+}
+
+// redactedError hides a secret, i.e. a registry password, that an error message might contain
+type redactedError struct {
+	err    error
+	secret string
+}
+
+// redactError returns an error that replaces the secret with a placeholder in its message
+func redactError(err error, secret string) error {
+	if err == nil || secret == "" {
+		return err
+	}
+	return &redactedError{err: err, secret: secret}
+}
+
+func (e *redactedError) Error() string {
+	return strings.ReplaceAll(e.err.Error(), e.secret, internal.RedactedValue)
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+// String returns the docker settings with the password hidden, so the manifest can be logged safely
+func (d AppManifestDocker) String() string {
+	password := ""
+	if d.Password != "" {
+		password = internal.RedactedValue
+	}
+	return fmt.Sprintf("{Image:%s Username:%s Password:%s}", d.Image, d.Username, password)
+}
+
+// GoString hides the password when the docker settings are formatted with %#v
+func (d AppManifestDocker) GoString() string {
+	return "operation.AppManifestDocker" + d.String()
+}
@@ -0,0 +1,169 @@
+package operation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/cloudfoundry/go-cfclient/v3/client"
+	"github.com/cloudfoundry/go-cfclient/v3/config"
+	"github.com/cloudfoundry/go-cfclient/v3/resource"
+	"github.com/cloudfoundry/go-cfclient/v3/testutil"
+)
+
+func TestParseDockerImage(t *testing.T) {
+	tests := []struct {
+		reference string
+		expected  *DockerImage
+	}{
+		{"nginx", &DockerImage{Registry: "docker.io", Repository: "library/nginx", Tag: "latest"}},
+		{"kennethreitz/httpbin:v2", &DockerImage{Registry: "docker.io", Repository: "kennethreitz/httpbin", Tag: "v2"}},
+		{"registry.example.org/team/api:1.2", &DockerImage{Registry: "registry.example.org", Repository: "team/api", Tag: "1.2"}},
+		{"localhost:5000/api", &DockerImage{Registry: "localhost:5000", Repository: "api", Tag: "latest"}},
+		{"ghcr.io/org/api@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+			&DockerImage{Registry: "ghcr.io", Repository: "org/api", Digest: "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.reference, func(t *testing.T) {
+			image, err := ParseDockerImage(tt.reference)
+			require.NoError(t, err)
+			tt.expected.Reference = tt.reference
+			require.Equal(t, tt.expected, image)
+		})
+	}
+
+	for _, reference := range []string{"", "Nginx", "nginx:", "registry.example.org/", "api@sha256:123"} {
+		_, err := ParseDockerImage(reference)
+		require.Error(t, err, reference)
+	}
+}
+
+// fakeDockerAPI records the docker package created by a push, optionally rejecting it
+type fakeDockerAPI struct {
+	fakeManifestPushAPI
+	reject  bool
+	created *resource.PackageCreate
+}
+
+func (f *fakeDockerAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.URL.Path != "/v3/packages" {
+		f.fakeManifestPushAPI.ServeHTTP(w, r)
+		return
+	}
+	f.created = &resource.PackageCreate{}
+	_ = json.NewDecoder(r.Body).Decode(f.created)
+	w.Header().Set("Content-Type", "application/json")
+	if f.reject {
+		// registries' errors can echo the credentials
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = fmt.Fprintf(w, `{"errors":[{"code":10008,"title":"CF-UnprocessableEntity","detail":"login failed with %s"}]}`,
+			f.created.Data.Password)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	_, _ = w.Write([]byte(`{"guid":"api","type":"docker","state":"READY","data":{"image":"registry.example.org/team/api:1.2"}}`))
+}
+
+func TestAppPushDockerCredentials(t *testing.T) {
+	uaaURL := testutil.SetupFakeUAAServer(300)
+	defer testutil.Teardown()
+	push := func(t *testing.T, fake *fakeDockerAPI, docker *AppManifestDocker, configure func(p *AppPushOperation)) error {
+		api := httptest.NewServer(fake)
+		t.Cleanup(api.Close)
+		cfg, err := config.New(api.URL, config.ClientCredentials("cf", "secret"), config.AuthTokenURL(uaaURL, uaaURL))
+		require.NoError(t, err)
+		cf, err := client.New(cfg)
+		require.NoError(t, err)
+		pusher := NewAppPushOperation(cf, "org", "space")
+		if configure != nil {
+			configure(pusher)
+		}
+		manifest := NewAppManifest("api")
+		manifest.Lifecycle = Docker
+		manifest.Docker = docker
+		_, err = pusher.Push(context.Background(), manifest, nil)
+		return err
+	}
+	credentials := func(fake *fakeDockerAPI) *resource.DockerCredentials {
+		require.NotNil(t, fake.created)
+		return fake.created.Data.DockerCredentials
+	}
+
+	t.Run("manifest password", func(t *testing.T) {
+		t.Setenv(DockerPasswordEnvVar, "env-password")
+		fake := &fakeDockerAPI{}
+		err := push(t, fake, &AppManifestDocker{Image: "registry.example.org/team/api:1.2", Username: "ci", Password: "manifest-password"},
+			func(p *AppPushOperation) { p.WithDockerCredentials("op", "op-password") })
+		require.NoError(t, err)
+		require.Equal(t, &resource.DockerCredentials{Username: "ci", Password: "manifest-password"}, credentials(fake))
+	})
+
+	t.Run("operation credentials", func(t *testing.T) {
+		t.Setenv(DockerPasswordEnvVar, "env-password")
+		fake := &fakeDockerAPI{}
+		err := push(t, fake, &AppManifestDocker{Image: "registry.example.org/team/api:1.2"},
+			func(p *AppPushOperation) { p.WithDockerCredentials("op", "op-password") })
+		require.NoError(t, err)
+		require.Equal(t, &resource.DockerCredentials{Username: "op", Password: "op-password"}, credentials(fake))
+	})
+
+	t.Run("env var fallback", func(t *testing.T) {
+		t.Setenv(DockerPasswordEnvVar, "env-password")
+		fake := &fakeDockerAPI{}
+		err := push(t, fake, &AppManifestDocker{Image: "registry.example.org/team/api:1.2", Username: "ci"}, nil)
+		require.NoError(t, err)
+		require.Equal(t, &resource.DockerCredentials{Username: "ci", Password: "env-password"}, credentials(fake))
+	})
+
+	t.Run("missing password", func(t *testing.T) {
+		t.Setenv(DockerPasswordEnvVar, "")
+		fake := &fakeDockerAPI{}
+		err := push(t, fake, &AppManifestDocker{Image: "registry.example.org/team/api:1.2", Username: "ci"}, nil)
+		require.ErrorContains(t, err, "has a username but no password")
+		require.Zero(t, fake.applied)
+	})
+
+	t.Run("redacts the password from errors", func(t *testing.T) {
+		fake := &fakeDockerAPI{reject: true}
+		err := push(t, fake, &AppManifestDocker{Image: "registry.example.org/team/api:1.2", Username: "ci", Password: "hunter2"}, nil)
+		require.ErrorContains(t, err, "login failed with [PRIVATE DATA HIDDEN]")
+		require.NotContains(t, err.Error(), "hunter2")
+		var cfErr resource.CloudFoundryError
+		require.True(t, errors.As(err, &cfErr))
+	})
+
+	t.Run("preflight", func(t *testing.T) {
+		fake := &fakeDockerAPI{}
+		var checked *DockerImage
+		err := push(t, fake, &AppManifestDocker{Image: "registry.example.org/team/api:1.2", Username: "ci", Password: "hunter2"},
+			func(p *AppPushOperation) {
+				p.WithDockerPreflight(func(ctx context.Context, appName string, image *DockerImage) error {
+					checked = image
+					return errors.New("untrusted registry")
+				})
+			})
+		require.EqualError(t, err, "docker image registry.example.org/team/api:1.2 for app api was rejected: untrusted registry")
+		require.Equal(t, &DockerImage{Reference: "registry.example.org/team/api:1.2", Registry: "registry.example.org",
+			Repository: "team/api", Tag: "1.2", Username: "ci"}, checked)
+		require.Nil(t, fake.created)
+	})
+}
+
+func TestAppManifestDockerRedacted(t *testing.T) {
+	docker := &AppManifestDocker{Image: "nginx", Username: "ci", Password: "hunter2"}
+	for _, format := range []string{"%v", "%+v", "%#v", "%s"} {
+		require.NotContains(t, fmt.Sprintf(format, docker), "hunter2", format)
+	}
+	require.NotContains(t, fmt.Sprintf("%+v", NewManifest(&AppManifest{Name: "api", Docker: docker})), "hunter2")
+
+	m, err := yaml.Marshal(NewManifest(&AppManifest{Name: "api", Docker: docker}))
+	require.NoError(t, err)
+	require.NotContains(t, string(m), "hunter2")
+}
@@ -0,0 +1,283 @@
+package operation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/cloudfoundry/go-cfclient/v3/client"
+	"github.com/cloudfoundry/go-cfclient/v3/internal/telemetry"
+	"github.com/cloudfoundry/go-cfclient/v3/resource"
+	"gopkg.in/yaml.v3"
+)
+
+// PushRollbackError is returned when a push with rollback enabled fails. It reports both why the push failed
+// and whether the app was restored to the state it had before the push.
+type PushRollbackError struct {
+	App string
+
+	// Err is why the push failed
+	Err error
+
+	// RollbackErr is why restoring the app failed, or nil if the app was restored
+	RollbackErr error
+}
+
+func (e *PushRollbackError) Error() string {
+	if e.RollbackErr != nil {
+		return fmt.Sprintf("failed to push application %s: %s\nfailed to roll back the application: %s", e.App, e.Err, e.RollbackErr)
+	}
+	return fmt.Sprintf("failed to push application %s: %s\nrolled back the application to its previous state", e.App, e.Err)
+}
+
+// Unwrap returns the push error and any rollback error
+func (e *PushRollbackError) Unwrap() []error {
+	if e.RollbackErr != nil {
+		return []error{e.Err, e.RollbackErr}
+	}
+	return []error{e.Err}
+}
+
+// RolledBack returns true if the app was restored to the state it had before the push
+func (e *PushRollbackError) RolledBack() bool {
+	return e.RollbackErr == nil
+}
+
+// deploymentRolledBackError is a failed deployment that the rolling or canary strategy already rolled back or
+// cancelled, so the app is running its previous droplet again
+type deploymentRolledBackError struct {
+	err error
+}
+
+func (e *deploymentRolledBackError) Error() string {
+	return e.err.Error()
+}
+
+func (e *deploymentRolledBackError) Unwrap() error {
+	return e.err
+}
+
+// appSnapshot is the state of an app before it's pushed
+type appSnapshot struct {
+	// name of the app, the rest of the fields are empty if the app didn't exist
+	name string
+
+	app        *resource.App
+	manifest   string
+	instances  uint
+	env        map[string]*string
+	droplet    *resource.Droplet
+	revision   *resource.Revision
+	routeGUIDs []string
+}
+
+// snapshotApp records the state of the app so it can be restored if the push fails
+func (p *AppPushOperation) snapshotApp(ctx context.Context, space *resource.Space, appName string) (_ *appSnapshot, err error) {
+	ctx, span := p.startSpan(ctx, "snapshotApp", telemetry.AttrAppName.String(appName))
+	defer func() { telemetry.End(span, err) }()
+
+	snapshot := &appSnapshot{name: appName}
+	app, err := p.findApp(ctx, appName, space)
+	if errors.Is(err, client.ErrExactlyOneResultNotReturned) {
+		return snapshot, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	snapshot.app = app
+	span.SetAttributes(telemetry.AttrResourceGUID.String(app.GUID))
+
+	if snapshot.manifest, err = p.client.Manifests.Generate(ctx, app.GUID); err != nil {
+		return nil, fmt.Errorf("failed to snapshot the manifest of application %s: %w", appName, err)
+	}
+	snapshot.instances = webInstances(snapshot.manifest)
+	if snapshot.env, err = p.client.Applications.GetEnvironmentVariables(ctx, app.GUID); err != nil {
+		return nil, fmt.Errorf("failed to snapshot the environment variables of application %s: %w", appName, err)
+	}
+	routes, err := p.client.Routes.ListForAppAll(ctx, app.GUID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to snapshot the routes of application %s: %w", appName, err)
+	}
+	for _, route := range routes {
+		snapshot.routeGUIDs = append(snapshot.routeGUIDs, route.GUID)
+	}
+
+	// an app that was never staged doesn't have a droplet, and revisions can be disabled
+	snapshot.droplet, err = p.client.Droplets.GetCurrentForApp(ctx, app.GUID)
+	if err != nil && !resource.IsResourceNotFoundError(err) {
+		return nil, fmt.Errorf("failed to snapshot the droplet of application %s: %w", appName, err)
+	}
+	snapshot.revision, _ = p.client.Revisions.SingleForAppDeployed(ctx, app.GUID, nil)
+	return snapshot, nil
+}
+
+// webInstances returns the number of instances of the web process in the generated manifest, or 0 if it can't be
+// found so the default timeout is used
+func webInstances(manifestYAML string) uint {
+	var manifest Manifest
+	if err := yaml.Unmarshal([]byte(manifestYAML), &manifest); err != nil || len(manifest.Applications) == 0 {
+		return 0
+	}
+	app := manifest.Applications[0]
+	if app == nil {
+		return 0
+	}
+	if app.Processes != nil {
+		for _, process := range *app.Processes {
+			if process.Type == Web && process.Instances != nil {
+				return *process.Instances
+			}
+		}
+	}
+	if app.Instances != nil {
+		return *app.Instances
+	}
+	return 0
+}
+
+// restoreSnapshot puts the app back into the state it was in before the push, deleting it if it didn't exist.
+// Every part of the state is restored even if restoring one part fails. The previous droplet is only redeployed
+// if the push changed it and the deployment strategy didn't already roll it back.
+func (p *AppPushOperation) restoreSnapshot(ctx context.Context, space *resource.Space, snapshot *appSnapshot, pushErr error) (err error) {
+	ctx, span := p.startSpan(ctx, "restoreSnapshot", telemetry.AttrAppName.String(snapshot.name))
+	defer func() { telemetry.End(span, err) }()
+
+	app, err := p.findApp(ctx, snapshot.name, space)
+	if errors.Is(err, client.ErrExactlyOneResultNotReturned) {
+		if snapshot.app == nil {
+			return nil
+		}
+		return fmt.Errorf("application %s no longer exists", snapshot.name)
+	}
+	if err != nil {
+		return err
+	}
+	if snapshot.app == nil {
+		if err = p.gracefulDeletion(ctx, app); err != nil {
+			return fmt.Errorf("failed to delete the new application: %w", err)
+		}
+		return nil
+	}
+
+	// compare the droplet before restoring the environment variables, which creates a new revision
+	var rolledBack *deploymentRolledBackError
+	redeploy := !errors.As(pushErr, &rolledBack) && p.dropletChanged(ctx, app, snapshot)
+
+	var errs []error
+	if err = p.applyManifestYAML(ctx, space, snapshot.manifest); err != nil {
+		errs = append(errs, fmt.Errorf("failed to restore the manifest: %w", err))
+	}
+	if err = p.restoreEnvironmentVariables(ctx, app, snapshot); err != nil {
+		errs = append(errs, fmt.Errorf("failed to restore the environment variables: %w", err))
+	}
+	if err = p.restoreRoutes(ctx, app, snapshot); err != nil {
+		errs = append(errs, fmt.Errorf("failed to restore the routes: %w", err))
+	}
+	if err = p.restoreDroplet(ctx, app, snapshot, redeploy); err != nil {
+		errs = append(errs, fmt.Errorf("failed to restore the droplet: %w", err))
+	}
+	return errors.Join(errs...)
+}
+
+// restoreEnvironmentVariables removes the variables added by the push and resets the changed ones, since applying
+// a manifest never removes variables
+func (p *AppPushOperation) restoreEnvironmentVariables(ctx context.Context, app *resource.App, snapshot *appSnapshot) error {
+	current, err := p.client.Applications.GetEnvironmentVariables(ctx, app.GUID)
+	if err != nil {
+		return err
+	}
+	env := make(map[string]*string)
+	for name := range current {
+		if _, ok := snapshot.env[name]; !ok {
+			env[name] = nil
+		}
+	}
+	for name, value := range snapshot.env {
+		env[name] = value
+	}
+	if len(env) == 0 {
+		return nil
+	}
+	_, err = p.client.Applications.SetEnvironmentVariables(ctx, app.GUID, env)
+	return err
+}
+
+// restoreRoutes unmaps the app from the routes mapped by the push, since applying a manifest never unmaps routes
+func (p *AppPushOperation) restoreRoutes(ctx context.Context, app *resource.App, snapshot *appSnapshot) error {
+	routes, err := p.client.Routes.ListForAppAll(ctx, app.GUID, nil)
+	if err != nil {
+		return err
+	}
+	for _, route := range routes {
+		if slices.Contains(snapshot.routeGUIDs, route.GUID) {
+			continue
+		}
+		destinations, err := p.client.Routes.GetDestinations(ctx, route.GUID)
+		if err != nil {
+			return err
+		}
+		for _, dest := range destinations.Destinations {
+			if dest.GUID == nil || dest.App.GUID == nil || *dest.App.GUID != app.GUID {
+				continue
+			}
+			if err = p.client.Routes.RemoveDestination(ctx, route.GUID, *dest.GUID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// dropletChanged returns true if the app's current droplet or deployed revision differs from the snapshot's, or
+// if they can't be looked up
+func (p *AppPushOperation) dropletChanged(ctx context.Context, app *resource.App, snapshot *appSnapshot) bool {
+	droplet, err := p.client.Droplets.GetCurrentForApp(ctx, app.GUID)
+	if err != nil && !resource.IsResourceNotFoundError(err) {
+		return true
+	}
+	if (droplet == nil) != (snapshot.droplet == nil) || (droplet != nil && droplet.GUID != snapshot.droplet.GUID) {
+		return true
+	}
+	revision, _ := p.client.Revisions.SingleForAppDeployed(ctx, app.GUID, nil)
+	return (revision == nil) != (snapshot.revision == nil) || (revision != nil && revision.GUID != snapshot.revision.GUID)
+}
+
+// restoreDroplet runs the previous droplet in the app's previous state. A running app with revisions is rolled
+// back with a deployment of its previous revision so it stays available. Without redeploy the app still runs the
+// previous droplet, so it's only started or stopped to match its previous state.
+func (p *AppPushOperation) restoreDroplet(ctx context.Context, app *resource.App, snapshot *appSnapshot, redeploy bool) error {
+	wasStarted := snapshot.app.State == "STARTED"
+	if !redeploy {
+		var err error
+		switch {
+		case wasStarted && app.State != "STARTED":
+			_, err = p.client.Applications.Start(ctx, app.GUID)
+		case !wasStarted && app.State == "STARTED":
+			_, err = p.client.Applications.Stop(ctx, app.GUID)
+		}
+		return err
+	}
+
+	if wasStarted && snapshot.revision != nil {
+		deployment, err := p.rollBackDeployment(ctx, app, snapshot.revision)
+		if err != nil {
+			return err
+		}
+		return p.waitForDeployment(ctx, deployment.GUID, snapshot.instances)
+	}
+
+	if snapshot.droplet != nil {
+		if _, err := p.client.Droplets.SetCurrentAssociationForApp(ctx, app.GUID, snapshot.droplet.GUID); err != nil {
+			return err
+		}
+	}
+	var err error
+	switch {
+	case wasStarted:
+		_, err = p.client.Applications.Restart(ctx, app.GUID)
+	case app.State == "STARTED":
+		_, err = p.client.Applications.Stop(ctx, app.GUID)
+	}
+	return err
+}
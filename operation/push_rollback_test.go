@@ -0,0 +1,224 @@
+package operation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cloudfoundry/go-cfclient/v3/client"
+	"github.com/cloudfoundry/go-cfclient/v3/config"
+	"github.com/cloudfoundry/go-cfclient/v3/resource"
+	"github.com/cloudfoundry/go-cfclient/v3/testutil"
+)
+
+// fakeRollbackAPI is a CF API where staging the app fails after the manifest added an env var and a route
+type fakeRollbackAPI struct {
+	fakeManifestPushAPI
+
+	mu         sync.Mutex
+	appExists  bool
+	env        map[string]*string
+	routes     []string
+	droplet    string
+	newDroplet string
+	dropletGet int
+	calls      []string
+	removedDst []string
+}
+
+func (f *fakeRollbackAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	paged := func(resources ...string) string {
+		return fmt.Sprintf(`{"pagination":{"total_results":%d,"total_pages":1},"resources":[%s]}`,
+			len(resources), strings.Join(resources, ","))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	switch r.Method + " " + r.URL.Path {
+	case "POST /v3/spaces/space-guid/actions/apply_manifest":
+		f.calls = append(f.calls, "apply_manifest")
+		if !f.appExists {
+			f.appExists = true
+		} else if len(f.calls) == 1 {
+			// the first manifest adds an env var and a route
+			v := "new"
+			f.env["NEW_VAR"] = &v
+			f.routes = append(f.routes, "route-new")
+		}
+		f.fakeManifestPushAPI.ServeHTTP(w, r)
+	case "GET /v3/apps":
+		if !f.appExists {
+			_, _ = w.Write([]byte(paged()))
+			return
+		}
+		_, _ = w.Write([]byte(paged(`{"guid":"api","name":"api","state":"STARTED","lifecycle":{"type":"buildpack","data":{}}}`)))
+	case "GET /v3/apps/api/manifest":
+		w.Header().Set("Content-Type", "application/x-yaml")
+		_, _ = w.Write([]byte("applications:\n- name: api\n"))
+	case "GET /v3/apps/api/environment_variables":
+		_ = json.NewEncoder(w).Encode(map[string]any{"var": f.env})
+	case "PATCH /v3/apps/api/environment_variables":
+		var body struct {
+			Var map[string]*string `json:"var"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		for name, value := range body.Var {
+			if value == nil {
+				delete(f.env, name)
+			} else {
+				f.env[name] = value
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"var": f.env})
+	case "GET /v3/apps/api/routes":
+		var routes []string
+		for _, guid := range f.routes {
+			routes = append(routes, fmt.Sprintf(`{"guid":"%s"}`, guid))
+		}
+		_, _ = w.Write([]byte(paged(routes...)))
+	case "GET /v3/routes/route-new/destinations":
+		_, _ = w.Write([]byte(`{"destinations":[{"guid":"dest-other","app":{"guid":"other"}},{"guid":"dest-api","app":{"guid":"api"}}]}`))
+	case "DELETE /v3/routes/route-new/destinations/dest-api":
+		f.removedDst = append(f.removedDst, "dest-api")
+		w.WriteHeader(http.StatusNoContent)
+	case "GET /v3/apps/api/droplets/current":
+		// the push changes the current droplet after the snapshot when newDroplet is set
+		guid := "old-droplet"
+		if f.dropletGet > 0 && f.newDroplet != "" {
+			guid = f.newDroplet
+		}
+		f.dropletGet++
+		_, _ = w.Write([]byte(fmt.Sprintf(`{"guid":"%s","state":"STAGED"}`, guid)))
+	case "GET /v3/apps/api/revisions/deployed":
+		_, _ = w.Write([]byte(paged()))
+	case "PATCH /v3/apps/api/relationships/current_droplet":
+		var body struct {
+			Data struct{ GUID string } `json:"data"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		f.droplet = body.Data.GUID
+		_, _ = w.Write([]byte(fmt.Sprintf(`{"data":{"guid":"%s"}}`, body.Data.GUID)))
+	case "POST /v3/apps/api/actions/restart", "POST /v3/apps/api/actions/stop":
+		f.calls = append(f.calls, r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:])
+		_, _ = w.Write([]byte(`{"guid":"api","name":"api","state":"STOPPED","lifecycle":{"type":"buildpack","data":{}}}`))
+	case "DELETE /v3/apps/api":
+		f.calls = append(f.calls, "delete")
+		f.appExists = false
+		w.Header().Set("Location", "http://"+r.Host+"/v3/jobs/job-guid")
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		f.fakeManifestPushAPI.ServeHTTP(w, r)
+	}
+}
+
+func TestAppPushRollback(t *testing.T) {
+	uaaURL := testutil.SetupFakeUAAServer(300)
+	defer testutil.Teardown()
+	push := func(t *testing.T, fake *fakeRollbackAPI) error {
+		api := httptest.NewServer(fake)
+		t.Cleanup(api.Close)
+		cfg, err := config.New(api.URL, config.ClientCredentials("cf", "secret"), config.AuthTokenURL(uaaURL, uaaURL))
+		require.NoError(t, err)
+		cf, err := client.New(cfg)
+		require.NoError(t, err)
+		pusher := NewAppPushOperation(cf, "org", "space")
+		pusher.WithRollback(true)
+		_, err = pusher.Push(context.Background(), NewAppManifest("api"), strings.NewReader("zip"))
+		return err
+	}
+
+	t.Run("restores an existing app", func(t *testing.T) {
+		old := "old"
+		fake := &fakeRollbackAPI{
+			fakeManifestPushAPI: fakeManifestPushAPI{failingApp: "api"},
+			appExists:           true,
+			env:                 map[string]*string{"OLD_VAR": &old},
+			routes:              []string{"route-old"},
+		}
+		err := push(t, fake)
+
+		var rollbackErr *PushRollbackError
+		require.ErrorAs(t, err, &rollbackErr)
+		require.True(t, rollbackErr.RolledBack())
+		require.ErrorContains(t, err, "staging failed")
+		require.ErrorContains(t, err, "rolled back the application to its previous state")
+
+		// staging failed before the droplet changed, so the running app isn't restarted
+		require.Equal(t, []string{"apply_manifest", "apply_manifest"}, fake.calls)
+		require.Equal(t, map[string]*string{"OLD_VAR": &old}, fake.env)
+		require.Equal(t, []string{"dest-api"}, fake.removedDst)
+		require.Empty(t, fake.droplet)
+	})
+
+	t.Run("restores the previous droplet", func(t *testing.T) {
+		old := "old"
+		fake := &fakeRollbackAPI{
+			fakeManifestPushAPI: fakeManifestPushAPI{failingApp: "api"},
+			appExists:           true,
+			env:                 map[string]*string{"OLD_VAR": &old},
+			routes:              []string{"route-old"},
+			newDroplet:          "new-droplet",
+		}
+		err := push(t, fake)
+
+		var rollbackErr *PushRollbackError
+		require.ErrorAs(t, err, &rollbackErr)
+		require.True(t, rollbackErr.RolledBack())
+		require.Equal(t, []string{"apply_manifest", "apply_manifest", "restart"}, fake.calls)
+		require.Equal(t, "old-droplet", fake.droplet)
+	})
+
+	t.Run("skips the droplet the strategy rolled back", func(t *testing.T) {
+		fake := &fakeRollbackAPI{
+			fakeManifestPushAPI: fakeManifestPushAPI{failingApp: "api"},
+			appExists:           true,
+			env:                 map[string]*string{},
+			newDroplet:          "new-droplet",
+		}
+		api := httptest.NewServer(fake)
+		defer api.Close()
+		cfg, err := config.New(api.URL, config.ClientCredentials("cf", "secret"), config.AuthTokenURL(uaaURL, uaaURL))
+		require.NoError(t, err)
+		cf, err := client.New(cfg)
+		require.NoError(t, err)
+		pusher := NewAppPushOperation(cf, "org", "space")
+		space := &resource.Space{Resource: resource.Resource{GUID: "space-guid"}}
+
+		snapshot, err := pusher.snapshotApp(context.Background(), space, "api")
+		require.NoError(t, err)
+		pushErr := &deploymentRolledBackError{err: errors.New("failed to deploy")}
+		require.NoError(t, pusher.restoreSnapshot(context.Background(), space, snapshot, pushErr))
+		require.Equal(t, []string{"apply_manifest"}, fake.calls)
+		require.Empty(t, fake.droplet)
+	})
+
+	t.Run("deletes a new app", func(t *testing.T) {
+		fake := &fakeRollbackAPI{
+			fakeManifestPushAPI: fakeManifestPushAPI{failingApp: "api"},
+			env:                 map[string]*string{},
+		}
+		err := push(t, fake)
+
+		var rollbackErr *PushRollbackError
+		require.ErrorAs(t, err, &rollbackErr)
+		require.True(t, rollbackErr.RolledBack())
+		require.Equal(t, []string{"apply_manifest", "stop", "delete"}, fake.calls)
+		require.False(t, fake.appExists)
+	})
+}
+
+func TestWebInstances(t *testing.T) {
+	require.Equal(t, uint(3), webInstances("applications:\n- name: api\n  processes:\n  - type: worker\n    instances: 1\n  - type: web\n    instances: 3\n"))
+	require.Equal(t, uint(2), webInstances("applications:\n- name: api\n  instances: 2\n"))
+	require.Zero(t, webInstances("applications:\n- name: api\n"))
+	require.Zero(t, webInstances("not: [yaml"))
+}
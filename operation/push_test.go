@@ -2,9 +2,13 @@ package operation
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/cloudfoundry/go-cfclient/v3/client"
@@ -13,6 +17,9 @@ import (
 	"github.com/cloudfoundry/go-cfclient/v3/testutil"
 
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 func setupAppPushRoutes(t *testing.T, serverURL string, g *testutil.ObjectJSONGenerator, org, space, job, app, pkg, build, droplet, dropletAssoc *testutil.JSONResource, finalAction string) {
@@ -200,6 +207,222 @@ func TestAppPushRestartsRunningApp(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestAppPushTracing(t *testing.T) {
+	serverURL := testutil.SetupFakeAPIServer()
+	defer testutil.Teardown()
+
+	g := testutil.NewObjectJSONGenerator()
+	org := g.Organization()
+	space := g.Space()
+	job := g.Job("COMPLETE")
+	app := g.Application()
+	pkg := g.Package("READY")
+	build := g.Build("STAGED")
+	droplet := g.Droplet()
+	dropletAssoc := g.DropletAssociation()
+	manifest := &AppManifest{
+		Name:       app.Name,
+		Buildpacks: []string{"java-buildpack-offline"},
+	}
+
+	setupAppPushRoutes(t, serverURL, g, org, space, job, app, pkg, build, droplet, dropletAssoc, "restart")
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	c, _ := config.New(serverURL, config.Token("", "fake-refresh-token"), config.TracerProvider(tp))
+	cf, err := client.New(c)
+	require.NoError(t, err)
+
+	pusher := NewAppPushOperation(cf, org.Name, space.Name)
+	_, err = pusher.Push(context.Background(), manifest, strings.NewReader("blah zip zip"))
+	require.NoError(t, err)
+
+	spans := exporter.GetSpans()
+	byName := make(map[string]tracetest.SpanStub)
+	for _, s := range spans {
+		byName[s.Name] = s
+	}
+	push, ok := byName["operation.AppPushOperation.Push"]
+	require.True(t, ok)
+	require.Contains(t, push.Attributes, attribute.String("cf.app.name", app.Name))
+
+	for _, stage := range []string{"applySpaceManifest", "uploadPackage", "buildDroplet"} {
+		s, ok := byName["operation.AppPushOperation."+stage]
+		require.True(t, ok, stage)
+		require.Equal(t, push.SpanContext.SpanID(), s.Parent.SpanID(), stage)
+	}
+	require.Contains(t, byName["operation.AppPushOperation.applySpaceManifest"].Attributes,
+		attribute.String("cf.job.guid", job.GUID))
+
+	// client operation spans are children of the stage spans, with the request spans under them
+	upload := byName["Packages.Upload"]
+	require.Equal(t, byName["operation.AppPushOperation.uploadPackage"].SpanContext.SpanID(), upload.Parent.SpanID())
+	uploadRequest := byName["POST /v3/packages/:guid/upload"]
+	require.Equal(t, upload.SpanContext.SpanID(), uploadRequest.Parent.SpanID())
+	require.Equal(t, push.SpanContext.TraceID(), uploadRequest.SpanContext.TraceID())
+}
+
+// fakeCanaryAPI is a CF API with a running app whose canary deployment pauses at each step
+type fakeCanaryAPI struct {
+	fakeManifestPushAPI
+
+	mu        sync.Mutex
+	create    resource.DeploymentCreate
+	step      int
+	stale     bool
+	cancelled bool
+	// the app is stopped once the deployment is created
+	stops bool
+}
+
+func (f *fakeCanaryAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	status := func() string {
+		switch {
+		case f.cancelled:
+			return `{"value":"FINALIZED","reason":"CANCELED"}`
+		case f.step > len(f.create.Options.Canary.Steps):
+			return `{"value":"FINALIZED","reason":"DEPLOYED"}`
+		}
+		return fmt.Sprintf(`{"value":"ACTIVE","reason":"PAUSED","canary":{"steps":{"current":%d,"total":%d}}}`,
+			f.step, len(f.create.Options.Canary.Steps))
+	}
+	deployment := func() string {
+		return fmt.Sprintf(`{"guid":"deployment-guid","strategy":"canary","status":%s}`, status())
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case r.URL.Path == "/v3/apps" && r.Method == http.MethodGet:
+		state := "STARTED"
+		if f.stops && f.step > 0 {
+			state = "STOPPED"
+		}
+		_, _ = w.Write([]byte(fmt.Sprintf(`{"pagination":{"total_results":1,"total_pages":1},"resources":[{"guid":"api","name":"api","state":%q,"lifecycle":{"type":"buildpack","data":{}}}]}`, state)))
+	case r.URL.Path == "/v3/deployments":
+		_ = json.NewDecoder(r.Body).Decode(&f.create)
+		f.step = 1
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(deployment()))
+	case r.URL.Path == "/v3/deployments/deployment-guid":
+		// the first check after continuing still reports the previous pause
+		if f.stale {
+			f.stale = false
+			f.step--
+			defer func() { f.step++ }()
+		}
+		_, _ = w.Write([]byte(deployment()))
+	case r.URL.Path == "/v3/deployments/deployment-guid/actions/continue":
+		f.step++
+		f.stale = true
+		_, _ = w.Write([]byte(deployment()))
+	case r.URL.Path == "/v3/deployments/deployment-guid/actions/cancel":
+		f.cancelled = true
+		_, _ = w.Write([]byte(deployment()))
+	default:
+		f.fakeManifestPushAPI.ServeHTTP(w, r)
+	}
+}
+
+func TestAppPushCanary(t *testing.T) {
+	uaaURL := testutil.SetupFakeUAAServer(300)
+	defer testutil.Teardown()
+	newPusher := func(t *testing.T, fake *fakeCanaryAPI, gate CanaryGate) *AppPushOperation {
+		api := httptest.NewServer(fake)
+		t.Cleanup(api.Close)
+		cfg, err := config.New(api.URL, config.ClientCredentials("cf", "secret"), config.AuthTokenURL(uaaURL, uaaURL))
+		require.NoError(t, err)
+		cf, err := client.New(cfg)
+		require.NoError(t, err)
+		pusher := NewAppPushOperation(cf, "org", "space")
+		pusher.WithCanaryStrategy([]resource.CanaryStep{{InstanceWeight: 10}, {InstanceWeight: 50}}, gate)
+		return pusher
+	}
+
+	t.Run("continues at each step", func(t *testing.T) {
+		fake := &fakeCanaryAPI{}
+		var gatedSteps []int
+		pusher := newPusher(t, fake, func(ctx context.Context, d *resource.Deployment) error {
+			gatedSteps = append(gatedSteps, d.Status.Canary.Steps.Current)
+			return nil
+		})
+		app, err := pusher.Push(context.Background(), NewAppManifest("api"), strings.NewReader("zip"))
+		require.NoError(t, err)
+		require.Equal(t, "STARTED", app.State)
+		require.Equal(t, []int{1, 2}, gatedSteps)
+		require.Equal(t, "canary", fake.create.Strategy)
+		require.Equal(t, "api", fake.create.Droplet.GUID)
+		require.Equal(t, []resource.CanaryStep{{InstanceWeight: 10}, {InstanceWeight: 50}}, fake.create.Options.Canary.Steps)
+		require.False(t, fake.cancelled)
+	})
+
+	t.Run("cancels when the gate rejects a step", func(t *testing.T) {
+		fake := &fakeCanaryAPI{}
+		pusher := newPusher(t, fake, func(ctx context.Context, d *resource.Deployment) error {
+			return errors.New("error rate too high")
+		})
+		_, err := pusher.Push(context.Background(), NewAppManifest("api"), strings.NewReader("zip"))
+		require.ErrorContains(t, err, "canary deployment rejected at step 1: error rate too high")
+		require.ErrorContains(t, err, "cancelled the deployment")
+		require.True(t, fake.cancelled)
+	})
+
+	t.Run("doesn't cancel a finished deployment", func(t *testing.T) {
+		fake := &fakeCanaryAPI{stops: true}
+		pusher := newPusher(t, fake, nil)
+		_, err := pusher.Push(context.Background(), NewAppManifest("api"), strings.NewReader("zip"))
+		require.ErrorContains(t, err, "application api is STOPPED after the deployment")
+		require.ErrorContains(t, err, "the deployment finished without being cancelled")
+		require.False(t, fake.cancelled)
+		var rolledBack *deploymentRolledBackError
+		require.False(t, errors.As(err, &rolledBack))
+	})
+}
+
+// fakeStagingLogsAPI is a CF API with a log-cache that has the staging logs of the api app
+type fakeStagingLogsAPI struct {
+	fakeManifestPushAPI
+}
+
+func (f *fakeStagingLogsAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	switch r.URL.Path {
+	case "/":
+		_, _ = w.Write([]byte(fmt.Sprintf(`{"links":{"log_cache":{"href":"http://%s"}}}`, r.Host)))
+	case "/api/v1/read/api":
+		_, _ = w.Write([]byte(`{"envelopes":{"batch":[
+			{"timestamp":"1","tags":{"source_type":"STG"},"log":{"payload":"RG93bmxvYWRpbmcgYnVpbGRwYWNrLi4u","type":"OUT"}},
+			{"timestamp":"2","tags":{"source_type":"API"},"log":{"payload":"VXBkYXRlZCBhcHA=","type":"OUT"}},
+			{"timestamp":"3","tags":{"source_type":"STG"},"log":{"payload":"Tm8gYnVpbGRwYWNrIGRldGVjdGVkCg==","type":"ERR"}}
+		]}}`))
+	default:
+		f.fakeManifestPushAPI.ServeHTTP(w, r)
+	}
+}
+
+func TestAppPushStagingLogs(t *testing.T) {
+	uaaURL := testutil.SetupFakeUAAServer(300)
+	defer testutil.Teardown()
+	api := httptest.NewServer(&fakeStagingLogsAPI{fakeManifestPushAPI{failingApp: "api"}})
+	defer api.Close()
+	cfg, err := config.New(api.URL, config.ClientCredentials("cf", "secret"), config.AuthTokenURL(uaaURL, uaaURL))
+	require.NoError(t, err)
+	cf, err := client.New(cfg)
+	require.NoError(t, err)
+
+	var out strings.Builder
+	pusher := NewAppPushOperation(cf, "org", "space")
+	pusher.WithStagingLogs(client.StagingLogWriter(&out))
+	_, err = pusher.Push(context.Background(), NewAppManifest("api"), strings.NewReader("zip"))
+
+	var stagingErr *client.StagingFailedError
+	require.ErrorAs(t, err, &stagingErr)
+	require.Equal(t, []string{"Downloading buildpack...", "No buildpack detected"}, stagingErr.LogTail)
+	require.ErrorContains(t, err, "staging failed\nlast 2 lines of the staging log:\nDownloading buildpack...\nNo buildpack detected")
+	require.Equal(t, "Downloading buildpack...\nNo buildpack detected\n", out.String())
+}
+
 func TestDockerLifecycleBuildCreation(t *testing.T) {
 	serverURL := testutil.SetupFakeAPIServer()
 	defer testutil.Teardown()
@@ -398,3 +621,25 @@ func TestPushOperationLifecycleLogic(t *testing.T) {
 		})
 	}
 }
+
+func TestAppPushManifestValidation(t *testing.T) {
+	cfg, err := config.New("http://127.0.0.1:1", config.Token("", "fake-refresh-token"),
+		config.AuthTokenURL("http://127.0.0.1:1", "http://127.0.0.1:1")) // skip service discovery
+	require.NoError(t, err)
+	cf, err := client.New(cfg)
+	require.NoError(t, err)
+	manifest := NewAppManifest("api")
+	manifest.Memory = "256"
+
+	// off by default, so the push fails calling the CC instead
+	pusher := NewAppPushOperation(cf, "org", "space")
+	_, err = pusher.Push(context.Background(), manifest, strings.NewReader("zip"))
+	var validationErr *ManifestValidationError
+	require.Error(t, err)
+	require.False(t, errors.As(err, &validationErr))
+
+	pusher.WithManifestValidation(true)
+	_, err = pusher.Push(context.Background(), manifest, strings.NewReader("zip"))
+	require.ErrorAs(t, err, &validationErr)
+	require.ErrorContains(t, err, "memory")
+}
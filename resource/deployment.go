@@ -2,6 +2,19 @@ package resource
 
 import "time"
 
+// The deployment status values and the reasons for them
+const (
+	DeploymentStatusValueActive    = "ACTIVE"
+	DeploymentStatusValueFinalized = "FINALIZED"
+
+	DeploymentStatusReasonDeploying  = "DEPLOYING"
+	DeploymentStatusReasonPaused     = "PAUSED"
+	DeploymentStatusReasonCanceling  = "CANCELING"
+	DeploymentStatusReasonDeployed   = "DEPLOYED"
+	DeploymentStatusReasonCanceled   = "CANCELED"
+	DeploymentStatusReasonSuperseded = "SUPERSEDED"
+)
+
 type Deployment struct {
 	Status          DeploymentStatus   `json:"status"`
 	Strategy        string             `json:"strategy"`
@@ -11,24 +11,53 @@ type CloudFoundryHTTPError struct {
 	StatusCode int
 	Status     string
 	Body       []byte
+
+	RequestMethod string // HTTP request method
+	RequestURL    string // HTTP request URL
+	RequestID     string // X-Vcap-Request-Id response header, used to correlate the request with the CC logs
 }
 
 func (e CloudFoundryHTTPError) Error() string {
-	return fmt.Sprintf("cfclient: HTTP error (%d): %s", e.StatusCode, e.Status)
+	msg := fmt.Sprintf("cfclient: HTTP error (%d): %s", e.StatusCode, e.Status)
+	if e.RequestID != "" {
+		msg += fmt.Sprintf(" (request id: %s)", e.RequestID)
+	}
+	return msg
 }
 
+// CloudFoundryErrors is the set of errors returned by the CF API for a failed request
 type CloudFoundryErrors struct {
 	Errors []CloudFoundryError `json:"errors"`
+
+	StatusCode    int    `json:"-"` // HTTP response status code
+	RequestMethod string `json:"-"` // HTTP request method
+	RequestURL    string `json:"-"` // HTTP request URL
+	RequestID     string `json:"-"` // X-Vcap-Request-Id response header, used to correlate the request with the CC logs
 }
 
 func (e CloudFoundryErrors) Error() string {
 	var sb strings.Builder
-	for _, err := range e.Errors {
+	for i, err := range e.Errors {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
 		sb.WriteString(err.Error())
 	}
+	if e.RequestID != "" {
+		sb.WriteString(fmt.Sprintf(" (request id: %s)", e.RequestID))
+	}
 	return sb.String()
 }
 
+// Unwrap returns each contained CloudFoundryError so errors.Is and errors.As can match any of them
+func (e CloudFoundryErrors) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+	return errs
+}
+
 type CloudFoundryError struct {
 	Code   int    `json:"code"`
 	Title  string `json:"title"`
@@ -38,3 +67,14 @@ type CloudFoundryError struct {
 func (e CloudFoundryError) Error() string {
 	return fmt.Sprintf("cfclient error (%s|%d): %s", e.Title, e.Code, e.Detail)
 }
+
+// Is returns true if the target is a CloudFoundryError with the same code, regardless of the title or detail
+func (e CloudFoundryError) Is(target error) bool {
+	switch t := target.(type) {
+	case CloudFoundryError:
+		return e.Code == t.Code
+	case *CloudFoundryError:
+		return t != nil && e.Code == t.Code
+	}
+	return false
+}
@@ -24,11 +24,7 @@ func NewInvalidAuthTokenError() CloudFoundryError {
 // - HTTP code: 401
 // - message: "Invalid Auth Token"
 func IsInvalidAuthTokenError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 1000
+	return errors.Is(err, CloudFoundryError{Code: 1000})
 }
 
 // NewMessageParseError returns a new CloudFoundryError
@@ -47,11 +43,7 @@ func NewMessageParseError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "Request invalid due to parse error: %s"
 func IsMessageParseError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 1001
+	return errors.Is(err, CloudFoundryError{Code: 1001})
 }
 
 // NewInvalidRelationError returns a new CloudFoundryError
@@ -70,11 +62,7 @@ func NewInvalidRelationError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "%s"
 func IsInvalidRelationError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 1002
+	return errors.Is(err, CloudFoundryError{Code: 1002})
 }
 
 // NewInvalidContentTypeError returns a new CloudFoundryError
@@ -93,11 +81,7 @@ func NewInvalidContentTypeError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "Invalid content type, expected: %s"
 func IsInvalidContentTypeError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 1003
+	return errors.Is(err, CloudFoundryError{Code: 1003})
 }
 
 // NewBadRequestError returns a new CloudFoundryError
@@ -116,11 +100,7 @@ func NewBadRequestError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "Bad request: %s"
 func IsBadRequestError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 1004
+	return errors.Is(err, CloudFoundryError{Code: 1004})
 }
 
 // NewNotFoundError returns a new CloudFoundryError
@@ -139,11 +119,7 @@ func NewNotFoundError() CloudFoundryError {
 // - HTTP code: 404
 // - message: "Unknown request"
 func IsNotFoundError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 10000
+	return errors.Is(err, CloudFoundryError{Code: 10000})
 }
 
 // NewServerError returns a new CloudFoundryError
@@ -162,11 +138,7 @@ func NewServerError() CloudFoundryError {
 // - HTTP code: 500
 // - message: "Server error"
 func IsServerError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 10001
+	return errors.Is(err, CloudFoundryError{Code: 10001})
 }
 
 // NewNotAuthenticatedError returns a new CloudFoundryError
@@ -185,11 +157,7 @@ func NewNotAuthenticatedError() CloudFoundryError {
 // - HTTP code: 401
 // - message: "Authentication error"
 func IsNotAuthenticatedError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 10002
+	return errors.Is(err, CloudFoundryError{Code: 10002})
 }
 
 // NewNotAuthorizedError returns a new CloudFoundryError
@@ -208,11 +176,7 @@ func NewNotAuthorizedError() CloudFoundryError {
 // - HTTP code: 403
 // - message: "You are not authorized to perform the requested action"
 func IsNotAuthorizedError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 10003
+	return errors.Is(err, CloudFoundryError{Code: 10003})
 }
 
 // NewInvalidRequestError returns a new CloudFoundryError
@@ -231,11 +195,7 @@ func NewInvalidRequestError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The request is invalid"
 func IsInvalidRequestError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 10004
+	return errors.Is(err, CloudFoundryError{Code: 10004})
 }
 
 // NewBadQueryParameterError returns a new CloudFoundryError
@@ -254,11 +214,7 @@ func NewBadQueryParameterError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The query parameter is invalid: %s"
 func IsBadQueryParameterError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 10005
+	return errors.Is(err, CloudFoundryError{Code: 10005})
 }
 
 // NewAssociationNotEmptyError returns a new CloudFoundryError
@@ -277,11 +233,7 @@ func NewAssociationNotEmptyError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "Please delete the %s associations for your %s."
 func IsAssociationNotEmptyError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 10006
+	return errors.Is(err, CloudFoundryError{Code: 10006})
 }
 
 // NewInsufficientScopeError returns a new CloudFoundryError
@@ -300,11 +252,7 @@ func NewInsufficientScopeError() CloudFoundryError {
 // - HTTP code: 403
 // - message: "Your token lacks the necessary scopes to access this resource."
 func IsInsufficientScopeError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 10007
+	return errors.Is(err, CloudFoundryError{Code: 10007})
 }
 
 // NewUnprocessableEntityError returns a new CloudFoundryError
@@ -323,11 +271,7 @@ func NewUnprocessableEntityError() CloudFoundryError {
 // - HTTP code: 422
 // - message: "%s"
 func IsUnprocessableEntityError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 10008
+	return errors.Is(err, CloudFoundryError{Code: 10008})
 }
 
 // NewUnableToPerformError returns a new CloudFoundryError
@@ -346,11 +290,7 @@ func NewUnableToPerformError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "%s could not be completed: %s"
 func IsUnableToPerformError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 10009
+	return errors.Is(err, CloudFoundryError{Code: 10009})
 }
 
 // NewResourceNotFoundError returns a new CloudFoundryError
@@ -369,11 +309,7 @@ func NewResourceNotFoundError() CloudFoundryError {
 // - HTTP code: 404
 // - message: "%s"
 func IsResourceNotFoundError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 10010
+	return errors.Is(err, CloudFoundryError{Code: 10010})
 }
 
 // NewDatabaseError returns a new CloudFoundryError
@@ -392,11 +328,7 @@ func NewDatabaseError() CloudFoundryError {
 // - HTTP code: 500
 // - message: "Database error"
 func IsDatabaseError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 10011
+	return errors.Is(err, CloudFoundryError{Code: 10011})
 }
 
 // NewOrderByParameterInvalidError returns a new CloudFoundryError
@@ -415,11 +347,7 @@ func NewOrderByParameterInvalidError() CloudFoundryError {
 // - HTTP code: 500
 // - message: "Cannot order by: %s"
 func IsOrderByParameterInvalidError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 10012
+	return errors.Is(err, CloudFoundryError{Code: 10012})
 }
 
 // NewRateLimitExceededError returns a new CloudFoundryError
@@ -438,11 +366,7 @@ func NewRateLimitExceededError() CloudFoundryError {
 // - HTTP code: 429
 // - message: "Rate Limit Exceeded"
 func IsRateLimitExceededError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 10013
+	return errors.Is(err, CloudFoundryError{Code: 10013})
 }
 
 // NewIPBasedRateLimitExceededError returns a new CloudFoundryError
@@ -461,11 +385,7 @@ func NewIPBasedRateLimitExceededError() CloudFoundryError {
 // - HTTP code: 429
 // - message: "Rate Limit Exceeded: Unauthenticated requests from this IP address have exceeded the limit. Please log in."
 func IsIPBasedRateLimitExceededError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 10014
+	return errors.Is(err, CloudFoundryError{Code: 10014})
 }
 
 // NewServiceUnavailableError returns a new CloudFoundryError
@@ -484,11 +404,7 @@ func NewServiceUnavailableError() CloudFoundryError {
 // - HTTP code: 503
 // - message: "%s"
 func IsServiceUnavailableError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 10015
+	return errors.Is(err, CloudFoundryError{Code: 10015})
 }
 
 // NewServiceBrokerRateLimitExceededError returns a new CloudFoundryError
@@ -507,11 +423,7 @@ func NewServiceBrokerRateLimitExceededError() CloudFoundryError {
 // - HTTP code: 429
 // - message: "Service broker concurrent request limit exceeded"
 func IsServiceBrokerRateLimitExceededError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 10016
+	return errors.Is(err, CloudFoundryError{Code: 10016})
 }
 
 // NewOrgSuspendedError returns a new CloudFoundryError
@@ -530,11 +442,7 @@ func NewOrgSuspendedError() CloudFoundryError {
 // - HTTP code: 403
 // - message: "The organization is suspended"
 func IsOrgSuspendedError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 10017
+	return errors.Is(err, CloudFoundryError{Code: 10017})
 }
 
 // NewRateLimitV2APIExceededError returns a new CloudFoundryError
@@ -553,11 +461,7 @@ func NewRateLimitV2APIExceededError() CloudFoundryError {
 // - HTTP code: 429
 // - message: "Rate Limit of V2 API Exceeded. Please consider using the V3 API"
 func IsRateLimitV2APIExceededError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 10018
+	return errors.Is(err, CloudFoundryError{Code: 10018})
 }
 
 // NewUserInvalidError returns a new CloudFoundryError
@@ -576,11 +480,7 @@ func NewUserInvalidError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The user info is invalid: %s"
 func IsUserInvalidError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 20001
+	return errors.Is(err, CloudFoundryError{Code: 20001})
 }
 
 // NewUAAIDTakenError returns a new CloudFoundryError
@@ -599,11 +499,7 @@ func NewUAAIDTakenError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The UAA ID is taken: %s"
 func IsUAAIDTakenError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 20002
+	return errors.Is(err, CloudFoundryError{Code: 20002})
 }
 
 // NewUserNotFoundError returns a new CloudFoundryError
@@ -622,11 +518,7 @@ func NewUserNotFoundError() CloudFoundryError {
 // - HTTP code: 404
 // - message: "The user could not be found: %s"
 func IsUserNotFoundError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 20003
+	return errors.Is(err, CloudFoundryError{Code: 20003})
 }
 
 // NewUAAUnavailableError returns a new CloudFoundryError
@@ -645,11 +537,7 @@ func NewUAAUnavailableError() CloudFoundryError {
 // - HTTP code: 503
 // - message: "The UAA service is currently unavailable"
 func IsUAAUnavailableError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 20004
+	return errors.Is(err, CloudFoundryError{Code: 20004})
 }
 
 // NewUserIsInMultipleOriginsError returns a new CloudFoundryError
@@ -668,11 +556,7 @@ func NewUserIsInMultipleOriginsError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The user exists in multiple origins. Specify an origin for the requested user from: %s"
 func IsUserIsInMultipleOriginsError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 20006
+	return errors.Is(err, CloudFoundryError{Code: 20006})
 }
 
 // NewUserWithOriginNotFoundError returns a new CloudFoundryError
@@ -691,11 +575,7 @@ func NewUserWithOriginNotFoundError() CloudFoundryError {
 // - HTTP code: 404
 // - message: "The user could not be found, %s"
 func IsUserWithOriginNotFoundError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 20007
+	return errors.Is(err, CloudFoundryError{Code: 20007})
 }
 
 // NewOutOfRouterGroupPortsError returns a new CloudFoundryError
@@ -714,11 +594,7 @@ func NewOutOfRouterGroupPortsError() CloudFoundryError {
 // - HTTP code: 403
 // - message: "There are no more ports available for router group: %s. Please contact your administrator for more information."
 func IsOutOfRouterGroupPortsError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 21008
+	return errors.Is(err, CloudFoundryError{Code: 21008})
 }
 
 // NewOrganizationInvalidError returns a new CloudFoundryError
@@ -737,11 +613,7 @@ func NewOrganizationInvalidError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The organization info is invalid: %s"
 func IsOrganizationInvalidError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 30001
+	return errors.Is(err, CloudFoundryError{Code: 30001})
 }
 
 // NewOrganizationNameTakenError returns a new CloudFoundryError
@@ -760,11 +632,7 @@ func NewOrganizationNameTakenError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The organization name is taken: %s"
 func IsOrganizationNameTakenError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 30002
+	return errors.Is(err, CloudFoundryError{Code: 30002})
 }
 
 // NewOrganizationNotFoundError returns a new CloudFoundryError
@@ -783,11 +651,7 @@ func NewOrganizationNotFoundError() CloudFoundryError {
 // - HTTP code: 404
 // - message: "The organization could not be found: %s"
 func IsOrganizationNotFoundError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 30003
+	return errors.Is(err, CloudFoundryError{Code: 30003})
 }
 
 // NewLastManagerInOrgError returns a new CloudFoundryError
@@ -806,11 +670,7 @@ func NewLastManagerInOrgError() CloudFoundryError {
 // - HTTP code: 403
 // - message: "Cannot remove last Org Manager in org"
 func IsLastManagerInOrgError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 30004
+	return errors.Is(err, CloudFoundryError{Code: 30004})
 }
 
 // NewLastBillingManagerInOrgError returns a new CloudFoundryError
@@ -829,11 +689,7 @@ func NewLastBillingManagerInOrgError() CloudFoundryError {
 // - HTTP code: 403
 // - message: "Cannot remove last Billing Manager in org"
 func IsLastBillingManagerInOrgError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 30005
+	return errors.Is(err, CloudFoundryError{Code: 30005})
 }
 
 // NewLastUserInOrgError returns a new CloudFoundryError
@@ -852,11 +708,7 @@ func NewLastUserInOrgError() CloudFoundryError {
 // - HTTP code: 403
 // - message: "Cannot remove last User in org"
 func IsLastUserInOrgError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 30006
+	return errors.Is(err, CloudFoundryError{Code: 30006})
 }
 
 // NewOrganizationAlreadySetError returns a new CloudFoundryError
@@ -875,11 +727,7 @@ func NewOrganizationAlreadySetError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "Cannot change organization"
 func IsOrganizationAlreadySetError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 30007
+	return errors.Is(err, CloudFoundryError{Code: 30007})
 }
 
 // NewSpaceInvalidError returns a new CloudFoundryError
@@ -898,11 +746,7 @@ func NewSpaceInvalidError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The app space info is invalid: %s"
 func IsSpaceInvalidError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 40001
+	return errors.Is(err, CloudFoundryError{Code: 40001})
 }
 
 // NewSpaceNameTakenError returns a new CloudFoundryError
@@ -921,11 +765,7 @@ func NewSpaceNameTakenError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The app space name is taken: %s"
 func IsSpaceNameTakenError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 40002
+	return errors.Is(err, CloudFoundryError{Code: 40002})
 }
 
 // NewSpaceUserNotInOrgError returns a new CloudFoundryError
@@ -944,11 +784,7 @@ func NewSpaceUserNotInOrgError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The app space and the user are not in the same org: %s"
 func IsSpaceUserNotInOrgError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 40003
+	return errors.Is(err, CloudFoundryError{Code: 40003})
 }
 
 // NewSpaceNotFoundError returns a new CloudFoundryError
@@ -967,11 +803,7 @@ func NewSpaceNotFoundError() CloudFoundryError {
 // - HTTP code: 404
 // - message: "The app space could not be found: %s"
 func IsSpaceNotFoundError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 40004
+	return errors.Is(err, CloudFoundryError{Code: 40004})
 }
 
 // NewServiceInstanceNameEmptyError returns a new CloudFoundryError
@@ -990,11 +822,7 @@ func NewServiceInstanceNameEmptyError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "Service instance name is required."
 func IsServiceInstanceNameEmptyError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 60001
+	return errors.Is(err, CloudFoundryError{Code: 60001})
 }
 
 // NewServiceInstanceNameTakenError returns a new CloudFoundryError
@@ -1013,11 +841,7 @@ func NewServiceInstanceNameTakenError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The service instance name is taken: %s"
 func IsServiceInstanceNameTakenError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 60002
+	return errors.Is(err, CloudFoundryError{Code: 60002})
 }
 
 // NewServiceInstanceInvalidError returns a new CloudFoundryError
@@ -1036,11 +860,7 @@ func NewServiceInstanceInvalidError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The service instance is invalid: %s"
 func IsServiceInstanceInvalidError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 60003
+	return errors.Is(err, CloudFoundryError{Code: 60003})
 }
 
 // NewServiceInstanceNotFoundError returns a new CloudFoundryError
@@ -1059,11 +879,7 @@ func NewServiceInstanceNotFoundError() CloudFoundryError {
 // - HTTP code: 404
 // - message: "The service instance could not be found: %s"
 func IsServiceInstanceNotFoundError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 60004
+	return errors.Is(err, CloudFoundryError{Code: 60004})
 }
 
 // NewServiceInstanceQuotaExceededError returns a new CloudFoundryError
@@ -1082,11 +898,7 @@ func NewServiceInstanceQuotaExceededError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "You have exceeded your organization's services limit."
 func IsServiceInstanceQuotaExceededError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 60005
+	return errors.Is(err, CloudFoundryError{Code: 60005})
 }
 
 // NewPreviouslyUsedAs_ServiceInstancePaidQuotaExceededError returns a new CloudFoundryError
@@ -1105,11 +917,7 @@ func NewPreviouslyUsedAs_ServiceInstancePaidQuotaExceededError() CloudFoundryErr
 // - HTTP code: 400
 // - message: "You have exceeded your organization's services limit."
 func IsPreviouslyUsedAs_ServiceInstancePaidQuotaExceededError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 60006
+	return errors.Is(err, CloudFoundryError{Code: 60006})
 }
 
 // NewServiceInstanceServicePlanNotAllowedError returns a new CloudFoundryError
@@ -1128,11 +936,7 @@ func NewServiceInstanceServicePlanNotAllowedError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The service instance cannot be created because paid service plans are not allowed."
 func IsServiceInstanceServicePlanNotAllowedError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 60007
+	return errors.Is(err, CloudFoundryError{Code: 60007})
 }
 
 // NewServiceInstanceDuplicateNotAllowedError returns a new CloudFoundryError
@@ -1151,11 +955,7 @@ func NewServiceInstanceDuplicateNotAllowedError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "An instance of this service is already present in this space. Some services only support one instance per space."
 func IsServiceInstanceDuplicateNotAllowedError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 60008
+	return errors.Is(err, CloudFoundryError{Code: 60008})
 }
 
 // NewServiceInstanceNameTooLongError returns a new CloudFoundryError
@@ -1174,11 +974,7 @@ func NewServiceInstanceNameTooLongError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "You have requested an invalid service instance name. Names are limited to 255 characters."
 func IsServiceInstanceNameTooLongError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 60009
+	return errors.Is(err, CloudFoundryError{Code: 60009})
 }
 
 // NewServiceInstanceOrganizationNotAuthorizedError returns a new CloudFoundryError
@@ -1197,11 +993,7 @@ func NewServiceInstanceOrganizationNotAuthorizedError() CloudFoundryError {
 // - HTTP code: 403
 // - message: "A service instance for the selected plan cannot be created in this organization. The plan is visible because another organization you belong to has access to it."
 func IsServiceInstanceOrganizationNotAuthorizedError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 60010
+	return errors.Is(err, CloudFoundryError{Code: 60010})
 }
 
 // NewServiceInstanceDeprovisionFailedError returns a new CloudFoundryError
@@ -1220,11 +1012,7 @@ func NewServiceInstanceDeprovisionFailedError() CloudFoundryError {
 // - HTTP code: 409
 // - message: "The service broker reported an error during deprovisioning: %s"
 func IsServiceInstanceDeprovisionFailedError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 60011
+	return errors.Is(err, CloudFoundryError{Code: 60011})
 }
 
 // NewServiceInstanceSpaceQuotaExceededError returns a new CloudFoundryError
@@ -1243,11 +1031,7 @@ func NewServiceInstanceSpaceQuotaExceededError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "You have exceeded your space's services limit."
 func IsServiceInstanceSpaceQuotaExceededError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 60012
+	return errors.Is(err, CloudFoundryError{Code: 60012})
 }
 
 // NewServiceInstanceServicePlanNotAllowedBySpaceQuotaError returns a new CloudFoundryError
@@ -1266,11 +1050,7 @@ func NewServiceInstanceServicePlanNotAllowedBySpaceQuotaError() CloudFoundryErro
 // - HTTP code: 400
 // - message: "The service instance cannot be created because paid service plans are not allowed for your space."
 func IsServiceInstanceServicePlanNotAllowedBySpaceQuotaError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 60013
+	return errors.Is(err, CloudFoundryError{Code: 60013})
 }
 
 // NewServiceInstanceSpaceChangeNotAllowedError returns a new CloudFoundryError
@@ -1289,11 +1069,7 @@ func NewServiceInstanceSpaceChangeNotAllowedError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "Cannot update space for service instance."
 func IsServiceInstanceSpaceChangeNotAllowedError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 60014
+	return errors.Is(err, CloudFoundryError{Code: 60014})
 }
 
 // NewServiceInstanceTagsTooLongError returns a new CloudFoundryError
@@ -1312,11 +1088,7 @@ func NewServiceInstanceTagsTooLongError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "Combined length of tags for service %s must be 2048 characters or less."
 func IsServiceInstanceTagsTooLongError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 60015
+	return errors.Is(err, CloudFoundryError{Code: 60015})
 }
 
 // NewAsyncServiceInstanceOperationInProgressError returns a new CloudFoundryError
@@ -1335,11 +1107,7 @@ func NewAsyncServiceInstanceOperationInProgressError() CloudFoundryError {
 // - HTTP code: 409
 // - message: "An operation for service instance %s is in progress."
 func IsAsyncServiceInstanceOperationInProgressError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 60016
+	return errors.Is(err, CloudFoundryError{Code: 60016})
 }
 
 // NewServiceInstanceRouteBindingSpaceMismatchError returns a new CloudFoundryError
@@ -1358,11 +1126,7 @@ func NewServiceInstanceRouteBindingSpaceMismatchError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The service instance and the route are in different spaces."
 func IsServiceInstanceRouteBindingSpaceMismatchError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 60017
+	return errors.Is(err, CloudFoundryError{Code: 60017})
 }
 
 // NewServiceInstanceSpaceNotAuthorizedError returns a new CloudFoundryError
@@ -1381,11 +1145,7 @@ func NewServiceInstanceSpaceNotAuthorizedError() CloudFoundryError {
 // - HTTP code: 403
 // - message: "A service instance for the selected plan cannot be created in this space."
 func IsServiceInstanceSpaceNotAuthorizedError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 60018
+	return errors.Is(err, CloudFoundryError{Code: 60018})
 }
 
 // NewServiceInstanceRouteServiceURLInvalidError returns a new CloudFoundryError
@@ -1404,11 +1164,7 @@ func NewServiceInstanceRouteServiceURLInvalidError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The route service URL is invalid: %s"
 func IsServiceInstanceRouteServiceURLInvalidError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 60019
+	return errors.Is(err, CloudFoundryError{Code: 60019})
 }
 
 // NewServiceInstanceRouteServiceRequiresDiegoError returns a new CloudFoundryError
@@ -1427,11 +1183,7 @@ func NewServiceInstanceRouteServiceRequiresDiegoError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "Route services are only supported for apps on Diego. Unbind the service instance from the route or enable Diego for the app."
 func IsServiceInstanceRouteServiceRequiresDiegoError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 60020
+	return errors.Is(err, CloudFoundryError{Code: 60020})
 }
 
 // NewServiceInstanceRouteServiceDisabledError returns a new CloudFoundryError
@@ -1450,11 +1202,7 @@ func NewServiceInstanceRouteServiceDisabledError() CloudFoundryError {
 // - HTTP code: 403
 // - message: "Support for route services is disabled"
 func IsServiceInstanceRouteServiceDisabledError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 60021
+	return errors.Is(err, CloudFoundryError{Code: 60021})
 }
 
 // NewAppPortMappingRequiresDiegoError returns a new CloudFoundryError
@@ -1473,11 +1221,7 @@ func NewAppPortMappingRequiresDiegoError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "App ports are supported for Diego apps only."
 func IsAppPortMappingRequiresDiegoError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 60022
+	return errors.Is(err, CloudFoundryError{Code: 60022})
 }
 
 // NewRoutePortNotEnabledOnAppError returns a new CloudFoundryError
@@ -1496,11 +1240,7 @@ func NewRoutePortNotEnabledOnAppError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "Routes can only be mapped to ports already enabled for the application."
 func IsRoutePortNotEnabledOnAppError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 60023
+	return errors.Is(err, CloudFoundryError{Code: 60023})
 }
 
 // NewMultipleAppPortsMappedDiegoToDeaError returns a new CloudFoundryError
@@ -1519,11 +1259,7 @@ func NewMultipleAppPortsMappedDiegoToDeaError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The app has routes mapped to multiple ports. Multiple ports are supported for Diego only. Please unmap routes from all but one app port. Multiple routes can be mapped to the same port if desired."
 func IsMultipleAppPortsMappedDiegoToDeaError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 60024
+	return errors.Is(err, CloudFoundryError{Code: 60024})
 }
 
 // NewVolumeMountServiceDisabledError returns a new CloudFoundryError
@@ -1542,11 +1278,7 @@ func NewVolumeMountServiceDisabledError() CloudFoundryError {
 // - HTTP code: 403
 // - message: "Support for volume mount services is disabled"
 func IsVolumeMountServiceDisabledError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 60025
+	return errors.Is(err, CloudFoundryError{Code: 60025})
 }
 
 // NewDockerAppToDeaError returns a new CloudFoundryError
@@ -1565,11 +1297,7 @@ func NewDockerAppToDeaError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "Docker apps cannot run on DEAs"
 func IsDockerAppToDeaError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 60026
+	return errors.Is(err, CloudFoundryError{Code: 60026})
 }
 
 // NewServiceInstanceRecursiveDeleteFailedError returns a new CloudFoundryError
@@ -1588,11 +1316,7 @@ func NewServiceInstanceRecursiveDeleteFailedError() CloudFoundryError {
 // - HTTP code: 502
 // - message: "Deletion of service instance %s failed because one or more associated resources could not be deleted.\n\n%s"
 func IsServiceInstanceRecursiveDeleteFailedError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 60027
+	return errors.Is(err, CloudFoundryError{Code: 60027})
 }
 
 // NewManagedServiceInstanceNotFoundError returns a new CloudFoundryError
@@ -1611,11 +1335,7 @@ func NewManagedServiceInstanceNotFoundError() CloudFoundryError {
 // - HTTP code: 404
 // - message: "The service instance could not be found: %s"
 func IsManagedServiceInstanceNotFoundError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 60028
+	return errors.Is(err, CloudFoundryError{Code: 60028})
 }
 
 // NewServiceInstanceWithInaccessiblePlanNotUpdateableError returns a new CloudFoundryError
@@ -1634,11 +1354,7 @@ func NewServiceInstanceWithInaccessiblePlanNotUpdateableError() CloudFoundryErro
 // - HTTP code: 403
 // - message: "Cannot update %s of a service instance that belongs to inaccessible plan"
 func IsServiceInstanceWithInaccessiblePlanNotUpdateableError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 60029
+	return errors.Is(err, CloudFoundryError{Code: 60029})
 }
 
 // NewServiceInstanceProvisionFailedError returns a new CloudFoundryError
@@ -1657,11 +1373,7 @@ func NewServiceInstanceProvisionFailedError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The service broker reported an error during provisioning: %s"
 func IsServiceInstanceProvisionFailedError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 60030
+	return errors.Is(err, CloudFoundryError{Code: 60030})
 }
 
 // NewRuntimeInvalidError returns a new CloudFoundryError
@@ -1680,11 +1392,7 @@ func NewRuntimeInvalidError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The runtime is invalid: %s"
 func IsRuntimeInvalidError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 70001
+	return errors.Is(err, CloudFoundryError{Code: 70001})
 }
 
 // NewRuntimeNameTakenError returns a new CloudFoundryError
@@ -1703,11 +1411,7 @@ func NewRuntimeNameTakenError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The runtime name is taken: %s"
 func IsRuntimeNameTakenError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 70002
+	return errors.Is(err, CloudFoundryError{Code: 70002})
 }
 
 // NewRuntimeNotFoundError returns a new CloudFoundryError
@@ -1726,11 +1430,7 @@ func NewRuntimeNotFoundError() CloudFoundryError {
 // - HTTP code: 404
 // - message: "The runtime could not be found: %s"
 func IsRuntimeNotFoundError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 70003
+	return errors.Is(err, CloudFoundryError{Code: 70003})
 }
 
 // NewFrameworkInvalidError returns a new CloudFoundryError
@@ -1749,11 +1449,7 @@ func NewFrameworkInvalidError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The framework is invalid: %s"
 func IsFrameworkInvalidError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 80001
+	return errors.Is(err, CloudFoundryError{Code: 80001})
 }
 
 // NewFrameworkNameTakenError returns a new CloudFoundryError
@@ -1772,11 +1468,7 @@ func NewFrameworkNameTakenError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The framework name is taken: %s"
 func IsFrameworkNameTakenError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 80002
+	return errors.Is(err, CloudFoundryError{Code: 80002})
 }
 
 // NewFrameworkNotFoundError returns a new CloudFoundryError
@@ -1795,11 +1487,7 @@ func NewFrameworkNotFoundError() CloudFoundryError {
 // - HTTP code: 404
 // - message: "The framework could not be found: %s"
 func IsFrameworkNotFoundError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 80003
+	return errors.Is(err, CloudFoundryError{Code: 80003})
 }
 
 // NewServiceBindingInvalidError returns a new CloudFoundryError
@@ -1818,11 +1506,7 @@ func NewServiceBindingInvalidError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The service binding is invalid: %s"
 func IsServiceBindingInvalidError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 90001
+	return errors.Is(err, CloudFoundryError{Code: 90001})
 }
 
 // NewServiceBindingDifferentSpacesError returns a new CloudFoundryError
@@ -1841,11 +1525,7 @@ func NewServiceBindingDifferentSpacesError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The app and the service are not in the same app space: %s"
 func IsServiceBindingDifferentSpacesError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 90002
+	return errors.Is(err, CloudFoundryError{Code: 90002})
 }
 
 // NewServiceBindingAppServiceTakenError returns a new CloudFoundryError
@@ -1864,11 +1544,7 @@ func NewServiceBindingAppServiceTakenError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "%s"
 func IsServiceBindingAppServiceTakenError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 90003
+	return errors.Is(err, CloudFoundryError{Code: 90003})
 }
 
 // NewServiceBindingNotFoundError returns a new CloudFoundryError
@@ -1887,11 +1563,7 @@ func NewServiceBindingNotFoundError() CloudFoundryError {
 // - HTTP code: 404
 // - message: "The service binding could not be found: %s"
 func IsServiceBindingNotFoundError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 90004
+	return errors.Is(err, CloudFoundryError{Code: 90004})
 }
 
 // NewUnbindableServiceError returns a new CloudFoundryError
@@ -1910,11 +1582,7 @@ func NewUnbindableServiceError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The service instance doesn't support binding."
 func IsUnbindableServiceError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 90005
+	return errors.Is(err, CloudFoundryError{Code: 90005})
 }
 
 // NewInvalidLoggingServiceBindingError returns a new CloudFoundryError
@@ -1933,11 +1601,7 @@ func NewInvalidLoggingServiceBindingError() CloudFoundryError {
 // - HTTP code: 502
 // - message: "The service is attempting to stream logs from your application, but is not registered as a logging service. Please contact the service provider."
 func IsInvalidLoggingServiceBindingError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 90006
+	return errors.Is(err, CloudFoundryError{Code: 90006})
 }
 
 // NewServiceFetchBindingParametersNotSupportedError returns a new CloudFoundryError
@@ -1956,11 +1620,7 @@ func NewServiceFetchBindingParametersNotSupportedError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "This service does not support fetching service binding parameters."
 func IsServiceFetchBindingParametersNotSupportedError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 90007
+	return errors.Is(err, CloudFoundryError{Code: 90007})
 }
 
 // NewAsyncServiceBindingOperationInProgressError returns a new CloudFoundryError
@@ -1979,11 +1639,7 @@ func NewAsyncServiceBindingOperationInProgressError() CloudFoundryError {
 // - HTTP code: 409
 // - message: "An operation for the service binding between app %s and service instance %s is in progress."
 func IsAsyncServiceBindingOperationInProgressError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 90008
+	return errors.Is(err, CloudFoundryError{Code: 90008})
 }
 
 // NewAppInvalidError returns a new CloudFoundryError
@@ -2002,11 +1658,7 @@ func NewAppInvalidError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The app is invalid: %s"
 func IsAppInvalidError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 100001
+	return errors.Is(err, CloudFoundryError{Code: 100001})
 }
 
 // NewAppNameTakenError returns a new CloudFoundryError
@@ -2025,11 +1677,7 @@ func NewAppNameTakenError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The app name is taken: %s"
 func IsAppNameTakenError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 100002
+	return errors.Is(err, CloudFoundryError{Code: 100002})
 }
 
 // NewAppNotFoundError returns a new CloudFoundryError
@@ -2048,11 +1696,7 @@ func NewAppNotFoundError() CloudFoundryError {
 // - HTTP code: 404
 // - message: "The app could not be found: %s"
 func IsAppNotFoundError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 100004
+	return errors.Is(err, CloudFoundryError{Code: 100004})
 }
 
 // NewAppMemoryQuotaExceededError returns a new CloudFoundryError
@@ -2071,11 +1715,7 @@ func NewAppMemoryQuotaExceededError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "You have exceeded your organization's memory limit: %s"
 func IsAppMemoryQuotaExceededError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 100005
+	return errors.Is(err, CloudFoundryError{Code: 100005})
 }
 
 // NewAppMemoryInvalidError returns a new CloudFoundryError
@@ -2094,11 +1734,7 @@ func NewAppMemoryInvalidError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "You have specified an invalid amount of memory for your application."
 func IsAppMemoryInvalidError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 100006
+	return errors.Is(err, CloudFoundryError{Code: 100006})
 }
 
 // NewQuotaInstanceMemoryLimitExceededError returns a new CloudFoundryError
@@ -2117,11 +1753,7 @@ func NewQuotaInstanceMemoryLimitExceededError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "You have exceeded the instance memory limit for your organization's quota."
 func IsQuotaInstanceMemoryLimitExceededError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 100007
+	return errors.Is(err, CloudFoundryError{Code: 100007})
 }
 
 // NewQuotaInstanceLimitExceededError returns a new CloudFoundryError
@@ -2140,11 +1772,7 @@ func NewQuotaInstanceLimitExceededError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "You have exceeded the instance limit for your organization's quota."
 func IsQuotaInstanceLimitExceededError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 100008
+	return errors.Is(err, CloudFoundryError{Code: 100008})
 }
 
 // NewAppMemoryInsufficientForSidecarsError returns a new CloudFoundryError
@@ -2163,11 +1791,7 @@ func NewAppMemoryInsufficientForSidecarsError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The requested memory allocation is not large enough to run all of your sidecar processes."
 func IsAppMemoryInsufficientForSidecarsError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 100009
+	return errors.Is(err, CloudFoundryError{Code: 100009})
 }
 
 // NewOrgQuotaLogRateLimitExceededError returns a new CloudFoundryError
@@ -2186,11 +1810,7 @@ func NewOrgQuotaLogRateLimitExceededError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "You have exceeded your organization's log rate limit: %s"
 func IsOrgQuotaLogRateLimitExceededError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 100010
+	return errors.Is(err, CloudFoundryError{Code: 100010})
 }
 
 // NewServicePlanInvalidError returns a new CloudFoundryError
@@ -2209,11 +1829,7 @@ func NewServicePlanInvalidError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The service plan is invalid: %s"
 func IsServicePlanInvalidError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 110001
+	return errors.Is(err, CloudFoundryError{Code: 110001})
 }
 
 // NewServicePlanNameTakenError returns a new CloudFoundryError
@@ -2232,11 +1848,7 @@ func NewServicePlanNameTakenError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The service plan name is taken: %s"
 func IsServicePlanNameTakenError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 110002
+	return errors.Is(err, CloudFoundryError{Code: 110002})
 }
 
 // NewServicePlanNotFoundError returns a new CloudFoundryError
@@ -2255,11 +1867,7 @@ func NewServicePlanNotFoundError() CloudFoundryError {
 // - HTTP code: 404
 // - message: "The service plan could not be found: %s"
 func IsServicePlanNotFoundError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 110003
+	return errors.Is(err, CloudFoundryError{Code: 110003})
 }
 
 // NewServicePlanNotUpdateableError returns a new CloudFoundryError
@@ -2278,11 +1886,7 @@ func NewServicePlanNotUpdateableError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The service does not support changing plans."
 func IsServicePlanNotUpdateableError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 110004
+	return errors.Is(err, CloudFoundryError{Code: 110004})
 }
 
 // NewServiceInvalidError returns a new CloudFoundryError
@@ -2301,11 +1905,7 @@ func NewServiceInvalidError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The service is invalid: %s"
 func IsServiceInvalidError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 120001
+	return errors.Is(err, CloudFoundryError{Code: 120001})
 }
 
 // NewServiceLabelTakenError returns a new CloudFoundryError
@@ -2324,11 +1924,7 @@ func NewServiceLabelTakenError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The service label is taken: %s"
 func IsServiceLabelTakenError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 120002
+	return errors.Is(err, CloudFoundryError{Code: 120002})
 }
 
 // NewServiceNotFoundError returns a new CloudFoundryError
@@ -2347,11 +1943,7 @@ func NewServiceNotFoundError() CloudFoundryError {
 // - HTTP code: 404
 // - message: "The service could not be found: %s"
 func IsServiceNotFoundError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 120003
+	return errors.Is(err, CloudFoundryError{Code: 120003})
 }
 
 // NewServiceFetchInstanceParametersNotSupportedError returns a new CloudFoundryError
@@ -2370,11 +1962,7 @@ func NewServiceFetchInstanceParametersNotSupportedError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "This service does not support fetching service instance parameters."
 func IsServiceFetchInstanceParametersNotSupportedError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 120004
+	return errors.Is(err, CloudFoundryError{Code: 120004})
 }
 
 // NewDomainInvalidError returns a new CloudFoundryError
@@ -2393,11 +1981,7 @@ func NewDomainInvalidError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The domain is invalid: %s"
 func IsDomainInvalidError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 130001
+	return errors.Is(err, CloudFoundryError{Code: 130001})
 }
 
 // NewDomainNotFoundError returns a new CloudFoundryError
@@ -2416,11 +2000,7 @@ func NewDomainNotFoundError() CloudFoundryError {
 // - HTTP code: 404
 // - message: "The domain could not be found: %s"
 func IsDomainNotFoundError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 130002
+	return errors.Is(err, CloudFoundryError{Code: 130002})
 }
 
 // NewDomainNameTakenError returns a new CloudFoundryError
@@ -2439,11 +2019,7 @@ func NewDomainNameTakenError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The domain name is taken: %s"
 func IsDomainNameTakenError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 130003
+	return errors.Is(err, CloudFoundryError{Code: 130003})
 }
 
 // NewPathInvalidError returns a new CloudFoundryError
@@ -2462,11 +2038,7 @@ func NewPathInvalidError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The path is invalid: %s"
 func IsPathInvalidError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 130004
+	return errors.Is(err, CloudFoundryError{Code: 130004})
 }
 
 // NewTotalPrivateDomainsExceededError returns a new CloudFoundryError
@@ -2485,11 +2057,7 @@ func NewTotalPrivateDomainsExceededError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The number of private domains exceeds the quota for organization: %s"
 func IsTotalPrivateDomainsExceededError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 130005
+	return errors.Is(err, CloudFoundryError{Code: 130005})
 }
 
 // NewServiceDoesNotSupportRoutesError returns a new CloudFoundryError
@@ -2508,11 +2076,7 @@ func NewServiceDoesNotSupportRoutesError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "This service does not support route binding."
 func IsServiceDoesNotSupportRoutesError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 130006
+	return errors.Is(err, CloudFoundryError{Code: 130006})
 }
 
 // NewRouteAlreadyBoundToServiceInstanceError returns a new CloudFoundryError
@@ -2531,11 +2095,7 @@ func NewRouteAlreadyBoundToServiceInstanceError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "A route may only be bound to a single service instance"
 func IsRouteAlreadyBoundToServiceInstanceError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 130007
+	return errors.Is(err, CloudFoundryError{Code: 130007})
 }
 
 // NewServiceInstanceAlreadyBoundToSameRouteError returns a new CloudFoundryError
@@ -2554,11 +2114,7 @@ func NewServiceInstanceAlreadyBoundToSameRouteError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The route and service instance are already bound."
 func IsServiceInstanceAlreadyBoundToSameRouteError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 130008
+	return errors.Is(err, CloudFoundryError{Code: 130008})
 }
 
 // NewInternalDomainCannotBeDeletedError returns a new CloudFoundryError
@@ -2577,11 +2133,7 @@ func NewInternalDomainCannotBeDeletedError() CloudFoundryError {
 // - HTTP code: 422
 // - message: "The domain '%s' cannot be deleted. It is reserved by the platform."
 func IsInternalDomainCannotBeDeletedError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 130009
+	return errors.Is(err, CloudFoundryError{Code: 130009})
 }
 
 // NewRouteServiceCannotBeBoundToInternalRouteError returns a new CloudFoundryError
@@ -2600,11 +2152,7 @@ func NewRouteServiceCannotBeBoundToInternalRouteError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "Route services cannot be bound to internal routes."
 func IsRouteServiceCannotBeBoundToInternalRouteError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 130010
+	return errors.Is(err, CloudFoundryError{Code: 130010})
 }
 
 // NewLegacyApiWithoutDefaultSpaceError returns a new CloudFoundryError
@@ -2623,11 +2171,7 @@ func NewLegacyApiWithoutDefaultSpaceError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "A legacy api call requiring a default app space was called, but no default app space is set for the user."
 func IsLegacyApiWithoutDefaultSpaceError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 140001
+	return errors.Is(err, CloudFoundryError{Code: 140001})
 }
 
 // NewAppPackageInvalidError returns a new CloudFoundryError
@@ -2646,11 +2190,7 @@ func NewAppPackageInvalidError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The app package is invalid: %s"
 func IsAppPackageInvalidError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 150001
+	return errors.Is(err, CloudFoundryError{Code: 150001})
 }
 
 // NewAppPackageNotFoundError returns a new CloudFoundryError
@@ -2669,11 +2209,7 @@ func NewAppPackageNotFoundError() CloudFoundryError {
 // - HTTP code: 404
 // - message: "The app package could not be found: %s"
 func IsAppPackageNotFoundError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 150002
+	return errors.Is(err, CloudFoundryError{Code: 150002})
 }
 
 // NewInsufficientRunningResourcesAvailableError returns a new CloudFoundryError
@@ -2692,11 +2228,7 @@ func NewInsufficientRunningResourcesAvailableError() CloudFoundryError {
 // - HTTP code: 503
 // - message: "One or more instances could not be started because of insufficient running resources."
 func IsInsufficientRunningResourcesAvailableError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 150003
+	return errors.Is(err, CloudFoundryError{Code: 150003})
 }
 
 // NewPackageBitsAlreadyUploadedError returns a new CloudFoundryError
@@ -2715,11 +2247,7 @@ func NewPackageBitsAlreadyUploadedError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "Bits may be uploaded only once. Create a new package to upload different bits."
 func IsPackageBitsAlreadyUploadedError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 150004
+	return errors.Is(err, CloudFoundryError{Code: 150004})
 }
 
 // NewBlobstoreNotLocalError returns a new CloudFoundryError
@@ -2738,11 +2266,7 @@ func NewBlobstoreNotLocalError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "Downloading blobs can only be done directly to the blobstore."
 func IsBlobstoreNotLocalError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 150005
+	return errors.Is(err, CloudFoundryError{Code: 150005})
 }
 
 // NewBlobstoreUnavailableError returns a new CloudFoundryError
@@ -2761,11 +2285,7 @@ func NewBlobstoreUnavailableError() CloudFoundryError {
 // - HTTP code: 502
 // - message: "Failed to perform operation due to blobstore unavailability."
 func IsBlobstoreUnavailableError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 150006
+	return errors.Is(err, CloudFoundryError{Code: 150006})
 }
 
 // NewBlobstoreError returns a new CloudFoundryError
@@ -2784,11 +2304,7 @@ func NewBlobstoreError() CloudFoundryError {
 // - HTTP code: 500
 // - message: "Failed to perform blobstore operation after three retries."
 func IsBlobstoreError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 150007
+	return errors.Is(err, CloudFoundryError{Code: 150007})
 }
 
 // NewDockerImageMissingError returns a new CloudFoundryError
@@ -2807,11 +2323,7 @@ func NewDockerImageMissingError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "Docker credentials can only be supplied for apps with a 'docker_image'"
 func IsDockerImageMissingError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 150008
+	return errors.Is(err, CloudFoundryError{Code: 150008})
 }
 
 // NewAppRecursiveDeleteFailedError returns a new CloudFoundryError
@@ -2830,11 +2342,7 @@ func NewAppRecursiveDeleteFailedError() CloudFoundryError {
 // - HTTP code: 502
 // - message: "Deletion of app %s failed because one or more associated resources could not be deleted.\n\n%s"
 func IsAppRecursiveDeleteFailedError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 150009
+	return errors.Is(err, CloudFoundryError{Code: 150009})
 }
 
 // NewResourceChecksumMismatchError returns a new CloudFoundryError
@@ -2853,11 +2361,7 @@ func NewResourceChecksumMismatchError() CloudFoundryError {
 // - HTTP code: 500
 // - message: "One or more cached resources did not match the given checksum. They have been deleted; please retry package upload."
 func IsResourceChecksumMismatchError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 150010
+	return errors.Is(err, CloudFoundryError{Code: 150010})
 }
 
 // NewAppBitsUploadInvalidError returns a new CloudFoundryError
@@ -2876,11 +2380,7 @@ func NewAppBitsUploadInvalidError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The app upload is invalid: %s"
 func IsAppBitsUploadInvalidError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 160001
+	return errors.Is(err, CloudFoundryError{Code: 160001})
 }
 
 // NewAppBitsCopyInvalidError returns a new CloudFoundryError
@@ -2899,11 +2399,7 @@ func NewAppBitsCopyInvalidError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The app copy is invalid: %s"
 func IsAppBitsCopyInvalidError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 160002
+	return errors.Is(err, CloudFoundryError{Code: 160002})
 }
 
 // NewAppResourcesFileModeInvalidError returns a new CloudFoundryError
@@ -2922,11 +2418,7 @@ func NewAppResourcesFileModeInvalidError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The resource file mode is invalid: %s"
 func IsAppResourcesFileModeInvalidError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 160003
+	return errors.Is(err, CloudFoundryError{Code: 160003})
 }
 
 // NewAppResourcesFilePathInvalidError returns a new CloudFoundryError
@@ -2945,11 +2437,7 @@ func NewAppResourcesFilePathInvalidError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The resource file path is invalid: %s"
 func IsAppResourcesFilePathInvalidError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 160004
+	return errors.Is(err, CloudFoundryError{Code: 160004})
 }
 
 // NewStagingError returns a new CloudFoundryError
@@ -2968,11 +2456,7 @@ func NewStagingError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "Staging error: %s"
 func IsStagingError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 170001
+	return errors.Is(err, CloudFoundryError{Code: 170001})
 }
 
 // NewNotStagedError returns a new CloudFoundryError
@@ -2991,11 +2475,7 @@ func NewNotStagedError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "App has not finished staging"
 func IsNotStagedError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 170002
+	return errors.Is(err, CloudFoundryError{Code: 170002})
 }
 
 // NewNoAppDetectedError returns a new CloudFoundryError
@@ -3014,11 +2494,7 @@ func NewNoAppDetectedError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "An app was not successfully detected by any available buildpack"
 func IsNoAppDetectedError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 170003
+	return errors.Is(err, CloudFoundryError{Code: 170003})
 }
 
 // NewBuildpackCompileFailedError returns a new CloudFoundryError
@@ -3037,11 +2513,7 @@ func NewBuildpackCompileFailedError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "App staging failed in the buildpack compile phase"
 func IsBuildpackCompileFailedError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 170004
+	return errors.Is(err, CloudFoundryError{Code: 170004})
 }
 
 // NewBuildpackReleaseFailedError returns a new CloudFoundryError
@@ -3060,11 +2532,7 @@ func NewBuildpackReleaseFailedError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "App staging failed in the buildpack release phase"
 func IsBuildpackReleaseFailedError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 170005
+	return errors.Is(err, CloudFoundryError{Code: 170005})
 }
 
 // NewNoBuildpacksFoundError returns a new CloudFoundryError
@@ -3083,11 +2551,7 @@ func NewNoBuildpacksFoundError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "There are no buildpacks available"
 func IsNoBuildpacksFoundError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 170006
+	return errors.Is(err, CloudFoundryError{Code: 170006})
 }
 
 // NewStagingTimeExpiredError returns a new CloudFoundryError
@@ -3106,11 +2570,7 @@ func NewStagingTimeExpiredError() CloudFoundryError {
 // - HTTP code: 504
 // - message: "Staging time expired: %s"
 func IsStagingTimeExpiredError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 170007
+	return errors.Is(err, CloudFoundryError{Code: 170007})
 }
 
 // NewInsufficientResourcesError returns a new CloudFoundryError
@@ -3129,11 +2589,7 @@ func NewInsufficientResourcesError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "Insufficient resources"
 func IsInsufficientResourcesError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 170008
+	return errors.Is(err, CloudFoundryError{Code: 170008})
 }
 
 // NewNoCompatibleCellError returns a new CloudFoundryError
@@ -3152,11 +2608,7 @@ func NewNoCompatibleCellError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "Found no compatible cell"
 func IsNoCompatibleCellError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 170009
+	return errors.Is(err, CloudFoundryError{Code: 170009})
 }
 
 // NewStagerUnavailableError returns a new CloudFoundryError
@@ -3175,11 +2627,7 @@ func NewStagerUnavailableError() CloudFoundryError {
 // - HTTP code: 503
 // - message: "Stager is unavailable: %s"
 func IsStagerUnavailableError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 170010
+	return errors.Is(err, CloudFoundryError{Code: 170010})
 }
 
 // NewStagerError returns a new CloudFoundryError
@@ -3198,11 +2646,7 @@ func NewStagerError() CloudFoundryError {
 // - HTTP code: 500
 // - message: "Stager error: %s"
 func IsStagerError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 170011
+	return errors.Is(err, CloudFoundryError{Code: 170011})
 }
 
 // NewRunnerInvalidRequestError returns a new CloudFoundryError
@@ -3221,11 +2665,7 @@ func NewRunnerInvalidRequestError() CloudFoundryError {
 // - HTTP code: 500
 // - message: "Runner invalid request: %s"
 func IsRunnerInvalidRequestError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 170014
+	return errors.Is(err, CloudFoundryError{Code: 170014})
 }
 
 // NewRunnerUnavailableError returns a new CloudFoundryError
@@ -3244,11 +2684,7 @@ func NewRunnerUnavailableError() CloudFoundryError {
 // - HTTP code: 503
 // - message: "Runner is unavailable: %s"
 func IsRunnerUnavailableError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 170015
+	return errors.Is(err, CloudFoundryError{Code: 170015})
 }
 
 // NewRunnerError returns a new CloudFoundryError
@@ -3267,11 +2703,7 @@ func NewRunnerError() CloudFoundryError {
 // - HTTP code: 500
 // - message: "Runner error: %s"
 func IsRunnerError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 170016
+	return errors.Is(err, CloudFoundryError{Code: 170016})
 }
 
 // NewStagingInProgressError returns a new CloudFoundryError
@@ -3290,11 +2722,7 @@ func NewStagingInProgressError() CloudFoundryError {
 // - HTTP code: 422
 // - message: "Only one build can be STAGING at a time per application."
 func IsStagingInProgressError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 170017
+	return errors.Is(err, CloudFoundryError{Code: 170017})
 }
 
 // NewInvalidTaskAddressError returns a new CloudFoundryError
@@ -3313,11 +2741,7 @@ func NewInvalidTaskAddressError() CloudFoundryError {
 // - HTTP code: 500
 // - message: "Invalid config: %s"
 func IsInvalidTaskAddressError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 170018
+	return errors.Is(err, CloudFoundryError{Code: 170018})
 }
 
 // NewTaskError returns a new CloudFoundryError
@@ -3336,11 +2760,7 @@ func NewTaskError() CloudFoundryError {
 // - HTTP code: 500
 // - message: "Task failed: %s"
 func IsTaskError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 170019
+	return errors.Is(err, CloudFoundryError{Code: 170019})
 }
 
 // NewTaskWorkersUnavailableError returns a new CloudFoundryError
@@ -3359,11 +2779,7 @@ func NewTaskWorkersUnavailableError() CloudFoundryError {
 // - HTTP code: 503
 // - message: "Task workers are unavailable: %s"
 func IsTaskWorkersUnavailableError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 170020
+	return errors.Is(err, CloudFoundryError{Code: 170020})
 }
 
 // NewInvalidTaskRequestError returns a new CloudFoundryError
@@ -3382,11 +2798,7 @@ func NewInvalidTaskRequestError() CloudFoundryError {
 // - HTTP code: 422
 // - message: "The task request is invalid: %s"
 func IsInvalidTaskRequestError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 170021
+	return errors.Is(err, CloudFoundryError{Code: 170021})
 }
 
 // NewServiceGatewayError returns a new CloudFoundryError
@@ -3405,11 +2817,7 @@ func NewServiceGatewayError() CloudFoundryError {
 // - HTTP code: 503
 // - message: "Service gateway internal error: %s"
 func IsServiceGatewayError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 180002
+	return errors.Is(err, CloudFoundryError{Code: 180002})
 }
 
 // NewServiceNotImplementedError returns a new CloudFoundryError
@@ -3428,11 +2836,7 @@ func NewServiceNotImplementedError() CloudFoundryError {
 // - HTTP code: 501
 // - message: "Operation not supported for service"
 func IsServiceNotImplementedError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 180003
+	return errors.Is(err, CloudFoundryError{Code: 180003})
 }
 
 // NewSDSNotAvailableError returns a new CloudFoundryError
@@ -3451,11 +2855,7 @@ func NewSDSNotAvailableError() CloudFoundryError {
 // - HTTP code: 501
 // - message: "No serialization service backends available"
 func IsSDSNotAvailableError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 180004
+	return errors.Is(err, CloudFoundryError{Code: 180004})
 }
 
 // NewFileError returns a new CloudFoundryError
@@ -3474,11 +2874,7 @@ func NewFileError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "File error: %s"
 func IsFileError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 190001
+	return errors.Is(err, CloudFoundryError{Code: 190001})
 }
 
 // NewStatsError returns a new CloudFoundryError
@@ -3497,11 +2893,7 @@ func NewStatsError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "Stats error: %s"
 func IsStatsError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 200001
+	return errors.Is(err, CloudFoundryError{Code: 200001})
 }
 
 // NewStatsUnavailableError returns a new CloudFoundryError
@@ -3520,11 +2912,7 @@ func NewStatsUnavailableError() CloudFoundryError {
 // - HTTP code: 503
 // - message: "Stats unavailable: %s"
 func IsStatsUnavailableError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 200002
+	return errors.Is(err, CloudFoundryError{Code: 200002})
 }
 
 // NewAppStoppedStatsError returns a new CloudFoundryError
@@ -3543,11 +2931,7 @@ func NewAppStoppedStatsError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "Could not fetch stats for stopped app: %s"
 func IsAppStoppedStatsError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 200003
+	return errors.Is(err, CloudFoundryError{Code: 200003})
 }
 
 // NewRouteInvalidError returns a new CloudFoundryError
@@ -3566,11 +2950,7 @@ func NewRouteInvalidError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The route is invalid: %s"
 func IsRouteInvalidError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 210001
+	return errors.Is(err, CloudFoundryError{Code: 210001})
 }
 
 // NewRouteNotFoundError returns a new CloudFoundryError
@@ -3589,11 +2969,7 @@ func NewRouteNotFoundError() CloudFoundryError {
 // - HTTP code: 404
 // - message: "The route could not be found: %s"
 func IsRouteNotFoundError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 210002
+	return errors.Is(err, CloudFoundryError{Code: 210002})
 }
 
 // NewRouteHostTakenError returns a new CloudFoundryError
@@ -3612,11 +2988,7 @@ func NewRouteHostTakenError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The host is taken: %s"
 func IsRouteHostTakenError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 210003
+	return errors.Is(err, CloudFoundryError{Code: 210003})
 }
 
 // NewRoutePathTakenError returns a new CloudFoundryError
@@ -3635,11 +3007,7 @@ func NewRoutePathTakenError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The path is taken: %s"
 func IsRoutePathTakenError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 210004
+	return errors.Is(err, CloudFoundryError{Code: 210004})
 }
 
 // NewRoutePortTakenError returns a new CloudFoundryError
@@ -3658,11 +3026,7 @@ func NewRoutePortTakenError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The port is taken: %s"
 func IsRoutePortTakenError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 210005
+	return errors.Is(err, CloudFoundryError{Code: 210005})
 }
 
 // NewRouteMappingTakenError returns a new CloudFoundryError
@@ -3681,11 +3045,7 @@ func NewRouteMappingTakenError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The route mapping is taken: %s"
 func IsRouteMappingTakenError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 210006
+	return errors.Is(err, CloudFoundryError{Code: 210006})
 }
 
 // NewRouteMappingNotFoundError returns a new CloudFoundryError
@@ -3704,11 +3064,7 @@ func NewRouteMappingNotFoundError() CloudFoundryError {
 // - HTTP code: 404
 // - message: "The route mapping could not be found: %s"
 func IsRouteMappingNotFoundError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 210007
+	return errors.Is(err, CloudFoundryError{Code: 210007})
 }
 
 // NewRouterGroupNotFoundError returns a new CloudFoundryError
@@ -3727,11 +3083,7 @@ func NewRouterGroupNotFoundError() CloudFoundryError {
 // - HTTP code: 404
 // - message: "The router group could not be found: %s"
 func IsRouterGroupNotFoundError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 210009
+	return errors.Is(err, CloudFoundryError{Code: 210009})
 }
 
 // NewInstancesError returns a new CloudFoundryError
@@ -3750,11 +3102,7 @@ func NewInstancesError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "Instances error: %s"
 func IsInstancesError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 220001
+	return errors.Is(err, CloudFoundryError{Code: 220001})
 }
 
 // NewInstancesUnavailableError returns a new CloudFoundryError
@@ -3773,11 +3121,7 @@ func NewInstancesUnavailableError() CloudFoundryError {
 // - HTTP code: 503
 // - message: "Instances information unavailable: %s"
 func IsInstancesUnavailableError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 220002
+	return errors.Is(err, CloudFoundryError{Code: 220002})
 }
 
 // NewEventNotFoundError returns a new CloudFoundryError
@@ -3796,11 +3140,7 @@ func NewEventNotFoundError() CloudFoundryError {
 // - HTTP code: 404
 // - message: "Event could not be found: %s"
 func IsEventNotFoundError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 230002
+	return errors.Is(err, CloudFoundryError{Code: 230002})
 }
 
 // NewQuotaDefinitionNotFoundError returns a new CloudFoundryError
@@ -3819,11 +3159,7 @@ func NewQuotaDefinitionNotFoundError() CloudFoundryError {
 // - HTTP code: 404
 // - message: "Quota Definition could not be found: %s"
 func IsQuotaDefinitionNotFoundError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 240001
+	return errors.Is(err, CloudFoundryError{Code: 240001})
 }
 
 // NewQuotaDefinitionNameTakenError returns a new CloudFoundryError
@@ -3842,11 +3178,7 @@ func NewQuotaDefinitionNameTakenError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "Quota Definition is taken: %s"
 func IsQuotaDefinitionNameTakenError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 240002
+	return errors.Is(err, CloudFoundryError{Code: 240002})
 }
 
 // NewQuotaDefinitionInvalidError returns a new CloudFoundryError
@@ -3865,11 +3197,7 @@ func NewQuotaDefinitionInvalidError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "Quota Definition is invalid: %s"
 func IsQuotaDefinitionInvalidError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 240003
+	return errors.Is(err, CloudFoundryError{Code: 240003})
 }
 
 // NewQuotaDefinitionMemoryLimitInvalidError returns a new CloudFoundryError
@@ -3888,11 +3216,7 @@ func NewQuotaDefinitionMemoryLimitInvalidError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "Quota Definition memory limit cannot be less than -1"
 func IsQuotaDefinitionMemoryLimitInvalidError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 240004
+	return errors.Is(err, CloudFoundryError{Code: 240004})
 }
 
 // NewStackInvalidError returns a new CloudFoundryError
@@ -3911,11 +3235,7 @@ func NewStackInvalidError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The stack is invalid: %s"
 func IsStackInvalidError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 250001
+	return errors.Is(err, CloudFoundryError{Code: 250001})
 }
 
 // NewStackNameTakenError returns a new CloudFoundryError
@@ -3934,11 +3254,7 @@ func NewStackNameTakenError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The stack name is taken: %s"
 func IsStackNameTakenError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 250002
+	return errors.Is(err, CloudFoundryError{Code: 250002})
 }
 
 // NewStackNotFoundError returns a new CloudFoundryError
@@ -3957,11 +3273,7 @@ func NewStackNotFoundError() CloudFoundryError {
 // - HTTP code: 404
 // - message: "The stack could not be found: %s"
 func IsStackNotFoundError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 250003
+	return errors.Is(err, CloudFoundryError{Code: 250003})
 }
 
 // NewServicePlanVisibilityInvalidError returns a new CloudFoundryError
@@ -3980,11 +3292,7 @@ func NewServicePlanVisibilityInvalidError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "Service Plan Visibility is invalid: %s"
 func IsServicePlanVisibilityInvalidError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 260001
+	return errors.Is(err, CloudFoundryError{Code: 260001})
 }
 
 // NewServicePlanVisibilityAlreadyExistsError returns a new CloudFoundryError
@@ -4003,11 +3311,7 @@ func NewServicePlanVisibilityAlreadyExistsError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "This combination of ServicePlan and Organization is already taken: %s"
 func IsServicePlanVisibilityAlreadyExistsError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 260002
+	return errors.Is(err, CloudFoundryError{Code: 260002})
 }
 
 // NewServicePlanVisibilityNotFoundError returns a new CloudFoundryError
@@ -4026,11 +3330,7 @@ func NewServicePlanVisibilityNotFoundError() CloudFoundryError {
 // - HTTP code: 404
 // - message: "The service plan visibility could not be found: %s"
 func IsServicePlanVisibilityNotFoundError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 260003
+	return errors.Is(err, CloudFoundryError{Code: 260003})
 }
 
 // NewServiceBrokerInvalidError returns a new CloudFoundryError
@@ -4049,11 +3349,7 @@ func NewServiceBrokerInvalidError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "Service broker is invalid: %s"
 func IsServiceBrokerInvalidError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 270001
+	return errors.Is(err, CloudFoundryError{Code: 270001})
 }
 
 // NewServiceBrokerNameTakenError returns a new CloudFoundryError
@@ -4072,11 +3368,7 @@ func NewServiceBrokerNameTakenError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The service broker name is taken"
 func IsServiceBrokerNameTakenError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 270002
+	return errors.Is(err, CloudFoundryError{Code: 270002})
 }
 
 // NewServiceBrokerURLTakenError returns a new CloudFoundryError
@@ -4095,11 +3387,7 @@ func NewServiceBrokerURLTakenError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The service broker url is taken: %s"
 func IsServiceBrokerURLTakenError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 270003
+	return errors.Is(err, CloudFoundryError{Code: 270003})
 }
 
 // NewServiceBrokerNotFoundError returns a new CloudFoundryError
@@ -4118,11 +3406,7 @@ func NewServiceBrokerNotFoundError() CloudFoundryError {
 // - HTTP code: 404
 // - message: "The service broker was not found: %s"
 func IsServiceBrokerNotFoundError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 270004
+	return errors.Is(err, CloudFoundryError{Code: 270004})
 }
 
 // NewServiceBrokerNotRemovableError returns a new CloudFoundryError
@@ -4141,11 +3425,7 @@ func NewServiceBrokerNotRemovableError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "Can not remove brokers that have associated service instances: %s"
 func IsServiceBrokerNotRemovableError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 270010
+	return errors.Is(err, CloudFoundryError{Code: 270010})
 }
 
 // NewServiceBrokerURLInvalidError returns a new CloudFoundryError
@@ -4164,11 +3444,7 @@ func NewServiceBrokerURLInvalidError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "%s is not a valid URL"
 func IsServiceBrokerURLInvalidError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 270011
+	return errors.Is(err, CloudFoundryError{Code: 270011})
 }
 
 // NewServiceBrokerCatalogInvalidError returns a new CloudFoundryError
@@ -4187,11 +3463,7 @@ func NewServiceBrokerCatalogInvalidError() CloudFoundryError {
 // - HTTP code: 502
 // - message: "Service broker catalog is invalid: %s"
 func IsServiceBrokerCatalogInvalidError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 270012
+	return errors.Is(err, CloudFoundryError{Code: 270012})
 }
 
 // NewServiceBrokerDashboardClientFailureError returns a new CloudFoundryError
@@ -4210,11 +3482,7 @@ func NewServiceBrokerDashboardClientFailureError() CloudFoundryError {
 // - HTTP code: 502
 // - message: "Service broker dashboard clients could not be modified: %s"
 func IsServiceBrokerDashboardClientFailureError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 270013
+	return errors.Is(err, CloudFoundryError{Code: 270013})
 }
 
 // NewServiceBrokerAsyncRequiredError returns a new CloudFoundryError
@@ -4233,11 +3501,7 @@ func NewServiceBrokerAsyncRequiredError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "This service plan requires client support for asynchronous service operations."
 func IsServiceBrokerAsyncRequiredError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 270014
+	return errors.Is(err, CloudFoundryError{Code: 270014})
 }
 
 // NewServiceDashboardClientMissingURLError returns a new CloudFoundryError
@@ -4256,11 +3520,7 @@ func NewServiceDashboardClientMissingURLError() CloudFoundryError {
 // - HTTP code: 502
 // - message: "Service broker returned dashboard client configuration without a dashboard URL"
 func IsServiceDashboardClientMissingURLError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 270015
+	return errors.Is(err, CloudFoundryError{Code: 270015})
 }
 
 // NewServiceBrokerURLBasicAuthNotSupportedError returns a new CloudFoundryError
@@ -4279,11 +3539,7 @@ func NewServiceBrokerURLBasicAuthNotSupportedError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "User name and password fields in the broker URI are not supported"
 func IsServiceBrokerURLBasicAuthNotSupportedError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 270016
+	return errors.Is(err, CloudFoundryError{Code: 270016})
 }
 
 // NewServiceBrokerRespondedAsyncWhenNotAllowedError returns a new CloudFoundryError
@@ -4302,11 +3558,7 @@ func NewServiceBrokerRespondedAsyncWhenNotAllowedError() CloudFoundryError {
 // - HTTP code: 502
 // - message: "The service broker responded asynchronously to a request, but the accepts_incomplete query parameter was false or not given."
 func IsServiceBrokerRespondedAsyncWhenNotAllowedError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 270017
+	return errors.Is(err, CloudFoundryError{Code: 270017})
 }
 
 // NewServiceBrokerConcurrencyError returns a new CloudFoundryError
@@ -4325,11 +3577,7 @@ func NewServiceBrokerConcurrencyError() CloudFoundryError {
 // - HTTP code: 422
 // - message: "The service broker could not perform this operation in parallel with other running operations"
 func IsServiceBrokerConcurrencyError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 270018
+	return errors.Is(err, CloudFoundryError{Code: 270018})
 }
 
 // NewServiceBrokerCatalogIncompatibleError returns a new CloudFoundryError
@@ -4348,11 +3596,7 @@ func NewServiceBrokerCatalogIncompatibleError() CloudFoundryError {
 // - HTTP code: 502
 // - message: "Service broker catalog is incompatible: %s"
 func IsServiceBrokerCatalogIncompatibleError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 270019
+	return errors.Is(err, CloudFoundryError{Code: 270019})
 }
 
 // NewServiceBrokerRequestRejectedError returns a new CloudFoundryError
@@ -4371,11 +3615,7 @@ func NewServiceBrokerRequestRejectedError() CloudFoundryError {
 // - HTTP code: 502
 // - message: "The service broker rejected the request. Status Code: %s. Please check that the URL points to a valid service broker."
 func IsServiceBrokerRequestRejectedError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 270020
+	return errors.Is(err, CloudFoundryError{Code: 270020})
 }
 
 // NewServiceBrokerRequestMalformedError returns a new CloudFoundryError
@@ -4394,11 +3634,7 @@ func NewServiceBrokerRequestMalformedError() CloudFoundryError {
 // - HTTP code: 502
 // - message: "The service broker returned an invalid response: expected valid JSON object in body. Please check that the URL points to a valid service broker."
 func IsServiceBrokerRequestMalformedError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 270021
+	return errors.Is(err, CloudFoundryError{Code: 270021})
 }
 
 // NewServiceBrokerSyncFailedError returns a new CloudFoundryError
@@ -4417,11 +3653,7 @@ func NewServiceBrokerSyncFailedError() CloudFoundryError {
 // - HTTP code: 502
 // - message: "Encountered an error while attempting to sync cloud controller with the service broker's catalog: %s"
 func IsServiceBrokerSyncFailedError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 270022
+	return errors.Is(err, CloudFoundryError{Code: 270022})
 }
 
 // NewBuildpackNameStackLifecycleTakenError returns a new CloudFoundryError
@@ -4440,11 +3672,7 @@ func NewBuildpackNameStackLifecycleTakenError() CloudFoundryError {
 // - HTTP code: 422
 // - message: "The buildpack name %s is already in use for the stack %s and the lifecycle %s"
 func IsBuildpackNameStackLifecycleTakenError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 290000
+	return errors.Is(err, CloudFoundryError{Code: 290000})
 }
 
 // NewBuildpackNameTakenError returns a new CloudFoundryError
@@ -4463,11 +3691,7 @@ func NewBuildpackNameTakenError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The buildpack name is already in use: %s"
 func IsBuildpackNameTakenError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 290001
+	return errors.Is(err, CloudFoundryError{Code: 290001})
 }
 
 // NewBuildpackBitsUploadInvalidError returns a new CloudFoundryError
@@ -4486,11 +3710,7 @@ func NewBuildpackBitsUploadInvalidError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The buildpack upload is invalid: %s"
 func IsBuildpackBitsUploadInvalidError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 290002
+	return errors.Is(err, CloudFoundryError{Code: 290002})
 }
 
 // NewBuildpackInvalidError returns a new CloudFoundryError
@@ -4509,11 +3729,7 @@ func NewBuildpackInvalidError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "Buildpack is invalid: %s"
 func IsBuildpackInvalidError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 290003
+	return errors.Is(err, CloudFoundryError{Code: 290003})
 }
 
 // NewCustomBuildpacksDisabledError returns a new CloudFoundryError
@@ -4532,11 +3748,7 @@ func NewCustomBuildpacksDisabledError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "Custom buildpacks are disabled"
 func IsCustomBuildpacksDisabledError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 290004
+	return errors.Is(err, CloudFoundryError{Code: 290004})
 }
 
 // NewBuildpackLockedError returns a new CloudFoundryError
@@ -4555,11 +3767,7 @@ func NewBuildpackLockedError() CloudFoundryError {
 // - HTTP code: 409
 // - message: "The buildpack is locked"
 func IsBuildpackLockedError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 290005
+	return errors.Is(err, CloudFoundryError{Code: 290005})
 }
 
 // NewJobTimeoutError returns a new CloudFoundryError
@@ -4578,11 +3786,7 @@ func NewJobTimeoutError() CloudFoundryError {
 // - HTTP code: 524
 // - message: "The job execution has timed out."
 func IsJobTimeoutError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 290006
+	return errors.Is(err, CloudFoundryError{Code: 290006})
 }
 
 // NewSpaceDeleteTimeoutError returns a new CloudFoundryError
@@ -4601,11 +3805,7 @@ func NewSpaceDeleteTimeoutError() CloudFoundryError {
 // - HTTP code: 524
 // - message: "Deletion of space %s timed out before all resources within could be deleted"
 func IsSpaceDeleteTimeoutError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 290007
+	return errors.Is(err, CloudFoundryError{Code: 290007})
 }
 
 // NewSpaceDeletionFailedError returns a new CloudFoundryError
@@ -4624,11 +3824,7 @@ func NewSpaceDeletionFailedError() CloudFoundryError {
 // - HTTP code: 502
 // - message: "Deletion of space %s failed because one or more resources within could not be deleted.\n\n%s"
 func IsSpaceDeletionFailedError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 290008
+	return errors.Is(err, CloudFoundryError{Code: 290008})
 }
 
 // NewOrganizationDeleteTimeoutError returns a new CloudFoundryError
@@ -4647,11 +3843,7 @@ func NewOrganizationDeleteTimeoutError() CloudFoundryError {
 // - HTTP code: 524
 // - message: "Delete of organization %s timed out before all resources within could be deleted"
 func IsOrganizationDeleteTimeoutError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 290009
+	return errors.Is(err, CloudFoundryError{Code: 290009})
 }
 
 // NewOrganizationDeletionFailedError returns a new CloudFoundryError
@@ -4670,11 +3862,7 @@ func NewOrganizationDeletionFailedError() CloudFoundryError {
 // - HTTP code: 502
 // - message: "Deletion of organization %s failed because one or more resources within could not be deleted.\n\n%s"
 func IsOrganizationDeletionFailedError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 290010
+	return errors.Is(err, CloudFoundryError{Code: 290010})
 }
 
 // NewNonrecursiveSpaceDeletionFailedError returns a new CloudFoundryError
@@ -4693,11 +3881,7 @@ func NewNonrecursiveSpaceDeletionFailedError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "Resource inside space %s must first be deleted, or specify recursive delete."
 func IsNonrecursiveSpaceDeletionFailedError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 290011
+	return errors.Is(err, CloudFoundryError{Code: 290011})
 }
 
 // NewSpaceRolesDeletionTimeoutError returns a new CloudFoundryError
@@ -4716,11 +3900,7 @@ func NewSpaceRolesDeletionTimeoutError() CloudFoundryError {
 // - HTTP code: 524
 // - message: "Deletion of roles for space %s timed out before all roles could be deleted"
 func IsSpaceRolesDeletionTimeoutError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 290013
+	return errors.Is(err, CloudFoundryError{Code: 290013})
 }
 
 // NewOrganizationRolesDeletionFailedError returns a new CloudFoundryError
@@ -4739,11 +3919,7 @@ func NewOrganizationRolesDeletionFailedError() CloudFoundryError {
 // - HTTP code: 502
 // - message: "Failed to delete one or more roles for organization %s"
 func IsOrganizationRolesDeletionFailedError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 290014
+	return errors.Is(err, CloudFoundryError{Code: 290014})
 }
 
 // NewSpaceRolesDeletionFailedError returns a new CloudFoundryError
@@ -4762,11 +3938,7 @@ func NewSpaceRolesDeletionFailedError() CloudFoundryError {
 // - HTTP code: 502
 // - message: "Failed to delete one or more roles for space %s"
 func IsSpaceRolesDeletionFailedError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 290016
+	return errors.Is(err, CloudFoundryError{Code: 290016})
 }
 
 // NewSecurityGroupInvalidError returns a new CloudFoundryError
@@ -4785,11 +3957,7 @@ func NewSecurityGroupInvalidError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The security group is invalid: %s"
 func IsSecurityGroupInvalidError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 300001
+	return errors.Is(err, CloudFoundryError{Code: 300001})
 }
 
 // NewSecurityGroupNotFoundError returns a new CloudFoundryError
@@ -4808,11 +3976,7 @@ func NewSecurityGroupNotFoundError() CloudFoundryError {
 // - HTTP code: 404
 // - message: "The security group could not be found: %s"
 func IsSecurityGroupNotFoundError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 300002
+	return errors.Is(err, CloudFoundryError{Code: 300002})
 }
 
 // NewSecurityGroupStagingDefaultInvalidError returns a new CloudFoundryError
@@ -4831,11 +3995,7 @@ func NewSecurityGroupStagingDefaultInvalidError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The security group could not be found: %s"
 func IsSecurityGroupStagingDefaultInvalidError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 300003
+	return errors.Is(err, CloudFoundryError{Code: 300003})
 }
 
 // NewSecurityGroupRunningDefaultInvalidError returns a new CloudFoundryError
@@ -4854,11 +4014,7 @@ func NewSecurityGroupRunningDefaultInvalidError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The security group could not be found: %s"
 func IsSecurityGroupRunningDefaultInvalidError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 300004
+	return errors.Is(err, CloudFoundryError{Code: 300004})
 }
 
 // NewSecurityGroupNameTakenError returns a new CloudFoundryError
@@ -4877,11 +4033,7 @@ func NewSecurityGroupNameTakenError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The security group name is taken: %s"
 func IsSecurityGroupNameTakenError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 300005
+	return errors.Is(err, CloudFoundryError{Code: 300005})
 }
 
 // NewSpaceQuotaDefinitionInvalidError returns a new CloudFoundryError
@@ -4900,11 +4052,7 @@ func NewSpaceQuotaDefinitionInvalidError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "Space Quota Definition is invalid: %s"
 func IsSpaceQuotaDefinitionInvalidError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 310001
+	return errors.Is(err, CloudFoundryError{Code: 310001})
 }
 
 // NewSpaceQuotaDefinitionNameTakenError returns a new CloudFoundryError
@@ -4923,11 +4071,7 @@ func NewSpaceQuotaDefinitionNameTakenError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The space quota definition name is taken: %s"
 func IsSpaceQuotaDefinitionNameTakenError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 310002
+	return errors.Is(err, CloudFoundryError{Code: 310002})
 }
 
 // NewSpaceQuotaMemoryLimitExceededError returns a new CloudFoundryError
@@ -4946,11 +4090,7 @@ func NewSpaceQuotaMemoryLimitExceededError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "You have exceeded your space's memory limit: %s"
 func IsSpaceQuotaMemoryLimitExceededError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 310003
+	return errors.Is(err, CloudFoundryError{Code: 310003})
 }
 
 // NewSpaceQuotaInstanceMemoryLimitExceededError returns a new CloudFoundryError
@@ -4969,11 +4109,7 @@ func NewSpaceQuotaInstanceMemoryLimitExceededError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "You have exceeded the instance memory limit for your space's quota."
 func IsSpaceQuotaInstanceMemoryLimitExceededError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 310004
+	return errors.Is(err, CloudFoundryError{Code: 310004})
 }
 
 // NewSpaceQuotaTotalRoutesExceededError returns a new CloudFoundryError
@@ -4992,11 +4128,7 @@ func NewSpaceQuotaTotalRoutesExceededError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "You have exceeded the total routes for your space's quota."
 func IsSpaceQuotaTotalRoutesExceededError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 310005
+	return errors.Is(err, CloudFoundryError{Code: 310005})
 }
 
 // NewOrgQuotaTotalRoutesExceededError returns a new CloudFoundryError
@@ -5015,11 +4147,7 @@ func NewOrgQuotaTotalRoutesExceededError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "You have exceeded the total routes for your organization's quota."
 func IsOrgQuotaTotalRoutesExceededError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 310006
+	return errors.Is(err, CloudFoundryError{Code: 310006})
 }
 
 // NewSpaceQuotaDefinitionNotFoundError returns a new CloudFoundryError
@@ -5038,11 +4166,7 @@ func NewSpaceQuotaDefinitionNotFoundError() CloudFoundryError {
 // - HTTP code: 404
 // - message: "Space Quota Definition could not be found: %s"
 func IsSpaceQuotaDefinitionNotFoundError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 310007
+	return errors.Is(err, CloudFoundryError{Code: 310007})
 }
 
 // NewSpaceQuotaInstanceLimitExceededError returns a new CloudFoundryError
@@ -5061,11 +4185,7 @@ func NewSpaceQuotaInstanceLimitExceededError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "You have exceeded the instance limit for your space's quota."
 func IsSpaceQuotaInstanceLimitExceededError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 310008
+	return errors.Is(err, CloudFoundryError{Code: 310008})
 }
 
 // NewOrgQuotaTotalReservedRoutePortsExceededError returns a new CloudFoundryError
@@ -5084,11 +4204,7 @@ func NewOrgQuotaTotalReservedRoutePortsExceededError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "You have exceeded the total reserved route ports for your organization's quota."
 func IsOrgQuotaTotalReservedRoutePortsExceededError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 310009
+	return errors.Is(err, CloudFoundryError{Code: 310009})
 }
 
 // NewSpaceQuotaTotalReservedRoutePortsExceededError returns a new CloudFoundryError
@@ -5107,11 +4223,7 @@ func NewSpaceQuotaTotalReservedRoutePortsExceededError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "You have exceeded the total reserved route ports for your space's quota."
 func IsSpaceQuotaTotalReservedRoutePortsExceededError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 310010
+	return errors.Is(err, CloudFoundryError{Code: 310010})
 }
 
 // NewSpaceQuotaLogRateLimitExceededError returns a new CloudFoundryError
@@ -5130,11 +4242,7 @@ func NewSpaceQuotaLogRateLimitExceededError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "You have exceeded your space's log rate limit: %s"
 func IsSpaceQuotaLogRateLimitExceededError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 310011
+	return errors.Is(err, CloudFoundryError{Code: 310011})
 }
 
 // NewDiegoDisabledError returns a new CloudFoundryError
@@ -5153,11 +4261,7 @@ func NewDiegoDisabledError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "Diego has not been enabled."
 func IsDiegoDisabledError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 320001
+	return errors.Is(err, CloudFoundryError{Code: 320001})
 }
 
 // NewDiegoDockerBuildpackConflictError returns a new CloudFoundryError
@@ -5176,11 +4280,7 @@ func NewDiegoDockerBuildpackConflictError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "You cannot specify a custom buildpack and a docker image at the same time."
 func IsDiegoDockerBuildpackConflictError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 320002
+	return errors.Is(err, CloudFoundryError{Code: 320002})
 }
 
 // NewDockerDisabledError returns a new CloudFoundryError
@@ -5199,11 +4299,7 @@ func NewDockerDisabledError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "Docker support has not been enabled."
 func IsDockerDisabledError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 320003
+	return errors.Is(err, CloudFoundryError{Code: 320003})
 }
 
 // NewStagingBackendInvalidError returns a new CloudFoundryError
@@ -5222,11 +4318,7 @@ func NewStagingBackendInvalidError() CloudFoundryError {
 // - HTTP code: 403
 // - message: "The request staging completion endpoint only handles apps desired to stage on the Diego backend."
 func IsStagingBackendInvalidError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 320004
+	return errors.Is(err, CloudFoundryError{Code: 320004})
 }
 
 // NewBackendSelectionNotAuthorizedError returns a new CloudFoundryError
@@ -5245,11 +4337,7 @@ func NewBackendSelectionNotAuthorizedError() CloudFoundryError {
 // - HTTP code: 403
 // - message: "You cannot select the backend on which to run this application"
 func IsBackendSelectionNotAuthorizedError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 320005
+	return errors.Is(err, CloudFoundryError{Code: 320005})
 }
 
 // NewRevisionsEnabledError returns a new CloudFoundryError
@@ -5268,11 +4356,7 @@ func NewRevisionsEnabledError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "V2 restaging is disabled when your app has revisions enabled"
 func IsRevisionsEnabledError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 320006
+	return errors.Is(err, CloudFoundryError{Code: 320006})
 }
 
 // NewCNBDisabledError returns a new CloudFoundryError
@@ -5291,11 +4375,7 @@ func NewCNBDisabledError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "Cloud Native Buildpacks support has not been enabled."
 func IsCNBDisabledError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 320007
+	return errors.Is(err, CloudFoundryError{Code: 320007})
 }
 
 // NewFeatureFlagNotFoundError returns a new CloudFoundryError
@@ -5314,11 +4394,7 @@ func NewFeatureFlagNotFoundError() CloudFoundryError {
 // - HTTP code: 404
 // - message: "The feature flag could not be found: %s"
 func IsFeatureFlagNotFoundError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 330000
+	return errors.Is(err, CloudFoundryError{Code: 330000})
 }
 
 // NewFeatureFlagInvalidError returns a new CloudFoundryError
@@ -5337,11 +4413,7 @@ func NewFeatureFlagInvalidError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The feature flag is invalid: %s"
 func IsFeatureFlagInvalidError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 330001
+	return errors.Is(err, CloudFoundryError{Code: 330001})
 }
 
 // NewFeatureDisabledError returns a new CloudFoundryError
@@ -5360,11 +4432,7 @@ func NewFeatureDisabledError() CloudFoundryError {
 // - HTTP code: 403
 // - message: "Feature Disabled: %s"
 func IsFeatureDisabledError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 330002
+	return errors.Is(err, CloudFoundryError{Code: 330002})
 }
 
 // NewUserProvidedServiceInstanceNotFoundError returns a new CloudFoundryError
@@ -5383,11 +4451,7 @@ func NewUserProvidedServiceInstanceNotFoundError() CloudFoundryError {
 // - HTTP code: 404
 // - message: "The service instance could not be found: %s"
 func IsUserProvidedServiceInstanceNotFoundError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 340001
+	return errors.Is(err, CloudFoundryError{Code: 340001})
 }
 
 // NewUserProvidedServiceInstanceHandlerNeededError returns a new CloudFoundryError
@@ -5406,11 +4470,7 @@ func NewUserProvidedServiceInstanceHandlerNeededError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "Please use the User Provided Services API to manage this resource."
 func IsUserProvidedServiceInstanceHandlerNeededError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 340002
+	return errors.Is(err, CloudFoundryError{Code: 340002})
 }
 
 // NewProcessInvalidError returns a new CloudFoundryError
@@ -5429,11 +4489,7 @@ func NewProcessInvalidError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The process is invalid: %s"
 func IsProcessInvalidError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 350001
+	return errors.Is(err, CloudFoundryError{Code: 350001})
 }
 
 // NewUnableToDeleteError returns a new CloudFoundryError
@@ -5452,11 +4508,7 @@ func NewUnableToDeleteError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "Unable to perform delete action: %s"
 func IsUnableToDeleteError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 350002
+	return errors.Is(err, CloudFoundryError{Code: 350002})
 }
 
 // NewProcessNotFoundError returns a new CloudFoundryError
@@ -5475,11 +4527,7 @@ func NewProcessNotFoundError() CloudFoundryError {
 // - HTTP code: 404
 // - message: "The process could not be found: %s"
 func IsProcessNotFoundError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 350003
+	return errors.Is(err, CloudFoundryError{Code: 350003})
 }
 
 // NewServiceKeyNameTakenError returns a new CloudFoundryError
@@ -5498,11 +4546,7 @@ func NewServiceKeyNameTakenError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The service key name is taken: %s"
 func IsServiceKeyNameTakenError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 360001
+	return errors.Is(err, CloudFoundryError{Code: 360001})
 }
 
 // NewServiceKeyInvalidError returns a new CloudFoundryError
@@ -5521,11 +4565,7 @@ func NewServiceKeyInvalidError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The service key is invalid: %s"
 func IsServiceKeyInvalidError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 360002
+	return errors.Is(err, CloudFoundryError{Code: 360002})
 }
 
 // NewServiceKeyNotFoundError returns a new CloudFoundryError
@@ -5544,11 +4584,7 @@ func NewServiceKeyNotFoundError() CloudFoundryError {
 // - HTTP code: 404
 // - message: "The service key could not be found: %s"
 func IsServiceKeyNotFoundError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 360003
+	return errors.Is(err, CloudFoundryError{Code: 360003})
 }
 
 // NewServiceKeyNotSupportedError returns a new CloudFoundryError
@@ -5567,11 +4603,7 @@ func NewServiceKeyNotSupportedError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "%s"
 func IsServiceKeyNotSupportedError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 360004
+	return errors.Is(err, CloudFoundryError{Code: 360004})
 }
 
 // NewServiceKeyCredentialStoreUnavailableError returns a new CloudFoundryError
@@ -5590,11 +4622,7 @@ func NewServiceKeyCredentialStoreUnavailableError() CloudFoundryError {
 // - HTTP code: 503
 // - message: "Credential store is unavailable"
 func IsServiceKeyCredentialStoreUnavailableError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 360005
+	return errors.Is(err, CloudFoundryError{Code: 360005})
 }
 
 // NewRoutingApiUnavailableError returns a new CloudFoundryError
@@ -5613,11 +4641,7 @@ func NewRoutingApiUnavailableError() CloudFoundryError {
 // - HTTP code: 503
 // - message: "The Routing API is currently unavailable"
 func IsRoutingApiUnavailableError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 370001
+	return errors.Is(err, CloudFoundryError{Code: 370001})
 }
 
 // NewRoutingApiDisabledError returns a new CloudFoundryError
@@ -5636,11 +4660,7 @@ func NewRoutingApiDisabledError() CloudFoundryError {
 // - HTTP code: 403
 // - message: "Routing API is disabled"
 func IsRoutingApiDisabledError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 370003
+	return errors.Is(err, CloudFoundryError{Code: 370003})
 }
 
 // NewEnvironmentVariableGroupInvalidError returns a new CloudFoundryError
@@ -5659,11 +4679,7 @@ func NewEnvironmentVariableGroupInvalidError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The Environment Variable Group is invalid: %s"
 func IsEnvironmentVariableGroupInvalidError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 380001
+	return errors.Is(err, CloudFoundryError{Code: 380001})
 }
 
 // NewDropletUploadInvalidError returns a new CloudFoundryError
@@ -5682,11 +4698,7 @@ func NewDropletUploadInvalidError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "The droplet upload is invalid: %s"
 func IsDropletUploadInvalidError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 380002
+	return errors.Is(err, CloudFoundryError{Code: 380002})
 }
 
 // NewServiceInstanceUnshareFailedError returns a new CloudFoundryError
@@ -5705,11 +4717,7 @@ func NewServiceInstanceUnshareFailedError() CloudFoundryError {
 // - HTTP code: 502
 // - message: "Unshare of service instance failed: \n\n%s"
 func IsServiceInstanceUnshareFailedError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 390001
+	return errors.Is(err, CloudFoundryError{Code: 390001})
 }
 
 // NewServiceInstanceDeletionSharesExistsError returns a new CloudFoundryError
@@ -5728,11 +4736,7 @@ func NewServiceInstanceDeletionSharesExistsError() CloudFoundryError {
 // - HTTP code: 422
 // - message: "Service instances must be unshared before they can be deleted. Unsharing %s will automatically delete any bindings that have been made to applications in other spaces."
 func IsServiceInstanceDeletionSharesExistsError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 390002
+	return errors.Is(err, CloudFoundryError{Code: 390002})
 }
 
 // NewSharedServiceInstanceCannotBeRenamedError returns a new CloudFoundryError
@@ -5751,11 +4755,7 @@ func NewSharedServiceInstanceCannotBeRenamedError() CloudFoundryError {
 // - HTTP code: 422
 // - message: "Service instances that have been shared cannot be renamed"
 func IsSharedServiceInstanceCannotBeRenamedError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 390003
+	return errors.Is(err, CloudFoundryError{Code: 390003})
 }
 
 // NewSharedServiceInstanceNotUpdatableInTargetSpaceError returns a new CloudFoundryError
@@ -5774,11 +4774,7 @@ func NewSharedServiceInstanceNotUpdatableInTargetSpaceError() CloudFoundryError
 // - HTTP code: 403
 // - message: "You cannot update service instances that have been shared with you"
 func IsSharedServiceInstanceNotUpdatableInTargetSpaceError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 390004
+	return errors.Is(err, CloudFoundryError{Code: 390004})
 }
 
 // NewSharedServiceInstanceNotDeletableInTargetSpaceError returns a new CloudFoundryError
@@ -5797,11 +4793,7 @@ func NewSharedServiceInstanceNotDeletableInTargetSpaceError() CloudFoundryError
 // - HTTP code: 403
 // - message: "You cannot delete service instances that have been shared with you"
 func IsSharedServiceInstanceNotDeletableInTargetSpaceError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 390005
+	return errors.Is(err, CloudFoundryError{Code: 390005})
 }
 
 // NewMaintenanceInfoNotSupportedError returns a new CloudFoundryError
@@ -5820,11 +4812,7 @@ func NewMaintenanceInfoNotSupportedError() CloudFoundryError {
 // - HTTP code: 422
 // - message: "The service broker does not support upgrades for service instances created from this plan."
 func IsMaintenanceInfoNotSupportedError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 390006
+	return errors.Is(err, CloudFoundryError{Code: 390006})
 }
 
 // NewMaintenanceInfoNotSemverError returns a new CloudFoundryError
@@ -5843,11 +4831,7 @@ func NewMaintenanceInfoNotSemverError() CloudFoundryError {
 // - HTTP code: 422
 // - message: "maintenance_info.version should be a semantic version."
 func IsMaintenanceInfoNotSemverError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 390007
+	return errors.Is(err, CloudFoundryError{Code: 390007})
 }
 
 // NewMaintenanceInfoNotUpdatableWhenChangingPlanError returns a new CloudFoundryError
@@ -5866,11 +4850,7 @@ func NewMaintenanceInfoNotUpdatableWhenChangingPlanError() CloudFoundryError {
 // - HTTP code: 422
 // - message: "maintenance_info should not be changed when switching to different plan."
 func IsMaintenanceInfoNotUpdatableWhenChangingPlanError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 390008
+	return errors.Is(err, CloudFoundryError{Code: 390008})
 }
 
 // NewMaintenanceInfoConflictError returns a new CloudFoundryError
@@ -5889,11 +4869,7 @@ func NewMaintenanceInfoConflictError() CloudFoundryError {
 // - HTTP code: 422
 // - message: "maintenance_info.version requested is invalid. Please ensure the catalog is up to date and you are providing a version supported by this service plan."
 func IsMaintenanceInfoConflictError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 390009
+	return errors.Is(err, CloudFoundryError{Code: 390009})
 }
 
 // NewBuildpackStacksDontMatchError returns a new CloudFoundryError
@@ -5912,11 +4888,7 @@ func NewBuildpackStacksDontMatchError() CloudFoundryError {
 // - HTTP code: 422
 // - message: "Uploaded buildpack stack (%s) does not match %s"
 func IsBuildpackStacksDontMatchError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 390011
+	return errors.Is(err, CloudFoundryError{Code: 390011})
 }
 
 // NewBuildpackStackDoesNotExistError returns a new CloudFoundryError
@@ -5935,11 +4907,7 @@ func NewBuildpackStackDoesNotExistError() CloudFoundryError {
 // - HTTP code: 422
 // - message: "Uploaded buildpack stack (%s) does not exist"
 func IsBuildpackStackDoesNotExistError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 390012
+	return errors.Is(err, CloudFoundryError{Code: 390012})
 }
 
 // NewBuildpackZipError returns a new CloudFoundryError
@@ -5958,11 +4926,7 @@ func NewBuildpackZipError() CloudFoundryError {
 // - HTTP code: 422
 // - message: "Buildpack zip error: %s"
 func IsBuildpackZipError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 390013
+	return errors.Is(err, CloudFoundryError{Code: 390013})
 }
 
 // NewDeploymentsDisabledError returns a new CloudFoundryError
@@ -5981,11 +4945,7 @@ func NewDeploymentsDisabledError() CloudFoundryError {
 // - HTTP code: 403
 // - message: "Deployments cannot be created due to manifest property 'temporary_disable_deployments'"
 func IsDeploymentsDisabledError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 390014
+	return errors.Is(err, CloudFoundryError{Code: 390014})
 }
 
 // NewNoCurrentEncryptionKeyError returns a new CloudFoundryError
@@ -6004,11 +4964,7 @@ func NewNoCurrentEncryptionKeyError() CloudFoundryError {
 // - HTTP code: 422
 // - message: "Please set the desired encryption key in the manifest at ‘cc.database_encryption.current_key_label’"
 func IsNoCurrentEncryptionKeyError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 390015
+	return errors.Is(err, CloudFoundryError{Code: 390015})
 }
 
 // NewScaleDisabledDuringDeploymentError returns a new CloudFoundryError
@@ -6027,11 +4983,7 @@ func NewScaleDisabledDuringDeploymentError() CloudFoundryError {
 // - HTTP code: 422
 // - message: "Cannot scale this process while a deployment is in flight."
 func IsScaleDisabledDuringDeploymentError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 390016
+	return errors.Is(err, CloudFoundryError{Code: 390016})
 }
 
 // NewProcessUpdateDisabledDuringDeploymentError returns a new CloudFoundryError
@@ -6050,11 +5002,7 @@ func NewProcessUpdateDisabledDuringDeploymentError() CloudFoundryError {
 // - HTTP code: 422
 // - message: "Cannot update this process while a deployment is in flight."
 func IsProcessUpdateDisabledDuringDeploymentError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 390017
+	return errors.Is(err, CloudFoundryError{Code: 390017})
 }
 
 // NewLabelLimitExceededError returns a new CloudFoundryError
@@ -6073,11 +5021,7 @@ func NewLabelLimitExceededError() CloudFoundryError {
 // - HTTP code: 422
 // - message: "Failed to add %d labels because it would exceed maximum of %d"
 func IsLabelLimitExceededError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 390020
+	return errors.Is(err, CloudFoundryError{Code: 390020})
 }
 
 // NewAnnotationLimitExceededError returns a new CloudFoundryError
@@ -6096,11 +5040,7 @@ func NewAnnotationLimitExceededError() CloudFoundryError {
 // - HTTP code: 422
 // - message: "Failed to add %d annotations because it would exceed maximum of %d"
 func IsAnnotationLimitExceededError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 390023
+	return errors.Is(err, CloudFoundryError{Code: 390023})
 }
 
 // NewStopDisabledDuringDeploymentError returns a new CloudFoundryError
@@ -6119,11 +5059,7 @@ func NewStopDisabledDuringDeploymentError() CloudFoundryError {
 // - HTTP code: 422
 // - message: "Cannot stop the app while it is deploying, please cancel the deployment before stopping the app."
 func IsStopDisabledDuringDeploymentError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 390024
+	return errors.Is(err, CloudFoundryError{Code: 390024})
 }
 
 // NewKubernetesRouteResourceError returns a new CloudFoundryError
@@ -6142,11 +5078,7 @@ func NewKubernetesRouteResourceError() CloudFoundryError {
 // - HTTP code: 422
 // - message: "Failed to create/update/delete Route resource with guid '%s' on Kubernetes"
 func IsKubernetesRouteResourceError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 400001
+	return errors.Is(err, CloudFoundryError{Code: 400001})
 }
 
 // NewKpackImageError returns a new CloudFoundryError
@@ -6165,11 +5097,7 @@ func NewKpackImageError() CloudFoundryError {
 // - HTTP code: 422
 // - message: "Failed to %s Image resource for staging: '%s'"
 func IsKpackImageError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 400002
+	return errors.Is(err, CloudFoundryError{Code: 400002})
 }
 
 // NewKpackBuilderError returns a new CloudFoundryError
@@ -6188,11 +5116,7 @@ func NewKpackBuilderError() CloudFoundryError {
 // - HTTP code: 422
 // - message: "Failed to %s Builder resource: '%s'"
 func IsKpackBuilderError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 400003
+	return errors.Is(err, CloudFoundryError{Code: 400003})
 }
 
 // NewEiriniLRPError returns a new CloudFoundryError
@@ -6211,11 +5135,7 @@ func NewEiriniLRPError() CloudFoundryError {
 // - HTTP code: 422
 // - message: "Failed to %s LRP resource: '%s'"
 func IsEiriniLRPError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 410001
+	return errors.Is(err, CloudFoundryError{Code: 410001})
 }
 
 // NewKorifiError returns a new CloudFoundryError
@@ -6234,11 +5154,7 @@ func NewKorifiError() CloudFoundryError {
 // - HTTP code: 500
 // - message: "%s"
 func IsKorifiError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 420000
+	return errors.Is(err, CloudFoundryError{Code: 420000})
 }
 
 // NewDeserializationError returns a new CloudFoundryError
@@ -6257,11 +5173,7 @@ func NewDeserializationError() CloudFoundryError {
 // - HTTP code: 500
 // - message: "%s"
 func IsDeserializationError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 420001
+	return errors.Is(err, CloudFoundryError{Code: 420001})
 }
 
 // NewCNBGenericBuildFailedError returns a new CloudFoundryError
@@ -6280,11 +5192,7 @@ func NewCNBGenericBuildFailedError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "cnb: generic build failure"
 func IsCNBGenericBuildFailedError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 430001
+	return errors.Is(err, CloudFoundryError{Code: 430001})
 }
 
 // NewCNBDownloadBuildpackFailedError returns a new CloudFoundryError
@@ -6303,11 +5211,7 @@ func NewCNBDownloadBuildpackFailedError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "cnb: downloading buildpacks failed: This may be caused by a wrong url, invalid buildpack or invalid credentials. Check the staging logs for more details."
 func IsCNBDownloadBuildpackFailedError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 430002
+	return errors.Is(err, CloudFoundryError{Code: 430002})
 }
 
 // NewCNBDetectFailedError returns a new CloudFoundryError
@@ -6326,11 +5230,7 @@ func NewCNBDetectFailedError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "cnb: detecting failed"
 func IsCNBDetectFailedError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 430003
+	return errors.Is(err, CloudFoundryError{Code: 430003})
 }
 
 // NewCNBBuildFailedError returns a new CloudFoundryError
@@ -6349,11 +5249,7 @@ func NewCNBBuildFailedError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "cnb: building failed"
 func IsCNBBuildFailedError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 430004
+	return errors.Is(err, CloudFoundryError{Code: 430004})
 }
 
 // NewCNBExportFailedError returns a new CloudFoundryError
@@ -6372,11 +5268,7 @@ func NewCNBExportFailedError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "cnb: exporting failed"
 func IsCNBExportFailedError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 430005
+	return errors.Is(err, CloudFoundryError{Code: 430005})
 }
 
 // NewCNBLaunchFailedError returns a new CloudFoundryError
@@ -6395,11 +5287,7 @@ func NewCNBLaunchFailedError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "cnb: launching failed"
 func IsCNBLaunchFailedError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 430006
+	return errors.Is(err, CloudFoundryError{Code: 430006})
 }
 
 // NewCNBRestoreFailedError returns a new CloudFoundryError
@@ -6418,14 +5306,5 @@ func NewCNBRestoreFailedError() CloudFoundryError {
 // - HTTP code: 400
 // - message: "cnb: restore failed"
 func IsCNBRestoreFailedError(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == 430007
-}
-
-func cloudFoundryError(err error) (cferr CloudFoundryError, ok bool) {
-	ok = errors.As(err, &cferr)
-	return cferr, ok
+	return errors.Is(err, CloudFoundryError{Code: 430007})
 }
@@ -19,6 +19,13 @@ func TestIsSpaceNotFoundError(t *testing.T) {
 		{"std wrapped CloudFoundry error", fmt.Errorf("%w", CloudFoundryError{
 			Code: 40004,
 		}), true},
+		{"CloudFoundry error set", CloudFoundryErrors{Errors: []CloudFoundryError{
+			{Code: 10008},
+			{Code: 40004},
+		}}, true},
+		{"std wrapped CloudFoundry error set", fmt.Errorf("%w", CloudFoundryErrors{Errors: []CloudFoundryError{
+			{Code: 10008},
+		}}), false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,111 @@
+package resource
+
+import "time"
+
+// EnvelopeType is the type of loggregator envelope stored in log-cache
+type EnvelopeType string
+
+func (e EnvelopeType) String() string {
+	return string(e)
+}
+
+const (
+	EnvelopeTypeLog     EnvelopeType = "LOG"
+	EnvelopeTypeCounter EnvelopeType = "COUNTER"
+	EnvelopeTypeGauge   EnvelopeType = "GAUGE"
+	EnvelopeTypeTimer   EnvelopeType = "TIMER"
+	EnvelopeTypeEvent   EnvelopeType = "EVENT"
+)
+
+// LogType is the output stream a log line was written to
+type LogType string
+
+const (
+	LogTypeOut LogType = "OUT"
+	LogTypeErr LogType = "ERR"
+)
+
+// The source_type tag values of the logs of an app
+const (
+	LogSourceTypeStaging = "STG"
+	LogSourceTypeApp     = "APP/PROC/WEB"
+	LogSourceTypeAPI     = "API"
+	LogSourceTypeCell    = "CELL"
+	LogSourceTypeRouter  = "RTR"
+)
+
+// Envelope is a single log line or metric read from log-cache, only the field matching its type is set
+type Envelope struct {
+	// Timestamp is when the envelope was emitted in nanoseconds since the Unix epoch
+	Timestamp  int64             `json:"timestamp,string"`
+	SourceID   string            `json:"source_id"`
+	InstanceID string            `json:"instance_id"`
+	Tags       map[string]string `json:"tags"`
+
+	Log     *LogEnvelope     `json:"log,omitempty"`
+	Counter *CounterEnvelope `json:"counter,omitempty"`
+	Gauge   *GaugeEnvelope   `json:"gauge,omitempty"`
+	Timer   *TimerEnvelope   `json:"timer,omitempty"`
+	Event   *EventEnvelope   `json:"event,omitempty"`
+}
+
+// LogEnvelope is a log line
+type LogEnvelope struct {
+	Payload []byte  `json:"payload"`
+	Type    LogType `json:"type"`
+}
+
+// CounterEnvelope is a counter metric, i.e. the number of requests an app has received
+type CounterEnvelope struct {
+	Name  string `json:"name"`
+	Delta uint64 `json:"delta,string"`
+	Total uint64 `json:"total,string"`
+}
+
+// GaugeEnvelope is a set of gauge metrics, i.e. the cpu, memory and disk usage of an app instance
+type GaugeEnvelope struct {
+	Metrics map[string]GaugeValue `json:"metrics"`
+}
+
+// GaugeValue is the value of a gauge metric
+type GaugeValue struct {
+	Unit  string  `json:"unit"`
+	Value float64 `json:"value"`
+}
+
+// TimerEnvelope is the duration of an operation, i.e. an HTTP request routed to an app
+type TimerEnvelope struct {
+	Name string `json:"name"`
+
+	// Start and Stop are in nanoseconds since the Unix epoch
+	Start int64 `json:"start,string"`
+	Stop  int64 `json:"stop,string"`
+}
+
+// EventEnvelope is an event, i.e. an app instance crash
+type EventEnvelope struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// EnvelopeBatch is a batch of envelopes returned by log-cache
+type EnvelopeBatch struct {
+	Envelopes struct {
+		Batch []*Envelope `json:"batch"`
+	} `json:"envelopes"`
+}
+
+// Time returns when the envelope was emitted
+func (e *Envelope) Time() time.Time {
+	return time.Unix(0, e.Timestamp)
+}
+
+// SourceType returns the source_type tag of the envelope, i.e. STG for staging logs
+func (e *Envelope) SourceType() string {
+	return e.Tags["source_type"]
+}
+
+// Duration returns how long the timed operation took
+func (t *TimerEnvelope) Duration() time.Duration {
+	return time.Duration(t.Stop - t.Start)
+}
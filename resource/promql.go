@@ -0,0 +1,113 @@
+package resource
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// The types of PromQL query results
+const (
+	PromQLResultTypeVector = "vector"
+	PromQLResultTypeMatrix = "matrix"
+	PromQLResultTypeScalar = "scalar"
+)
+
+// PromQLResult is the result of a PromQL query against log-cache, only the field matching the ResultType is set
+type PromQLResult struct {
+	ResultType string
+
+	// Vector has a sample per series for an instant query
+	Vector []PromQLSample
+
+	// Matrix has the samples of each series for a range query
+	Matrix []PromQLSeries
+
+	// Scalar is the result of an instant query that evaluates to a number
+	Scalar *PromQLPoint
+}
+
+// PromQLSample is the value of a series at a single point in time
+type PromQLSample struct {
+	Metric map[string]string `json:"metric"`
+	Point  PromQLPoint       `json:"value"`
+}
+
+// PromQLSeries is the values of a series over a time range
+type PromQLSeries struct {
+	Metric map[string]string `json:"metric"`
+	Points []PromQLPoint     `json:"values"`
+}
+
+// PromQLPoint is a value at a point in time
+type PromQLPoint struct {
+	Time  time.Time
+	Value float64
+}
+
+// PromQLResponse is the Prometheus HTTP API response returned by the log-cache query endpoints
+type PromQLResponse struct {
+	Status    string `json:"status"`
+	ErrorType string `json:"errorType"`
+	Error     string `json:"error"`
+	Data      struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+// Result decodes the data of a successful response
+func (r *PromQLResponse) Result() (*PromQLResult, error) {
+	if r.Status != "success" {
+		return nil, fmt.Errorf("PromQL query failed with %s: %s", r.ErrorType, r.Error)
+	}
+	result := &PromQLResult{ResultType: r.Data.ResultType}
+	var err error
+	switch r.Data.ResultType {
+	case PromQLResultTypeVector:
+		err = json.Unmarshal(r.Data.Result, &result.Vector)
+	case PromQLResultTypeMatrix:
+		err = json.Unmarshal(r.Data.Result, &result.Matrix)
+	case PromQLResultTypeScalar:
+		err = json.Unmarshal(r.Data.Result, &result.Scalar)
+	default:
+		return nil, fmt.Errorf("unsupported PromQL result type %q", r.Data.ResultType)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error decoding PromQL %s result: %w", r.Data.ResultType, err)
+	}
+	return result, nil
+}
+
+// MarshalJSON encodes the point as a Prometheus [<unix seconds>, "<value>"] pair
+func (p PromQLPoint) MarshalJSON() ([]byte, error) {
+	seconds := float64(p.Time.UnixNano()) / float64(time.Second)
+	return json.Marshal([]any{seconds, strconv.FormatFloat(p.Value, 'f', -1, 64)})
+}
+
+// UnmarshalJSON decodes a Prometheus [<unix seconds>, "<value>"] pair
+func (p *PromQLPoint) UnmarshalJSON(b []byte) error {
+	var pair []json.RawMessage
+	if err := json.Unmarshal(b, &pair); err != nil {
+		return err
+	}
+	if len(pair) != 2 {
+		return fmt.Errorf("expected a [time, value] pair but got %d elements", len(pair))
+	}
+	var seconds float64
+	if err := json.Unmarshal(pair[0], &seconds); err != nil {
+		return fmt.Errorf("invalid sample time: %w", err)
+	}
+	var value string
+	if err := json.Unmarshal(pair[1], &value); err != nil {
+		return fmt.Errorf("invalid sample value: %w", err)
+	}
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return fmt.Errorf("invalid sample value: %w", err)
+	}
+	p.Time = time.Unix(0, int64(seconds*float64(time.Second))).UTC()
+	p.Value = v
+	return nil
+}
@@ -221,7 +221,7 @@ func SetupMultiple(mockEndpoints []MockRoute, t *testing.T) string {
 					"href": "wss://doppler.example.org:443",
 				},
 				"log_cache": map[string]any{
-					"href": "https://log-cache.example.org",
+					"href": server.URL,
 				},
 				"log_stream": map[string]any{
 					"href": "https://log-stream.example.org",
@@ -130,16 +130,7 @@ func {{ $newMethod }}() CloudFoundryError {
 // - HTTP code: {{ .HTTPCode }}
 // - message: {{ printf "%q" .Message }}
 func Is{{ .Name | cleanGoName }}Error(err error) bool {
-	cferr, ok := cloudFoundryError(err)
-	if !ok {
-		return false
-	}
-	return cferr.Code == {{ .CFCode }}
+	return errors.Is(err, CloudFoundryError{Code: {{ .CFCode }}})
 }
 {{- end }}
-
-func cloudFoundryError(err error) (cferr CloudFoundryError, ok bool) {
-	ok = errors.As(err, &cferr)
-	return cferr, ok
-}
 `))